@@ -0,0 +1,20 @@
+import { FC } from "react"
+import { Playtime } from "../../types/stats"
+import classes from "./playtimeCard.module.scss"
+
+export const formatMinutes = (minutes: number) => {
+    const hours = Math.floor(minutes / 60)
+    return hours > 0 ? `${hours} h ${minutes % 60} min` : `${minutes} min`
+}
+
+export const Card: FC<{ title: string; value: string | number; hint: string }> = ({ title, value, hint }) => (
+    <div className={classes.card}>
+        <p className={classes.label}>{title}</p>
+        <p className={classes.value}>{value}</p>
+        <p className={classes.hint}>{hint}</p>
+    </div>
+)
+
+export const PlaytimeCard: FC<{ title: string; playtime: Playtime }> = ({ title, playtime }) => (
+    <Card title={title} value={formatMinutes(playtime.minutes)} hint={`${playtime.sessions} sessions`} />
+)
@@ -5,6 +5,7 @@ const Admin = lazy(() => import("./layout/Admin"))
 const Dashboard = lazy(() => import("../pages/Dashboard/Dashboard"))
 const Games = lazy(() => import("../pages/Games/Games"))
 const Users = lazy(() => import("../pages/Users/Users"))
+const Stats = lazy(() => import("../pages/Stats/Stats"))
 const Auth = lazy(() => import("../pages/Auth/Auth"))
 const NotFound = lazy(() => import("../pages/NotFound/NotFound"))
 
@@ -19,6 +20,7 @@ export const MyRoutes = () => {
                 <Route path='users/' element={<Users />} />
             </Route>
             <Route path='/auth/' element={<Auth />} />
+            <Route path='/stats/' element={<Stats />} />
             <Route path='/*' element={<NotFound />} />
         </>
     )
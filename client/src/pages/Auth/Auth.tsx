@@ -1,13 +1,30 @@
-import { useState } from "react"
-import { Form } from "../../components/Form/Form"
+import { FormEvent, useState } from "react"
+import { useNavigate } from "react-router-dom"
 import { Input } from "../../components/Input/Input"
+import { errorMessage } from "../../services/api"
+import { signIn } from "../../services/auth"
 import classes from "./auth.module.scss"
 
 export default function Auth() {
     const [tab, setTab] = useState("singIn")
+    const [email, setEmail] = useState("")
+    const [password, setPassword] = useState("")
+    const [error, setError] = useState("")
+    const navigate = useNavigate()
 
     const changeTab = (tabName: string) => () => setTab(tabName)
 
+    const signInHandler = async (event: FormEvent) => {
+        event.preventDefault()
+        setError("")
+        try {
+            await signIn(email, password)
+            navigate("/stats/")
+        } catch (err) {
+            setError(errorMessage(err))
+        }
+    }
+
     return (
         <div className={classes.auth}>
             <div
@@ -25,26 +42,29 @@ export default function Auth() {
                             <span onClick={changeTab("singUp")}>Sign Up</span>
                         </li>
                     </ul>
+                    {tab === "singIn" && (
+                        <form className={classes.tab} onSubmit={signInHandler}>
+                            <Input
+                                id='email'
+                                name='email'
+                                value={email}
+                                onChange={event => setEmail(event.target.value)}
+                                attr={{ type: "email", placeholder: "Email", required: true }}
+                            />
+                            <Input
+                                id='password'
+                                name='password'
+                                value={password}
+                                onChange={event => setPassword(event.target.value)}
+                                attr={{ type: "password", placeholder: "Password", required: true }}
+                            />
+                            {error && <p className={classes.error}>{error}</p>}
+                            <button className={classes.submit} type='submit'>
+                                Sign in
+                            </button>
+                        </form>
+                    )}
                     {/* <transition name="slide-fade" mode="out-in">
-                    <form class="tab" v-if="isSignIn" @submit.prevent="SignIn(signIn)">
-                        <input-field
-                            id="email"
-                            name="email"
-                            type="email"
-                            labelText="Email"
-                            :errorText="signIn.errorEmail"
-                            v-model="signIn.email"
-                        />
-                        <input-field
-                            id="password"
-                            name="password"
-                            type="password"
-                            labelText="Пароль"
-                            :errorText="signIn.errorPassword"
-                            v-model="signIn.password"
-                        />
-                        <button class="submit" @click="checkFormSignIn">Sign in</button>
-                    </form>
                     <form class="tab" v-else @submit.prevent="SignUp(signUp)">
                         <input-field
                             id="name"
@@ -1,7 +1,73 @@
+import { useEffect, useState } from "react"
+import { api, DataResponse, errorMessage } from "../../services/api"
+import { Card, formatMinutes, PlaytimeCard } from "../../components/PlaytimeCard/PlaytimeCard"
+import { DashboardStats } from "../../types/stats"
+import classes from "./dashboard.module.scss"
+
 export default function Dashboard() {
+    const [stats, setStats] = useState<DashboardStats | null>(null)
+    const [error, setError] = useState("")
+
+    useEffect(() => {
+        let cancelled = false
+        api.get<DataResponse<DashboardStats>>("/stats")
+            .then(res => !cancelled && setStats(res.data.data))
+            .catch(err => !cancelled && setError(errorMessage(err)))
+        return () => {
+            cancelled = true
+        }
+    }, [])
+
+    const maxDaily = Math.max(1, ...(stats?.daily || []).map(d => d.minutes))
+
     return (
         <div className='container'>
             <h2 className='title'>Dashboard</h2>
+            {error && <p className={classes.error}>Failed to load the statistics: {error}</p>}
+            {!stats && !error && <p>Loading...</p>}
+            {stats && (
+                <>
+                    <div className={classes.cards}>
+                        <PlaytimeCard title='Total playtime' playtime={stats.total} />
+                        <PlaytimeCard title='Last 7 days' playtime={stats.week} />
+                        <PlaytimeCard title='Last month' playtime={stats.month} />
+                        <Card title='Active users' value={stats.activeUsers} hint='last month' />
+                    </div>
+
+                    <div className={classes.section}>
+                        <h3 className={classes.subtitle}>Playtime by day</h3>
+                        <ul className={classes.chart}>
+                            {stats.daily.map(d => (
+                                <li key={d.day} className={classes.bar} title={`${d.day}: ${formatMinutes(d.minutes)}`}>
+                                    <span style={{ height: `${(d.minutes / maxDaily) * 100}%` }} />
+                                </li>
+                            ))}
+                        </ul>
+                    </div>
+
+                    <div className={classes.section}>
+                        <h3 className={classes.subtitle}>Most played games</h3>
+                        <table className={classes.table}>
+                            <thead>
+                                <tr>
+                                    <th>Game</th>
+                                    <th>Playtime</th>
+                                    <th>Sessions</th>
+                                </tr>
+                            </thead>
+                            <tbody>
+                                {stats.mostPlayed.map(g => (
+                                    <tr key={g.gameId}>
+                                        <td>{g.title}</td>
+                                        <td>{formatMinutes(g.minutes)}</td>
+                                        <td>{g.sessions}</td>
+                                    </tr>
+                                ))}
+                            </tbody>
+                        </table>
+                    </div>
+                </>
+            )}
         </div>
     )
 }
@@ -0,0 +1,69 @@
+import { useEffect, useState } from "react"
+import { api, DataResponse, errorMessage } from "../../services/api"
+import { Card, formatMinutes, PlaytimeCard } from "../../components/PlaytimeCard/PlaytimeCard"
+import { UserStats } from "../../types/stats"
+import classes from "./stats.module.scss"
+
+const days = (count: number) => `${count} ${count === 1 ? "day" : "days"}`
+
+export default function Stats() {
+    const [stats, setStats] = useState<UserStats | null>(null)
+    const [error, setError] = useState("")
+
+    useEffect(() => {
+        let cancelled = false
+        api.get<DataResponse<UserStats>>("/users/me/stats")
+            .then(res => !cancelled && setStats(res.data.data))
+            .catch(err => !cancelled && setError(errorMessage(err)))
+        return () => {
+            cancelled = true
+        }
+    }, [])
+
+    return (
+        <div className='container'>
+            <h2 className='title'>My statistics</h2>
+            {error && <p className={classes.error}>Failed to load the statistics: {error}</p>}
+            {!stats && !error && <p>Loading...</p>}
+            {stats && (
+                <>
+                    <div className={classes.cards}>
+                        <PlaytimeCard title='Total playtime' playtime={stats.total} />
+                        <PlaytimeCard title='Last 7 days' playtime={stats.week} />
+                        <PlaytimeCard title='Last month' playtime={stats.month} />
+                        <Card
+                            title='Current streak'
+                            value={days(stats.currentStreak)}
+                            hint={`longest ${days(stats.longestStreak)}`}
+                        />
+                    </div>
+
+                    <div className={classes.section}>
+                        <h3 className={classes.subtitle}>Most played games</h3>
+                        {stats.mostPlayed.length === 0 && <p>No play sessions yet</p>}
+                        {stats.mostPlayed.length > 0 && (
+                            <table className={classes.table}>
+                                <thead>
+                                    <tr>
+                                        <th>Game</th>
+                                        <th>Playtime</th>
+                                        <th>Sessions</th>
+                                    </tr>
+                                </thead>
+                                <tbody>
+                                    {stats.mostPlayed.map(g => (
+                                        <tr key={g.gameId}>
+                                            <td>{g.title}</td>
+                                            <td>{formatMinutes(g.minutes)}</td>
+                                            <td>{g.sessions}</td>
+                                        </tr>
+                                    ))}
+                                </tbody>
+                            </table>
+                        )}
+                    </div>
+                </>
+            )}
+        </div>
+    )
+}
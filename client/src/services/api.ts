@@ -0,0 +1,53 @@
+import axios, { AxiosRequestConfig } from "axios"
+
+// the access token saved by the sign in
+export const accessTokenKey = "accessToken"
+
+export const api = axios.create({
+    baseURL: "/api",
+})
+
+api.interceptors.request.use(config => {
+    const token = localStorage.getItem(accessTokenKey)
+    if (token) {
+        config.headers = { ...config.headers, Authorization: `Bearer ${token}` }
+    }
+    return config
+})
+
+// the expired access token is refreshed by the cookie once, then the request is repeated
+api.interceptors.response.use(undefined, async error => {
+    const config = error.config as (AxiosRequestConfig & { retried?: boolean }) | undefined
+    if (!config || config.retried || error.response?.status !== 401 || config.url?.startsWith("/auth/")) {
+        throw error
+    }
+    config.retried = true
+
+    try {
+        const res = await api.post<{ data: { accessToken: string } }>("/auth/refresh")
+        localStorage.setItem(accessTokenKey, res.data.data.accessToken)
+    } catch {
+        localStorage.removeItem(accessTokenKey)
+        throw error
+    }
+    return api(config)
+})
+
+export type DataResponse<T> = {
+    data: T
+    count: number
+}
+
+export type ErrorResponse = {
+    code?: string
+    message: string
+}
+
+// errorMessage returns the message of the api error or of the failed request
+export const errorMessage = (error: unknown): string => {
+    if (axios.isAxiosError(error)) {
+        const data = error.response?.data as ErrorResponse | undefined
+        return data?.message || error.message
+    }
+    return String(error)
+}
@@ -0,0 +1,19 @@
+import { accessTokenKey, api, DataResponse } from "./api"
+
+export type Token = {
+    accessToken: string
+}
+
+// signIn saves the access token, the refresh token is kept by the server in the cookie
+export const signIn = async (email: string, password: string) => {
+    const res = await api.post<DataResponse<Token>>("/auth/sign-in", { email, password })
+    localStorage.setItem(accessTokenKey, res.data.data.accessToken)
+}
+
+export const signOut = async () => {
+    try {
+        await api.post("/auth/sign-out")
+    } finally {
+        localStorage.removeItem(accessTokenKey)
+    }
+}
@@ -0,0 +1,36 @@
+export type Playtime = {
+    minutes: number
+    sessions: number
+}
+
+export type GamePlaytime = {
+    gameId: string
+    title: string
+    minutes: number
+    sessions: number
+}
+
+export type DayPlaytime = {
+    day: string
+    minutes: number
+}
+
+// the statistics of all users returned by GET /api/stats
+export type DashboardStats = {
+    total: Playtime
+    week: Playtime
+    month: Playtime
+    activeUsers: number
+    mostPlayed: GamePlaytime[]
+    daily: DayPlaytime[]
+}
+
+// the statistics of the signed in user returned by GET /api/users/me/stats
+export type UserStats = {
+    total: Playtime
+    week: Playtime
+    month: Playtime
+    currentStreak: number
+    longestStreak: number
+    mostPlayed: GamePlaytime[]
+}
@@ -3,11 +3,11 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"github.com/Alexander272/games-library/internal/config"
 	"github.com/Alexander272/games-library/internal/repository"
@@ -18,9 +18,17 @@ import (
 	"github.com/Alexander272/games-library/pkg/database/mongo"
 	"github.com/Alexander272/games-library/pkg/database/redis"
 	"github.com/Alexander272/games-library/pkg/hasher"
+	"github.com/Alexander272/games-library/pkg/health"
+	"github.com/Alexander272/games-library/pkg/lifecycle"
+	"github.com/Alexander272/games-library/pkg/limiter"
 	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/Alexander272/games-library/pkg/metrics"
+	"github.com/Alexander272/games-library/pkg/notifier"
 	"github.com/Alexander272/games-library/pkg/storage"
+	"github.com/Alexander272/games-library/pkg/tracing"
 	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 // @title Games Library
@@ -35,17 +43,41 @@ import (
 // @name Authorization
 
 func main() {
+	printConfig := flag.Bool("print-config", false, "print the configuration with the secrets redacted and exit")
+	flag.Parse()
+
 	logger.Init(os.Stdout)
 	if err := godotenv.Load(); err != nil {
 		logger.Fatalf("error loading env variables: %s", err.Error())
 	}
+	if *printConfig {
+		runPrintConfig()
+		return
+	}
 	conf, err := config.Init("configs")
 	if err != nil {
 		logger.Fatalf("error initializing configs: %s", err.Error())
 	}
+	if err := logger.Configure(logger.Config{Level: conf.Log.Level, Format: conf.Log.Format, Redact: conf.Log.Redact}); err != nil {
+		logger.Fatalf("error configuring logger: %s", err.Error())
+	}
+
+	shutdownTracing, err := tracing.Init(tracing.Config{
+		ServiceName: conf.Tracing.ServiceName,
+		Exporter:    conf.Tracing.Exporter,
+		Endpoint:    conf.Tracing.Endpoint,
+		Headers:     conf.Tracing.Headers,
+		Timeout:     conf.Tracing.Timeout,
+		SampleRatio: conf.Tracing.SampleRatio,
+	})
+	if err != nil {
+		logger.Fatalf("failed to initialize tracing: %s", err.Error())
+	}
 
 	// Dependencies
-	mongoClient, err := mongo.NewClient(conf.Mongo.URI, conf.Mongo.User, conf.Mongo.Password)
+	mongoClient, err := mongo.NewClient(conf.Mongo.URI, conf.Mongo.User, conf.Mongo.Password, options.Client().
+		SetMonitor(tracing.NewMongoMonitor()).
+		SetPoolMonitor(metrics.NewMongoPoolMonitor()))
 	if err != nil {
 		logger.Fatalf("failed to initialize db: %s", err.Error())
 	}
@@ -60,6 +92,9 @@ func main() {
 	if err != nil {
 		logger.Fatalf("failed to initialize redis %s", err.Error())
 	}
+	client.AddHook(tracing.RedisHook{})
+	metrics.RegisterRedisPool(client)
+	metrics.RegisterRuntime()
 
 	hasher := hasher.NewBcryptHasher(conf.Auth.Bcrypt.MinCost, conf.Auth.Bcrypt.DefaultCost, conf.Auth.Bcrypt.MaxCost)
 	tokenManager, err := auth.NewManager(conf.Auth.JWT.Key)
@@ -73,42 +108,120 @@ func main() {
 	}
 
 	// Services, Repos & API Handlers
+	background := lifecycle.NewBackground()
 	repos := repository.NewRepo(db, client)
+	if err := repos.EnsureIndexes(context.Background()); err != nil {
+		logger.Fatalf("failed to create db indexes: %s", err.Error())
+	}
+	if err := repos.Merge.Prepare(context.Background()); err != nil {
+		logger.Fatalf("failed to prepare merge of games: %s", err.Error())
+	}
 	services := service.NewServices(service.Deps{
 		Repos:           repos,
 		StorageProvider: storage,
+		Notifier:        notifier.NewLogNotifier(),
 		Hasher:          hasher,
 		TokenManager:    tokenManager,
 		AccessTokenTTL:  conf.Auth.JWT.AccessTokenTTL,
 		RefreshTokenTTL: conf.Auth.JWT.RefreshTokenTTL,
+		CacheTTL:        conf.Cache.TTL,
+		PriceFeedDir:    conf.Prices.FeedDir,
 		Domain:          conf.Http.Domain,
+		Background:      background,
 	})
-	handlers := transport.NewHandler(services)
+	if err := services.Company.Sync(context.Background()); err != nil {
+		logger.Errorf("failed to create companies of games: %s", err.Error())
+	}
+	limiterBackend, err := limiter.NewBackend(conf.Limiter.Backend, client, conf.Limiter.TTL)
+	if err != nil {
+		logger.Fatalf("failed to initialize limiter: %s", err.Error())
+	}
+	probes := health.New(conf.Health.Timeout,
+		health.Check{Name: "mongo", Check: func(ctx context.Context) error { return mongoClient.Ping(ctx, readpref.Primary()) }},
+		health.Check{Name: "redis", Check: func(ctx context.Context) error { return client.Ping(ctx).Err() }},
+		health.Check{Name: "storage", Check: storage.Ping},
+	)
+	handlers := transport.NewHandler(services, limiterBackend, probes)
+
+	// The components are stopped in reverse order: the readiness fails first, so no new requests come,
+	// then the servers finish the requests, the workers stop and the connections are closed last.
+	app := lifecycle.New(conf.Shutdown.Timeout)
+	app.Append(
+		lifecycle.Hook{Name: "tracing", Stop: shutdownTracing},
+		lifecycle.Hook{Name: "mongo", Stop: mongoClient.Disconnect},
+		lifecycle.Hook{Name: "redis", Stop: func(context.Context) error { return client.Close() }},
+	)
+
+	// Background jobs
+	app.Append(
+		worker("reminder", func(ctx context.Context) { services.Reminder.Run(ctx, conf.Lending.OverdueInterval) }),
+		worker("recommendations", func(ctx context.Context) { services.Calculation.Run(ctx, conf.Recommendation.Interval) }),
+		worker("price feed", func(ctx context.Context) { services.PriceFeed.Run(ctx, conf.Prices.Interval) }),
+		worker("duplicates", func(ctx context.Context) { services.Detection.Run(ctx, conf.Duplicates.Interval) }),
+		background.Hook(),
+	)
+
+	if conf.Metrics.Enabled {
+		mux := http.NewServeMux()
+		mux.Handle(conf.Metrics.Path, metrics.Handler())
+		app.Append(serverHook("admin server", server.NewAdminServer(conf, mux)))
+		logger.Infof("Metrics are exposed on port: %s", conf.Metrics.Port)
+	}
 
 	// HTTP Server
-	srv := server.NewServer(conf, handlers.Init(conf))
-	go func() {
-		if err := srv.Run(); !errors.Is(err, http.ErrServerClosed) {
-			logger.Fatalf("error occurred while running http server: %s\n", err.Error())
-		}
-	}()
+	app.Append(serverHook("http server", server.NewServer(conf, handlers.Init(conf))))
+	app.Append(lifecycle.Hook{
+		Name: "readiness",
+		Stop: func(ctx context.Context) error {
+			probes.Shutdown()
+			return lifecycle.Sleep(ctx, conf.Health.ShutdownDelay)
+		},
+	})
 	logger.Infof("Application started on port: %s", conf.Http.Port)
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
-
-	<-quit
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
 
-	const timeout = 5 * time.Second
+	if err := app.Run(ctx); err != nil {
+		logger.Fatalf("application stopped with errors: %s", err.Error())
+	}
+	logger.Info("Application stopped")
+}
 
-	ctx, shutdown := context.WithTimeout(context.Background(), timeout)
-	defer shutdown()
+// runPrintConfig prints the config the application would run with and reports its invalid fields.
+func runPrintConfig() {
+	conf, err := config.Load("configs")
+	if err != nil {
+		logger.Fatalf("error loading configs: %s", err.Error())
+	}
+	if err := config.Print(os.Stdout, conf); err != nil {
+		logger.Fatalf("error printing configs: %s", err.Error())
+	}
+	if err := conf.Validate(); err != nil {
+		logger.Fatalf("%s", err.Error())
+	}
+}
 
-	if err := srv.Stop(ctx); err != nil {
-		logger.Errorf("failed to stop server: %v", err)
+// worker runs the job until the application stops.
+func worker(name string, run func(ctx context.Context)) lifecycle.Hook {
+	return lifecycle.Hook{
+		Name: name,
+		Run: func(ctx context.Context) error {
+			run(ctx)
+			return nil
+		},
 	}
+}
 
-	if err := mongoClient.Disconnect(context.Background()); err != nil {
-		logger.Errorf("error occured on db connection close: %s", err.Error())
+func serverHook(name string, srv *server.Server) lifecycle.Hook {
+	return lifecycle.Hook{
+		Name: name,
+		Run: func(context.Context) error {
+			if err := srv.Run(); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		Stop: srv.Stop,
 	}
 }
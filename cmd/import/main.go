@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Alexander272/games-library/internal/config"
+	"github.com/Alexander272/games-library/internal/game"
+	"github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/internal/repository"
+	"github.com/Alexander272/games-library/pkg/database/mongo"
+	"github.com/Alexander272/games-library/pkg/lifecycle"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/joho/godotenv"
+)
+
+// Imports games from the csv or jsonl file.
+//
+//	go run ./cmd/import -file games.csv
+//	go run ./cmd/import -file games.txt -format jsonl
+func main() {
+	path := flag.String("file", "", "path to the csv or jsonl file")
+	format := flag.String("format", "", "file format (csv, jsonl). By default it is taken from the file extension")
+	flag.Parse()
+
+	logger.Init(os.Stdout)
+	if *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(*path)), ".")
+	}
+
+	if err := godotenv.Load(); err != nil {
+		logger.Fatalf("error loading env variables: %s", err.Error())
+	}
+	// the import uses only the db, redis and the file storage may be not configured
+	conf, err := config.Init("configs", "log", "mongo")
+	if err != nil {
+		logger.Fatalf("error initializing configs: %s", err.Error())
+	}
+	if err := logger.Configure(logger.Config{Level: conf.Log.Level, Format: conf.Log.Format, Redact: conf.Log.Redact}); err != nil {
+		logger.Fatalf("error configuring logger: %s", err.Error())
+	}
+
+	mongoClient, err := mongo.NewClient(conf.Mongo.URI, conf.Mongo.User, conf.Mongo.Password)
+	if err != nil {
+		logger.Fatalf("failed to initialize db: %s", err.Error())
+	}
+	defer func() {
+		if err := mongoClient.Disconnect(context.Background()); err != nil {
+			logger.Errorf("error occured on db connection close: %s", err.Error())
+		}
+	}()
+
+	repos := repository.NewImportRepo(mongoClient.Database(conf.Mongo.Name))
+	if err := repos.EnsureIndexes(context.Background()); err != nil {
+		logger.Fatalf("failed to create db indexes: %s", err.Error())
+	}
+	importer := game.NewImportService(repos.Game, repos.Redirect, repos.Company, repos.Import, lifecycle.NewBackground())
+
+	file, err := os.Open(*path)
+	if err != nil {
+		logger.Fatalf("failed to open file: %s", err.Error())
+	}
+	defer file.Close()
+
+	report, err := importer.Import(context.Background(), *format, file, func(r models.ImportReport) {
+		logger.Infof("processed %d rows", r.Processed)
+	})
+	if err != nil {
+		logger.Errorf("failed to import games: %s", err.Error())
+		return
+	}
+
+	for _, e := range report.Errors {
+		fmt.Println(e.Error())
+	}
+	fmt.Printf("processed: %d, created: %d, updated: %d, failed: %d\n", report.Processed, report.Created, report.Updated, report.Failed)
+}
@@ -0,0 +1,32 @@
+package achievement
+
+import (
+	"github.com/Alexander272/games-library/internal/achievement/repository"
+	"github.com/Alexander272/games-library/internal/achievement/service"
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/pkg/storage"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IAchievementRepo interface {
+	repository.IAchievement
+}
+type IUnlockRepo interface {
+	repository.IUnlock
+}
+
+type IAchievementService interface {
+	service.IAchievement
+}
+
+func NewAchievementRepo(db *mongo.Database, collection string) IAchievementRepo {
+	return repository.NewAchievementRepo(db, collection)
+}
+func NewUnlockRepo(db *mongo.Database, collection, achievementsCollection, gamesCollection string) IUnlockRepo {
+	return repository.NewUnlockRepo(db, collection, achievementsCollection, gamesCollection)
+}
+
+func NewAchievementService(repo repository.IAchievement, unlocks repository.IUnlock, games gameRepo.IGame,
+	storage storage.Provider) IAchievementService {
+	return service.NewAchievementService(repo, unlocks, games, storage)
+}
@@ -0,0 +1,77 @@
+package models
+
+import "time"
+
+const (
+	hiddenName        = "Hidden achievement"
+	hiddenDescription = "Unlock it to see the description"
+)
+
+type Achievement struct {
+	Id          string `json:"id" bson:"_id,omitempty"`
+	GameId      string `json:"gameId" bson:"gameId"`
+	Name        string `json:"name" bson:"name,omitempty"`
+	Description string `json:"description" bson:"description,omitempty"`
+	Icon        string `json:"icon" bson:"icon,omitempty"`
+	IconName    string `json:"-" bson:"iconName,omitempty"`
+	Hidden      bool   `json:"hidden" bson:"hidden"`
+}
+
+// Mask hides the name and the description of the hidden achievement.
+func (a Achievement) Mask() Achievement {
+	if a.Hidden {
+		a.Name = hiddenName
+		a.Description = hiddenDescription
+		a.Icon = ""
+	}
+	return a
+}
+
+func NewAchievement(dto AchievementDTO) Achievement {
+	hidden := false
+	if dto.Hidden != nil {
+		hidden = *dto.Hidden
+	}
+	return Achievement{
+		Id:          dto.Id,
+		GameId:      dto.GameId,
+		Name:        dto.Name,
+		Description: dto.Description,
+		Hidden:      hidden,
+	}
+}
+
+type AchievementDTO struct {
+	Id          string `json:"-"`
+	GameId      string `json:"-"`
+	Name        string `json:"name" binding:"required,max=128"`
+	Description string `json:"description" binding:"max=1024"`
+	Hidden      *bool  `json:"hidden"`
+}
+
+type UnlockDTO struct {
+	UserId        string    `json:"-"`
+	AchievementId string    `json:"-"`
+	UnlockedAt    time.Time `json:"unlockedAt"`
+}
+
+// Unlocked is the achievement unlocked by the user.
+type Unlocked struct {
+	UserId      string      `json:"userId" bson:"userId"`
+	UnlockedAt  time.Time   `json:"unlockedAt" bson:"unlockedAt"`
+	Achievement Achievement `json:"achievement" bson:"achievement"`
+}
+
+// Progress is the completion of the game by the user.
+type Progress struct {
+	GameId       string    `json:"gameId" bson:"_id"`
+	Title        string    `json:"title" bson:"title"`
+	Unlocked     int       `json:"unlocked" bson:"unlocked"`
+	Total        int       `json:"total" bson:"total"`
+	Percent      float64   `json:"percent" bson:"percent"`
+	LastUnlocked time.Time `json:"lastUnlocked" bson:"lastUnlocked"`
+}
+
+type FeedFilter struct {
+	Limit int64 `form:"limit" binding:"omitempty,min=1,max=100"`
+}
@@ -0,0 +1,8 @@
+package models
+
+import "github.com/Alexander272/games-library/pkg/apperror"
+
+var (
+	ErrAchievementNotFound = apperror.NotFound("ACHIEVEMENT_NOT_FOUND", "achievement doesn't exists")
+	ErrNotUnlocked         = apperror.NotFound("ACHIEVEMENT_NOT_UNLOCKED", "achievement isn't unlocked")
+)
@@ -0,0 +1,131 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Alexander272/games-library/internal/achievement/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AchievementRepo struct {
+	db *mongo.Collection
+}
+
+func NewAchievementRepo(db *mongo.Database, collection string) *AchievementRepo {
+	return &AchievementRepo{
+		db: db.Collection(collection),
+	}
+}
+
+func (r *AchievementRepo) Create(ctx context.Context, achievement models.Achievement) (id string, err error) {
+	gameId, err := primitive.ObjectIDFromHex(achievement.GameId)
+	if err != nil {
+		return id, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	doc := bson.M{
+		"gameId":      gameId,
+		"name":        achievement.Name,
+		"description": achievement.Description,
+		"hidden":      achievement.Hidden,
+	}
+	res, err := r.db.InsertOne(ctx, doc)
+	if err != nil {
+		return id, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	oid, ok := res.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return id, fmt.Errorf("failed to convert objectid")
+	}
+	logger.Tracef("Created document with oid %s.\n", oid)
+	return oid.Hex(), nil
+}
+
+func (r *AchievementRepo) GetByGame(ctx context.Context, gameId string) (achievements []models.Achievement, err error) {
+	oid, err := primitive.ObjectIDFromHex(gameId)
+	if err != nil {
+		return achievements, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+	cur, err := r.db.Find(ctx, bson.M{"gameId": oid}, opts)
+	if err != nil {
+		return achievements, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &achievements); err != nil {
+		return achievements, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return achievements, nil
+}
+
+func (r *AchievementRepo) GetById(ctx context.Context, achievementId string) (achievement models.Achievement, err error) {
+	oid, err := primitive.ObjectIDFromHex(achievementId)
+	if err != nil {
+		return achievement, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	res := r.db.FindOne(ctx, bson.M{"_id": oid})
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return achievement, models.ErrAchievementNotFound
+		}
+		return achievement, fmt.Errorf("failed to execute query. error: %w", res.Err())
+	}
+	if err := res.Decode(&achievement); err != nil {
+		return achievement, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return achievement, nil
+}
+
+func (r *AchievementRepo) Update(ctx context.Context, achievement models.Achievement) error {
+	oid, err := primitive.ObjectIDFromHex(achievement.Id)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	update := bson.M{"$set": bson.M{
+		"name":        achievement.Name,
+		"description": achievement.Description,
+		"icon":        achievement.Icon,
+		"iconName":    achievement.IconName,
+		"hidden":      achievement.Hidden,
+	}}
+
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrAchievementNotFound
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+func (r *AchievementRepo) Remove(ctx context.Context, achievementId string) error {
+	oid, err := primitive.ObjectIDFromHex(achievementId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	res, err := r.db.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return models.ErrAchievementNotFound
+	}
+
+	logger.Tracef("Delete %v documents.\n", res.DeletedCount)
+	return nil
+}
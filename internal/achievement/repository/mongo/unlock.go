@@ -0,0 +1,247 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/achievement/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const defaultFeedLimit = 20
+
+type UnlockRepo struct {
+	db           *mongo.Collection
+	achievements string
+	games        string
+}
+
+func NewUnlockRepo(db *mongo.Database, collection, achievementsCollection, gamesCollection string) *UnlockRepo {
+	return &UnlockRepo{
+		db:           db.Collection(collection),
+		achievements: achievementsCollection,
+		games:        gamesCollection,
+	}
+}
+
+// Unlock marks the achievement as unlocked by the user. Repeated calls keep the first unlock time.
+func (r *UnlockRepo) Unlock(ctx context.Context, userId string, achievement models.Achievement, unlockedAt time.Time) error {
+	uid, aid, gid, err := toObjectIds(userId, achievement.Id, achievement.GameId)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"userId": uid, "achievementId": aid}
+	update := bson.M{"$setOnInsert": bson.M{
+		"userId":        uid,
+		"achievementId": aid,
+		"gameId":        gid,
+		"unlockedAt":    unlockedAt,
+	}}
+
+	res, err := r.db.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Matched %v documents and upserted %v documents.\n", res.MatchedCount, res.UpsertedCount)
+	return nil
+}
+
+func (r *UnlockRepo) Lock(ctx context.Context, userId, achievementId string) error {
+	uid, aid, err := toObjectIdPair(userId, achievementId)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.db.DeleteOne(ctx, bson.M{"userId": uid, "achievementId": aid})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return models.ErrNotUnlocked
+	}
+
+	logger.Tracef("Delete %v documents.\n", res.DeletedCount)
+	return nil
+}
+
+func (r *UnlockRepo) RemoveByAchievement(ctx context.Context, achievementId string) error {
+	aid, err := primitive.ObjectIDFromHex(achievementId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	res, err := r.db.DeleteMany(ctx, bson.M{"achievementId": aid})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Delete %v documents.\n", res.DeletedCount)
+	return nil
+}
+
+// GetUnlockedIds returns ids of the game achievements unlocked by the user.
+func (r *UnlockRepo) GetUnlockedIds(ctx context.Context, userId, gameId string) (ids map[string]bool, err error) {
+	uid, gid, err := toObjectIdPair(userId, gameId)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetProjection(bson.M{"achievementId": 1})
+	cur, err := r.db.Find(ctx, bson.M{"userId": uid, "gameId": gid}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	var docs []struct {
+		AchievementId primitive.ObjectID `bson:"achievementId"`
+	}
+	if err := cur.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	ids = make(map[string]bool, len(docs))
+	for _, d := range docs {
+		ids[d.AchievementId.Hex()] = true
+	}
+	return ids, nil
+}
+
+// GetProgress calculates the completion of every game the user has unlocked achievements in.
+// If gameId is not empty only this game is calculated.
+func (r *UnlockRepo) GetProgress(ctx context.Context, userId, gameId string) (progress []models.Progress, err error) {
+	uid, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return progress, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	match := bson.M{"userId": uid}
+	if gameId != "" {
+		gid, err := primitive.ObjectIDFromHex(gameId)
+		if err != nil {
+			return progress, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+		}
+		match["gameId"] = gid
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":          "$gameId",
+			"unlocked":     bson.M{"$sum": 1},
+			"lastUnlocked": bson.M{"$max": "$unlockedAt"},
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.achievements,
+			"localField":   "_id",
+			"foreignField": "gameId",
+			"as":           "all",
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.games,
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "game",
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"title":        bson.M{"$arrayElemAt": bson.A{"$game.title", 0}},
+			"unlocked":     1,
+			"lastUnlocked": 1,
+			"total":        bson.M{"$size": "$all"},
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"percent": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$total", 0}},
+				bson.M{"$round": bson.A{bson.M{"$multiply": bson.A{bson.M{"$divide": bson.A{"$unlocked", "$total"}}, 100}}, 1}},
+				0,
+			}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "lastUnlocked", Value: -1}}}},
+	}
+
+	cur, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return progress, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &progress); err != nil {
+		return progress, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return progress, nil
+}
+
+// GetRecentByUser returns the last achievements unlocked by the user.
+func (r *UnlockRepo) GetRecentByUser(ctx context.Context, userId string, limit int64) ([]models.Unlocked, error) {
+	uid, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	return r.getRecent(ctx, bson.M{"userId": uid}, limit)
+}
+
+// GetRecentByGame returns the last achievements of the game unlocked by any user.
+func (r *UnlockRepo) GetRecentByGame(ctx context.Context, gameId string, limit int64) ([]models.Unlocked, error) {
+	gid, err := primitive.ObjectIDFromHex(gameId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	return r.getRecent(ctx, bson.M{"gameId": gid}, limit)
+}
+
+func (r *UnlockRepo) getRecent(ctx context.Context, match bson.M, limit int64) (unlocked []models.Unlocked, err error) {
+	if limit <= 0 {
+		limit = defaultFeedLimit
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "unlockedAt", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.achievements,
+			"localField":   "achievementId",
+			"foreignField": "_id",
+			"as":           "achievement",
+		}}},
+		{{Key: "$unwind", Value: "$achievement"}},
+	}
+
+	cur, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return unlocked, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &unlocked); err != nil {
+		return unlocked, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return unlocked, nil
+}
+
+func toObjectIdPair(first, second string) (primitive.ObjectID, primitive.ObjectID, error) {
+	firstOid, err := primitive.ObjectIDFromHex(first)
+	if err != nil {
+		return firstOid, primitive.NilObjectID, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	secondOid, err := primitive.ObjectIDFromHex(second)
+	if err != nil {
+		return firstOid, secondOid, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	return firstOid, secondOid, nil
+}
+
+func toObjectIds(first, second, third string) (primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, error) {
+	firstOid, secondOid, err := toObjectIdPair(first, second)
+	if err != nil {
+		return firstOid, secondOid, primitive.NilObjectID, err
+	}
+	thirdOid, err := primitive.ObjectIDFromHex(third)
+	if err != nil {
+		return firstOid, secondOid, thirdOid, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	return firstOid, secondOid, thirdOid, nil
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/achievement/models"
+	achievement "github.com/Alexander272/games-library/internal/achievement/repository/mongo"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IAchievement interface {
+	Create(ctx context.Context, achievement models.Achievement) (string, error)
+	GetByGame(ctx context.Context, gameId string) ([]models.Achievement, error)
+	GetById(ctx context.Context, achievementId string) (models.Achievement, error)
+	Update(ctx context.Context, achievement models.Achievement) error
+	Remove(ctx context.Context, achievementId string) error
+}
+
+type IUnlock interface {
+	Unlock(ctx context.Context, userId string, achievement models.Achievement, unlockedAt time.Time) error
+	Lock(ctx context.Context, userId, achievementId string) error
+	RemoveByAchievement(ctx context.Context, achievementId string) error
+	GetUnlockedIds(ctx context.Context, userId, gameId string) (map[string]bool, error)
+	GetProgress(ctx context.Context, userId, gameId string) ([]models.Progress, error)
+	GetRecentByUser(ctx context.Context, userId string, limit int64) ([]models.Unlocked, error)
+	GetRecentByGame(ctx context.Context, gameId string, limit int64) ([]models.Unlocked, error)
+}
+
+func NewAchievementRepo(db *mongo.Database, collection string) IAchievement {
+	return achievement.NewAchievementRepo(db, collection)
+}
+
+func NewUnlockRepo(db *mongo.Database, collection, achievementsCollection, gamesCollection string) IUnlock {
+	return achievement.NewUnlockRepo(db, collection, achievementsCollection, gamesCollection)
+}
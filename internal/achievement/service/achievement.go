@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/achievement/models"
+	"github.com/Alexander272/games-library/internal/achievement/repository"
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/Alexander272/games-library/pkg/storage"
+)
+
+const iconsPath = "achievements"
+
+type AchievementService struct {
+	repo    repository.IAchievement
+	unlocks repository.IUnlock
+	games   gameRepo.IGame
+	storage storage.Provider
+}
+
+func NewAchievementService(repo repository.IAchievement, unlocks repository.IUnlock, games gameRepo.IGame,
+	storage storage.Provider) *AchievementService {
+	return &AchievementService{
+		repo:    repo,
+		unlocks: unlocks,
+		games:   games,
+		storage: storage,
+	}
+}
+
+func (s *AchievementService) Create(ctx context.Context, dto models.AchievementDTO) (id string, err error) {
+	if _, err := s.games.GetById(ctx, dto.GameId); err != nil {
+		if errors.Is(err, gameModels.ErrGameNotFound) {
+			return id, err
+		}
+		return id, fmt.Errorf("failed to get game by id. error: %w", err)
+	}
+
+	id, err = s.repo.Create(ctx, models.NewAchievement(dto))
+	if err != nil {
+		return id, fmt.Errorf("failed to create achievement. error: %w", err)
+	}
+	return id, nil
+}
+
+// GetByGame returns achievements of the game. Hidden achievements are masked unless
+// they are unlocked by the user. The user id may be empty for anonymous requests.
+func (s *AchievementService) GetByGame(ctx context.Context, gameId, userId string) (achievements []models.Achievement, err error) {
+	achievements, err = s.repo.GetByGame(ctx, gameId)
+	if err != nil {
+		return achievements, fmt.Errorf("failed to get achievements. error: %w", err)
+	}
+	if len(achievements) == 0 {
+		return achievements, models.ErrAchievementNotFound
+	}
+
+	unlocked := map[string]bool{}
+	if userId != "" {
+		unlocked, err = s.unlocks.GetUnlockedIds(ctx, userId, gameId)
+		if err != nil {
+			return achievements, fmt.Errorf("failed to get unlocked achievements. error: %w", err)
+		}
+	}
+
+	for i, a := range achievements {
+		if !unlocked[a.Id] {
+			achievements[i] = a.Mask()
+		}
+	}
+	return achievements, nil
+}
+
+func (s *AchievementService) Update(ctx context.Context, dto models.AchievementDTO) error {
+	achievement, err := s.repo.GetById(ctx, dto.Id)
+	if err != nil {
+		if errors.Is(err, models.ErrAchievementNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to get achievement. error: %w", err)
+	}
+
+	achievement.Name = dto.Name
+	achievement.Description = dto.Description
+	if dto.Hidden != nil {
+		achievement.Hidden = *dto.Hidden
+	}
+
+	if err := s.repo.Update(ctx, achievement); err != nil {
+		if errors.Is(err, models.ErrAchievementNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to update achievement. error: %w", err)
+	}
+	return nil
+}
+
+// SetIcon uploads the icon to the file storage and replaces the previous one.
+func (s *AchievementService) SetIcon(ctx context.Context, achievementId string, file multipart.File, header *multipart.FileHeader) (url string, err error) {
+	achievement, err := s.repo.GetById(ctx, achievementId)
+	if err != nil {
+		if errors.Is(err, models.ErrAchievementNotFound) {
+			return url, err
+		}
+		return url, fmt.Errorf("failed to get achievement. error: %w", err)
+	}
+
+	path := fmt.Sprintf("%s/%s", iconsPath, achievement.GameId)
+	icon, err := s.storage.Upload(ctx, file, header, path, achievement.Id)
+	if err != nil {
+		return url, fmt.Errorf("failed to upload icon. error: %w", err)
+	}
+
+	if achievement.IconName != "" && achievement.IconName != icon.Name {
+		if err := s.storage.Remove(ctx, path, achievement.IconName); err != nil {
+			logger.Errorf("failed to remove old icon. error: %s", err.Error())
+		}
+	}
+
+	achievement.Icon = icon.Url
+	achievement.IconName = icon.Name
+	if err := s.repo.Update(ctx, achievement); err != nil {
+		return url, fmt.Errorf("failed to update achievement. error: %w", err)
+	}
+
+	return icon.Url, nil
+}
+
+func (s *AchievementService) Remove(ctx context.Context, achievementId string) error {
+	achievement, err := s.repo.GetById(ctx, achievementId)
+	if err != nil {
+		if errors.Is(err, models.ErrAchievementNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to get achievement. error: %w", err)
+	}
+
+	if achievement.IconName != "" {
+		path := fmt.Sprintf("%s/%s", iconsPath, achievement.GameId)
+		if err := s.storage.Remove(ctx, path, achievement.IconName); err != nil {
+			logger.Errorf("failed to remove icon. error: %s", err.Error())
+		}
+	}
+
+	if err := s.unlocks.RemoveByAchievement(ctx, achievementId); err != nil {
+		return fmt.Errorf("failed to remove unlocked achievements. error: %w", err)
+	}
+	if err := s.repo.Remove(ctx, achievementId); err != nil {
+		if errors.Is(err, models.ErrAchievementNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to remove achievement. error: %w", err)
+	}
+	return nil
+}
+
+func (s *AchievementService) Unlock(ctx context.Context, dto models.UnlockDTO) error {
+	achievement, err := s.repo.GetById(ctx, dto.AchievementId)
+	if err != nil {
+		if errors.Is(err, models.ErrAchievementNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to get achievement. error: %w", err)
+	}
+
+	unlockedAt := dto.UnlockedAt
+	if unlockedAt.IsZero() || unlockedAt.After(time.Now()) {
+		unlockedAt = time.Now()
+	}
+
+	if err := s.unlocks.Unlock(ctx, dto.UserId, achievement, unlockedAt); err != nil {
+		return fmt.Errorf("failed to unlock achievement. error: %w", err)
+	}
+	return nil
+}
+
+func (s *AchievementService) Lock(ctx context.Context, userId, achievementId string) error {
+	if err := s.unlocks.Lock(ctx, userId, achievementId); err != nil {
+		if errors.Is(err, models.ErrNotUnlocked) {
+			return err
+		}
+		return fmt.Errorf("failed to lock achievement. error: %w", err)
+	}
+	return nil
+}
+
+func (s *AchievementService) GetProgress(ctx context.Context, userId, gameId string) (progress []models.Progress, err error) {
+	progress, err = s.unlocks.GetProgress(ctx, userId, gameId)
+	if err != nil {
+		return progress, fmt.Errorf("failed to get progress. error: %w", err)
+	}
+	if len(progress) == 0 {
+		return progress, models.ErrAchievementNotFound
+	}
+	return progress, nil
+}
+
+func (s *AchievementService) GetRecentByUser(ctx context.Context, userId string, limit int64) (unlocked []models.Unlocked, err error) {
+	unlocked, err = s.unlocks.GetRecentByUser(ctx, userId, limit)
+	if err != nil {
+		return unlocked, fmt.Errorf("failed to get recent achievements. error: %w", err)
+	}
+	return unlocked, nil
+}
+
+// GetRecentByGame returns the feed of the game. Achievements unlocked by other users
+// stay masked if they are hidden.
+func (s *AchievementService) GetRecentByGame(ctx context.Context, gameId string, limit int64) (unlocked []models.Unlocked, err error) {
+	unlocked, err = s.unlocks.GetRecentByGame(ctx, gameId, limit)
+	if err != nil {
+		return unlocked, fmt.Errorf("failed to get recent achievements. error: %w", err)
+	}
+	for i := range unlocked {
+		unlocked[i].Achievement = unlocked[i].Achievement.Mask()
+	}
+	return unlocked, nil
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+	"mime/multipart"
+
+	"github.com/Alexander272/games-library/internal/achievement/models"
+)
+
+type IAchievement interface {
+	Create(ctx context.Context, dto models.AchievementDTO) (string, error)
+	GetByGame(ctx context.Context, gameId, userId string) ([]models.Achievement, error)
+	Update(ctx context.Context, dto models.AchievementDTO) error
+	SetIcon(ctx context.Context, achievementId string, file multipart.File, header *multipart.FileHeader) (string, error)
+	Remove(ctx context.Context, achievementId string) error
+
+	Unlock(ctx context.Context, dto models.UnlockDTO) error
+	Lock(ctx context.Context, userId, achievementId string) error
+	GetProgress(ctx context.Context, userId, gameId string) ([]models.Progress, error)
+	GetRecentByUser(ctx context.Context, userId string, limit int64) ([]models.Unlocked, error)
+	GetRecentByGame(ctx context.Context, gameId string, limit int64) ([]models.Unlocked, error)
+}
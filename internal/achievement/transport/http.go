@@ -0,0 +1,346 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/Alexander272/games-library/internal/achievement/models"
+	"github.com/Alexander272/games-library/internal/service"
+	"github.com/Alexander272/games-library/internal/transport/errcode"
+	"github.com/Alexander272/games-library/internal/transport/middleware"
+	"github.com/Alexander272/games-library/internal/transport/validation"
+	userModels "github.com/Alexander272/games-library/internal/user/models"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services   *service.Services
+	middleware *middleware.Middleware
+}
+
+func NewHandler(services *service.Services, middleware *middleware.Middleware) *Handler {
+	return &Handler{
+		services:   services,
+		middleware: middleware,
+	}
+}
+
+func (h *Handler) Init(api *gin.RouterGroup) {
+	achievements := api.Group("/games/:id/achievements", h.middleware.ObjectIds("id", "achievementId"))
+	{
+		achievements.GET("/", h.middleware.OptionalIdentity, h.getByGame)
+		achievements.GET("/recent", h.getRecentByGame)
+
+		admin := achievements.Group("", h.middleware.UserIdentity, h.middleware.AccessForRoles(userModels.RoleAdmin))
+		{
+			admin.POST("/", h.create)
+			admin.PATCH("/:achievementId", h.update)
+			admin.PUT("/:achievementId/icon", h.setIcon)
+			admin.DELETE("/:achievementId", h.remove)
+		}
+	}
+
+	my := api.Group("/users/me/achievements", h.middleware.UserIdentity, h.middleware.ObjectIds("achievementId"))
+	{
+		my.GET("/progress", h.getProgress)
+		my.GET("/recent", h.getRecentByUser)
+		my.POST("/:achievementId", h.unlock)
+		my.DELETE("/:achievementId", h.lock)
+	}
+}
+
+// @Summary Get By Game
+// @Tags achievements
+// @Description получение достижений игры. Скрытые достижения показываются только если пользователь их получил
+// @ID getAchievements
+// @Accept json
+// @Produce json
+// @Param id path string true "game id"
+// @Success 200 {object} dataResponse{data=[]models.Achievement}
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id}/achievements [get]
+func (h *Handler) getByGame(c *gin.Context) {
+	userId, _ := middleware.GetUserId(c)
+
+	achievements, err := h.services.Achievement.GetByGame(c, c.Param("id"), userId)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: achievements, Count: int64(len(achievements))})
+}
+
+// @Summary Recent By Game
+// @Tags achievements
+// @Description последние полученные достижения игры
+// @ID getRecentAchievementsByGame
+// @Accept json
+// @Produce json
+// @Param id path string true "game id"
+// @Param filter query models.FeedFilter false "filter"
+// @Success 200 {object} dataResponse{data=[]models.Unlocked}
+// @Failure 400 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id}/achievements/recent [get]
+func (h *Handler) getRecentByGame(c *gin.Context) {
+	var filter models.FeedFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(validation.Query(err))
+		return
+	}
+
+	unlocked, err := h.services.Achievement.GetRecentByGame(c, c.Param("id"), filter.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: unlocked, Count: int64(len(unlocked))})
+}
+
+// @Summary Create
+// @Security ApiKeyAuth
+// @Tags achievements
+// @Description создание достижения игры
+// @ID createAchievement
+// @Accept json
+// @Produce json
+// @Param id path string true "game id"
+// @Param achievement body models.AchievementDTO true "achievement info"
+// @Success 201 {object} idResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id}/achievements [post]
+func (h *Handler) create(c *gin.Context) {
+	var dto models.AchievementDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.GameId = c.Param("id")
+
+	id, err := h.services.Achievement.Create(c, dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, idResponse{Id: id})
+}
+
+// @Summary Update
+// @Security ApiKeyAuth
+// @Tags achievements
+// @Description обновление достижения
+// @ID updateAchievement
+// @Accept json
+// @Produce json
+// @Param id path string true "game id"
+// @Param achievementId path string true "achievement id"
+// @Param achievement body models.AchievementDTO true "achievement info"
+// @Success 200 {object} response
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id}/achievements/{achievementId} [patch]
+func (h *Handler) update(c *gin.Context) {
+	var dto models.AchievementDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.Id = c.Param("achievementId")
+	dto.GameId = c.Param("id")
+
+	if err := h.services.Achievement.Update(c, dto); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response{Message: "Achievement updated"})
+}
+
+// @Summary Set Icon
+// @Security ApiKeyAuth
+// @Tags achievements
+// @Description загрузка иконки достижения
+// @ID setAchievementIcon
+// @Accept mpfd
+// @Produce json
+// @Param id path string true "game id"
+// @Param achievementId path string true "achievement id"
+// @Param icon formData file true "icon"
+// @Success 200 {object} dataResponse{data=string}
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id}/achievements/{achievementId}/icon [put]
+func (h *Handler) setIcon(c *gin.Context) {
+	header, err := c.FormFile("icon")
+	if err != nil {
+		c.Error(errcode.ErrFileNotFound)
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		c.Error(errcode.ErrFileOpenFailed)
+		return
+	}
+	defer file.Close()
+
+	url, err := h.services.Achievement.SetIcon(c, c.Param("achievementId"), file, header)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: url})
+}
+
+// @Summary Remove
+// @Security ApiKeyAuth
+// @Tags achievements
+// @Description удаление достижения
+// @ID removeAchievement
+// @Accept json
+// @Produce json
+// @Param id path string true "game id"
+// @Param achievementId path string true "achievement id"
+// @Success 204 {object} response
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id}/achievements/{achievementId} [delete]
+func (h *Handler) remove(c *gin.Context) {
+	if err := h.services.Achievement.Remove(c, c.Param("achievementId")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusNoContent, response{Message: "Achievement removed"})
+}
+
+// @Summary Progress
+// @Security ApiKeyAuth
+// @Tags achievements
+// @Description процент выполнения достижений по играм текущего пользователя
+// @ID getAchievementsProgress
+// @Accept json
+// @Produce json
+// @Param gameId query string false "game id"
+// @Success 200 {object} dataResponse{data=[]models.Progress}
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/achievements/progress [get]
+func (h *Handler) getProgress(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	progress, err := h.services.Achievement.GetProgress(c, userId, c.Query("gameId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: progress, Count: int64(len(progress))})
+}
+
+// @Summary Recent By User
+// @Security ApiKeyAuth
+// @Tags achievements
+// @Description последние полученные достижения текущего пользователя
+// @ID getRecentAchievementsByUser
+// @Accept json
+// @Produce json
+// @Param filter query models.FeedFilter false "filter"
+// @Success 200 {object} dataResponse{data=[]models.Unlocked}
+// @Failure 400 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/achievements/recent [get]
+func (h *Handler) getRecentByUser(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var filter models.FeedFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(validation.Query(err))
+		return
+	}
+
+	unlocked, err := h.services.Achievement.GetRecentByUser(c, userId, filter.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: unlocked, Count: int64(len(unlocked))})
+}
+
+// @Summary Unlock
+// @Security ApiKeyAuth
+// @Tags achievements
+// @Description отметить достижение полученным
+// @ID unlockAchievement
+// @Accept json
+// @Produce json
+// @Param achievementId path string true "achievement id"
+// @Param unlock body models.UnlockDTO false "unlock time"
+// @Success 200 {object} response
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/achievements/{achievementId} [post]
+func (h *Handler) unlock(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto models.UnlockDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(validation.Body(err))
+			return
+		}
+	}
+	dto.UserId = userId
+	dto.AchievementId = c.Param("achievementId")
+
+	if err := h.services.Achievement.Unlock(c, dto); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response{Message: "Achievement unlocked"})
+}
+
+// @Summary Lock
+// @Security ApiKeyAuth
+// @Tags achievements
+// @Description снять отметку о получении достижения
+// @ID lockAchievement
+// @Accept json
+// @Produce json
+// @Param achievementId path string true "achievement id"
+// @Success 204 {object} response
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/achievements/{achievementId} [delete]
+func (h *Handler) lock(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.services.Achievement.Lock(c, userId, c.Param("achievementId")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusNoContent, response{Message: "Achievement locked"})
+}
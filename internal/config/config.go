@@ -10,73 +10,190 @@ import (
 
 type (
 	Config struct {
-		Environment string
-		Mongo       MongoConfig
-		Redis       RedisConfig
-		Auth        AuthConfig
-		Http        HttpConfig
-		Limiter     LimiterConfig
-		FileStorage FileStorageConfig
+		Environment    string               `mapstructure:"-"`
+		Log            LogConfig            `mapstructure:"log"`
+		Tracing        TracingConfig        `mapstructure:"tracing"`
+		Metrics        MetricsConfig        `mapstructure:"metrics"`
+		Health         HealthConfig         `mapstructure:"health"`
+		Shutdown       ShutdownConfig       `mapstructure:"shutdown"`
+		Mongo          MongoConfig          `mapstructure:"mongo"`
+		Redis          RedisConfig          `mapstructure:"redis"`
+		Auth           AuthConfig           `mapstructure:"auth"`
+		Http           HttpConfig           `mapstructure:"http"`
+		Limiter        LimiterConfig        `mapstructure:"limiter"`
+		FileStorage    FileStorageConfig    `mapstructure:"fileStorage"`
+		Lending        LendingConfig        `mapstructure:"lending"`
+		Cache          CacheConfig          `mapstructure:"cache"`
+		Recommendation RecommendationConfig `mapstructure:"recommendation"`
+		Prices         PricesConfig         `mapstructure:"prices"`
+		Duplicates     DuplicatesConfig     `mapstructure:"duplicates"`
+	}
+
+	LogConfig struct {
+		Level  string   `mapstructure:"level" validate:"oneof=trace debug info warning warn error fatal panic"`
+		Format string   `mapstructure:"format" validate:"oneof=text json"`
+		Redact []string `mapstructure:"redact"`
+	}
+
+	TracingConfig struct {
+		ServiceName string `mapstructure:"serviceName" validate:"required"`
+		Exporter    string `mapstructure:"exporter" validate:"oneof=none stdout otlp"`
+		Endpoint    string `mapstructure:"endpoint" validate:"required_if=Exporter otlp,omitempty,url"`
+		// Headers are sent with the spans, e.g. the api key of the collector
+		Headers     map[string]string `mapstructure:"headers" secret:"true"`
+		Timeout     time.Duration     `mapstructure:"timeout" validate:"gt=0"`
+		SampleRatio float64           `mapstructure:"sampleRatio" validate:"gt=0,lte=1"`
+	}
+
+	// MetricsConfig exposes the metrics on the separate admin port, so they aren't public with the api
+	MetricsConfig struct {
+		Enabled bool   `mapstructure:"enabled"`
+		Port    string `mapstructure:"port" validate:"required_if=Enabled true,omitempty,numeric"`
+		Path    string `mapstructure:"path" validate:"required_if=Enabled true,omitempty,startswith=/"`
+	}
+
+	HealthConfig struct {
+		// Timeout limits every check of the readiness
+		Timeout time.Duration `mapstructure:"timeout" validate:"gt=0"`
+		// ShutdownDelay is the time between the failing readiness and the stop of the server,
+		// so the load balancer has time to notice it
+		ShutdownDelay time.Duration `mapstructure:"shutdownDelay" validate:"gte=0"`
+	}
+
+	ShutdownConfig struct {
+		// Timeout limits the stop of all components, the servers, the workers and the connections
+		Timeout time.Duration `mapstructure:"timeout" validate:"gt=0"`
 	}
 
 	MongoConfig struct {
-		URI      string
-		User     string
-		Password string
-		Name     string `mapstructure:"databaseName"`
+		URI      string `mapstructure:"uri" validate:"required,uri" secret:"url"`
+		User     string `mapstructure:"user"`
+		Password string `mapstructure:"password" secret:"true"`
+		Name     string `mapstructure:"databaseName" validate:"required"`
 	}
 
 	RedisConfig struct {
-		Host     string `mapstructure:"host"`
-		Port     string `mapstructure:"port"`
-		DB       int    `mapstructure:"db"`
-		Password string
+		Host     string `mapstructure:"host" validate:"required"`
+		Port     string `mapstructure:"port" validate:"required,numeric"`
+		DB       int    `mapstructure:"db" validate:"gte=0"`
+		Password string `mapstructure:"password" secret:"true"`
 	}
 
 	AuthConfig struct {
-		JWT    JWTConfig
-		Bcrypt BcryptConfig
+		JWT    JWTConfig    `mapstructure:"jwt"`
+		Bcrypt BcryptConfig `mapstructure:"bcrypt"`
+		// APIKeys are the keys of the clients by their names, the clients send them in the X-API-Key header
+		APIKeys map[string]string `mapstructure:"apiKeys" validate:"dive,min=32" secret:"true"`
 	}
 
 	JWTConfig struct {
-		AccessTokenTTL  time.Duration `mapstructure:"accessTokenTTL"`
-		RefreshTokenTTL time.Duration `mapstructure:"refreshTokenTTL"`
-		Key             string
+		AccessTokenTTL  time.Duration `mapstructure:"accessTokenTTL" validate:"gt=0"`
+		RefreshTokenTTL time.Duration `mapstructure:"refreshTokenTTL" validate:"gtfield=AccessTokenTTL"`
+		// Key signs the tokens with HS256, it must have at least 256 bits
+		Key string `mapstructure:"key" validate:"required,min=32" secret:"true"`
 	}
 	BcryptConfig struct {
-		MinCost     int
-		DefaultCost int
-		MaxCost     int
+		MinCost     int `mapstructure:"minCost" validate:"min=4,max=31"`
+		DefaultCost int `mapstructure:"defaultCost" validate:"gtefield=MinCost,ltefield=MaxCost"`
+		MaxCost     int `mapstructure:"maxCost" validate:"min=4,max=31"`
 	}
 
 	FileStorageConfig struct {
-		Endpoint string
-		Bucket   string
+		// Endpoint is the path to the credentials of the firebase project
+		Endpoint string `mapstructure:"endpoint" validate:"required"`
+		Bucket   string `mapstructure:"bucket" validate:"required"`
 	}
 
 	HttpConfig struct {
 		Host               string        `mapstructure:"host"`
-		Port               string        `mapstructure:"port"`
+		Port               string        `mapstructure:"port" validate:"required,numeric"`
 		Domain             string        `mapstructure:"domain"`
-		ReadTimeout        time.Duration `mapstructure:"readTimeout"`
-		WriteTimeout       time.Duration `mapstructure:"writeTimeout"`
-		MaxHeaderMegabytes int           `mapstructure:"maxHeaderBytes"`
+		ReadTimeout        time.Duration `mapstructure:"readTimeout" validate:"gt=0"`
+		WriteTimeout       time.Duration `mapstructure:"writeTimeout" validate:"gt=0"`
+		MaxHeaderMegabytes int           `mapstructure:"maxHeaderBytes" validate:"gt=0"`
+		// ClientIPHeader is the header set by the proxy, only it is trusted: Forwarded, X-Forwarded-For or X-Real-IP
+		ClientIPHeader string `mapstructure:"clientIpHeader" validate:"oneof=Forwarded X-Forwarded-For X-Real-IP"`
+		// TrustedProxies are the networks of the proxies whose forwarding header is trusted
+		TrustedProxies []string `mapstructure:"trustedProxies" validate:"dive,cidr|ip"`
+	}
+
+	CacheConfig struct {
+		TTL time.Duration `mapstructure:"ttl" validate:"gt=0"`
+	}
+
+	RecommendationConfig struct {
+		Interval time.Duration `mapstructure:"interval" validate:"gt=0"`
+	}
+
+	DuplicatesConfig struct {
+		Interval time.Duration `mapstructure:"interval" validate:"gt=0"`
+	}
+
+	PricesConfig struct {
+		FeedDir  string        `mapstructure:"feedDir" validate:"required"`
+		Interval time.Duration `mapstructure:"interval" validate:"gt=0"`
+	}
+
+	LendingConfig struct {
+		OverdueInterval time.Duration `mapstructure:"overdueInterval" validate:"gt=0"`
 	}
 
 	LimiterConfig struct {
-		RPS   int           `mapstructure:"rps"`
-		Burst int           `mapstructure:"burst"`
-		TTL   time.Duration `mapstructure:"ttl"`
+		// Backend is memory (limits of one instance) or redis (limits shared by all instances)
+		Backend string        `mapstructure:"backend" validate:"oneof=memory redis"`
+		TTL     time.Duration `mapstructure:"ttl" validate:"gt=0"`
+		// Policies are the named limits, the default policy limits the routes without a policy
+		Policies map[string]LimitPolicyConfig `mapstructure:"policies"`
+		Routes   []LimitRouteConfig           `mapstructure:"routes" validate:"dive"`
+	}
+
+	LimitRateConfig struct {
+		Count  int           `mapstructure:"count"`
+		Period time.Duration `mapstructure:"period"`
+		Burst  int           `mapstructure:"burst"`
+	}
+
+	LimitPolicyConfig struct {
+		LimitRateConfig `mapstructure:",squash"`
+		// Authenticated is the limit of the requests with an access token, they are limited by the user id
+		Authenticated LimitRateConfig `mapstructure:"authenticated"`
+		// APIKey is the limit of the requests with an api key, they are limited by the name of the key
+		APIKey LimitRateConfig `mapstructure:"apiKey"`
+	}
+
+	LimitRouteConfig struct {
+		Method string `mapstructure:"method" validate:"required"`
+		Path   string `mapstructure:"path" validate:"required"`
+		Policy string `mapstructure:"policy" validate:"required"`
 	}
 )
 
-func Init(configDir string) (*Config, error) {
-	if err := parseConfigFile(configDir, os.Getenv("APP_ENV")); err != nil {
+// Init loads the config and validates it, all invalid fields are reported at once.
+// If the sections are passed, only they are validated, see Validate.
+func Init(configDir string, sections ...string) (*Config, error) {
+	conf, err := Load(configDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := conf.Validate(sections...); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// Load reads the config file of the environment (APP_ENV), fills the missing values with the defaults
+// and overrides them with the env variables. The config isn't validated.
+func Load(configDir string) (*Config, error) {
+	v := viper.New()
+	setDefaults(v)
+
+	if err := parseConfigFile(v, configDir, os.Getenv("APP_ENV")); err != nil {
 		return nil, err
 	}
 
 	var conf Config
-	if err := unmarhal(&conf); err != nil {
+	// the whole config is unmarshalled at once, so the defaults of the nested keys are merged with the file
+	if err := v.Unmarshal(&conf); err != nil {
 		return nil, err
 	}
 	if err := setFromEnv(&conf); err != nil {
@@ -86,42 +203,33 @@ func Init(configDir string) (*Config, error) {
 	return &conf, nil
 }
 
-func parseConfigFile(folder, env string) error {
-	viper.AddConfigPath(folder)
-	viper.SetConfigName("dev")
+func parseConfigFile(v *viper.Viper, folder, env string) error {
+	v.AddConfigPath(folder)
+	v.SetConfigName("dev")
 
 	if env == "production" {
-		viper.SetConfigName("prod")
+		v.SetConfigName("prod")
 	}
 
-	if err := viper.ReadInConfig(); err != nil {
-		return err
-	}
-
-	return viper.MergeInConfig()
+	return v.ReadInConfig()
 }
 
-func unmarhal(conf *Config) error {
-	if err := viper.UnmarshalKey("mongo", &conf.Mongo); err != nil {
+func setFromEnv(conf *Config) error {
+	if err := envconfig.Process("log", &conf.Log); err != nil {
 		return err
 	}
-	if err := viper.UnmarshalKey("redis", &conf.Redis); err != nil {
+	if err := envconfig.Process("tracing", &conf.Tracing); err != nil {
 		return err
 	}
-	if err := viper.UnmarshalKey("limiter", &conf.Limiter); err != nil {
+	if err := envconfig.Process("metrics", &conf.Metrics); err != nil {
 		return err
 	}
-	if err := viper.UnmarshalKey("http", &conf.Http); err != nil {
+	if err := envconfig.Process("health", &conf.Health); err != nil {
 		return err
 	}
-	if err := viper.UnmarshalKey("auth", &conf.Auth.JWT); err != nil {
+	if err := envconfig.Process("shutdown", &conf.Shutdown); err != nil {
 		return err
 	}
-
-	return nil
-}
-
-func setFromEnv(conf *Config) error {
 	if err := envconfig.Process("mongo", &conf.Mongo); err != nil {
 		return err
 	}
@@ -137,9 +245,18 @@ func setFromEnv(conf *Config) error {
 	if err := envconfig.Process("bcrypt", &conf.Auth.Bcrypt); err != nil {
 		return err
 	}
+	// API_KEYS is the list of the name:key pairs, e.g. "mobile:<key>,partner:<key>"
+	apiKeys := struct{ Keys map[string]string }{conf.Auth.APIKeys}
+	if err := envconfig.Process("api", &apiKeys); err != nil {
+		return err
+	}
+	conf.Auth.APIKeys = apiKeys.Keys
 	if err := envconfig.Process("storage", &conf.FileStorage); err != nil {
 		return err
 	}
+	if err := envconfig.Process("cache", &conf.Cache); err != nil {
+		return err
+	}
 	conf.Environment = os.Getenv("APP_ENV")
 
 	return nil
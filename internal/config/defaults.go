@@ -0,0 +1,64 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// defaults are used for the keys missing in the config file. The connections and the secrets
+// have no defaults, they must be set for every environment.
+var defaults = map[string]interface{}{
+	"log.level":  "info",
+	"log.format": "text",
+
+	"tracing.serviceName": "games-library",
+	"tracing.exporter":    "none",
+	"tracing.timeout":     10 * time.Second,
+	"tracing.sampleRatio": 1,
+
+	"metrics.enabled": false,
+	"metrics.port":    "9090",
+	"metrics.path":    "/metrics",
+
+	"health.timeout":       2 * time.Second,
+	"health.shutdownDelay": 0,
+
+	"shutdown.timeout": 10 * time.Second,
+
+	"redis.host": "localhost",
+	"redis.port": "6379",
+	"redis.db":   0,
+
+	"auth.jwt.accessTokenTTL":  15 * time.Minute,
+	"auth.jwt.refreshTokenTTL": 30 * 24 * time.Hour,
+	"auth.bcrypt.minCost":      bcrypt.MinCost,
+	"auth.bcrypt.defaultCost":  bcrypt.DefaultCost,
+	"auth.bcrypt.maxCost":      bcrypt.MaxCost,
+
+	"http.port":           "8080",
+	"http.readTimeout":    10 * time.Second,
+	"http.writeTimeout":   10 * time.Second,
+	"http.maxHeaderBytes": 1,
+	"http.clientIpHeader": "X-Forwarded-For",
+
+	"limiter.backend":                 "memory",
+	"limiter.ttl":                     10 * time.Minute,
+	"limiter.policies.default.count":  10,
+	"limiter.policies.default.period": time.Second,
+	"limiter.policies.default.burst":  20,
+
+	"cache.ttl":               time.Hour,
+	"lending.overdueInterval": time.Hour,
+	"recommendation.interval": 6 * time.Hour,
+	"prices.feedDir":          "feeds",
+	"prices.interval":         time.Hour,
+	"duplicates.interval":     24 * time.Hour,
+}
+
+func setDefaults(v *viper.Viper) {
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const redacted = "[REDACTED]"
+
+// Print writes the config in the format of the config file. The values of the fields with the secret tag
+// are masked: `secret:"true"` hides the whole value, `secret:"url"` hides only the password of the url.
+// The empty secrets are kept, so it's visible that they aren't set.
+func Print(w io.Writer, conf *Config) error {
+	out, err := yaml.Marshal(printable(reflect.ValueOf(*conf), ""))
+	if err != nil {
+		return fmt.Errorf("failed to marshal config. error: %w", err)
+	}
+	fmt.Fprintf(w, "# environment: %q\n", conf.Environment)
+	_, err = w.Write(out)
+	return err
+}
+
+// printable converts the value to the yaml types keeping the order of the fields.
+func printable(v reflect.Value, secret string) interface{} {
+	if secret != "" {
+		return redact(v, secret)
+	}
+
+	if d, ok := v.Interface().(time.Duration); ok {
+		return d.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		var res yaml.MapSlice
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			name, opts := parseTag(f)
+			if name == "-" {
+				continue
+			}
+			value := printable(v.Field(i), f.Tag.Get("secret"))
+			if opts == "squash" {
+				res = append(res, value.(yaml.MapSlice)...)
+				continue
+			}
+			res = append(res, yaml.MapItem{Key: name, Value: value})
+		}
+		return res
+	case reflect.Map:
+		res := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			res[fmt.Sprint(iter.Key().Interface())] = printable(iter.Value(), "")
+		}
+		return res
+	case reflect.Slice:
+		res := make([]interface{}, v.Len())
+		for i := range res {
+			res[i] = printable(v.Index(i), "")
+		}
+		return res
+	}
+	return v.Interface()
+}
+
+func redact(v reflect.Value, secret string) interface{} {
+	switch v.Kind() {
+	case reflect.String:
+		if v.String() == "" {
+			return ""
+		}
+		if secret == "url" {
+			if u, err := url.Parse(v.String()); err == nil && u.Scheme != "" {
+				return u.Redacted()
+			}
+		}
+		return redacted
+	case reflect.Map:
+		// the keys aren't secret, e.g. the names of the headers
+		res := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			res[fmt.Sprint(iter.Key().Interface())] = redacted
+		}
+		return res
+	}
+	return redacted
+}
+
+func parseTag(f reflect.StructField) (name, opts string) {
+	parts := strings.SplitN(f.Tag.Get("mapstructure"), ",", 2)
+	name = parts[0]
+	if len(parts) == 2 {
+		opts = parts[1]
+	}
+	if name == "" {
+		name = strings.ToLower(f.Name[:1]) + f.Name[1:]
+	}
+	return name, opts
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidationError lists all invalid fields of the config, so they can be fixed at once.
+type ValidationError struct {
+	Fields []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid config: " + strings.Join(e.Fields, "; ")
+}
+
+// Validate checks the config by the validate tags of the fields. The fields are reported
+// by their keys in the config file, e.g. "auth.jwt.key is required". If the sections are passed,
+// e.g. "mongo", only they are checked, so the commands using a part of the config don't need the rest.
+func (c *Config) Validate(sections ...string) error {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		return strings.SplitN(f.Tag.Get("mapstructure"), ",", 2)[0]
+	})
+
+	if len(sections) == 0 {
+		return fieldErrors(v.Struct(c), "")
+	}
+
+	res := &ValidationError{}
+	for _, name := range sections {
+		section, ok := c.section(name)
+		if !ok {
+			return fmt.Errorf("unknown config section %q", name)
+		}
+		err := fieldErrors(v.Struct(section), name+".")
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		res.Fields = append(res.Fields, validationErr.Fields...)
+	}
+	if len(res.Fields) == 0 {
+		return nil
+	}
+	return res
+}
+
+// section returns the section of the config by its key, e.g. "mongo".
+func (c *Config) section(name string) (interface{}, bool) {
+	v := reflect.ValueOf(c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if strings.SplitN(v.Type().Field(i).Tag.Get("mapstructure"), ",", 2)[0] == name {
+			return v.Field(i).Interface(), true
+		}
+	}
+	return nil, false
+}
+
+// fieldErrors converts the errors of the validator to ValidationError, the keys get the prefix of the section.
+func fieldErrors(err error, prefix string) error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+
+	res := &ValidationError{}
+	for _, e := range validationErrs {
+		res.Fields = append(res.Fields, prefix+key(e)+" "+message(e))
+	}
+	return res
+}
+
+// key returns the name of the field without the name of the struct, e.g. "auth.jwt.key".
+func key(e validator.FieldError) string {
+	ns := e.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return e.Field()
+}
+
+func message(e validator.FieldError) string {
+	switch e.Tag() {
+	case "required", "required_if":
+		return "is required"
+	case "min", "gte":
+		if e.Kind() == reflect.String {
+			return fmt.Sprintf("must have at least %s characters", e.Param())
+		}
+		return "must be at least " + e.Param()
+	case "max", "lte":
+		return "must be at most " + e.Param()
+	case "gt":
+		return "must be greater than " + e.Param()
+	case "gtfield":
+		return "must be greater than " + fieldKey(e.Param())
+	case "gtefield":
+		return "must be at least " + fieldKey(e.Param())
+	case "ltefield":
+		return "must be at most " + fieldKey(e.Param())
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(e.Param(), " ", ", ")
+	case "numeric":
+		return "must be a number"
+	case "url", "uri":
+		return "must be a valid url"
+	case "startswith":
+		return fmt.Sprintf("must start with %q", e.Param())
+	case "cidr|ip":
+		return "must be an ip or a network"
+	}
+	return fmt.Sprintf("failed on the %s rule", e.Tag())
+}
+
+// fieldKey returns the key of the field compared with, the keys are the names of the fields in camel case.
+func fieldKey(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
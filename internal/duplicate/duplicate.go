@@ -0,0 +1,45 @@
+package duplicate
+
+import (
+	"github.com/Alexander272/games-library/internal/duplicate/repository"
+	"github.com/Alexander272/games-library/internal/duplicate/service"
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/pkg/lifecycle"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type Collections = repository.Collections
+
+type ICandidateRepo interface {
+	repository.ICandidate
+}
+type IMergeRepo interface {
+	repository.IMerge
+}
+
+type ICandidateService interface {
+	service.ICandidate
+}
+type IDetectionService interface {
+	service.IDetection
+}
+type IMergeService interface {
+	service.IMerge
+}
+
+func NewCandidateRepo(db *mongo.Database, collection, gamesCollection string) ICandidateRepo {
+	return repository.NewCandidateRepo(db, collection, gamesCollection)
+}
+func NewMergeRepo(db *mongo.Database, collections Collections) IMergeRepo {
+	return repository.NewMergeRepo(db, collections)
+}
+
+func NewCandidateService(repo repository.ICandidate) ICandidateService {
+	return service.NewCandidateService(repo)
+}
+func NewDetectionService(games gameRepo.IGame, candidates repository.ICandidate, background *lifecycle.Background) IDetectionService {
+	return service.NewDetectionService(games, candidates, background)
+}
+func NewMergeService(repo repository.IMerge, candidates repository.ICandidate, games gameRepo.IGame, redirects gameRepo.IRedirect) IMergeService {
+	return service.NewMergeService(repo, candidates, games, redirects)
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+)
+
+const (
+	StatusOpen      = "open"
+	StatusDismissed = "dismissed"
+)
+
+// Candidate is a pair of games that are probably the same game entered twice.
+// Dismissed candidates aren't reported again by the detection job.
+type Candidate struct {
+	Id         string            `json:"id" bson:"_id,omitempty"`
+	GameIds    []string          `json:"gameIds" bson:"gameIds"`
+	Games      []gameModels.Game `json:"games,omitempty" bson:"games,omitempty"`
+	Score      float64           `json:"score" bson:"score"`
+	Reasons    []string          `json:"reasons" bson:"reasons"`
+	Status     string            `json:"status" bson:"status"`
+	DetectedAt time.Time         `json:"detectedAt" bson:"detectedAt"`
+}
+
+type CandidateFilter struct {
+	Status   string  `form:"status" binding:"omitempty,oneof=open dismissed"`
+	MinScore float64 `form:"minScore" binding:"omitempty,min=0,max=1"`
+	Limit    int64   `form:"limit" binding:"omitempty,min=1,max=100"`
+	Skip     int64   `form:"skip" binding:"omitempty,min=0"`
+}
+
+// MergeDTO merges the duplicate into the surviving game. The duplicate is removed.
+type MergeDTO struct {
+	SurvivorId  string `json:"-"`
+	DuplicateId string `json:"duplicateId" binding:"required,objectid"`
+}
+
+// MergeReport is the number of documents moved from the duplicate to the surviving game.
+type MergeReport struct {
+	Library      int64    `json:"library"`
+	Achievements int64    `json:"achievements"`
+	Unlocks      int64    `json:"unlocks"`
+	Sessions     int64    `json:"sessions"`
+	Loans        int64    `json:"loans"`
+	Lists        int64    `json:"lists"`
+	Prices       int64    `json:"prices"`
+	Alerts       int64    `json:"alerts"`
+	Redirects    []string `json:"redirects"`
+}
+
+// DetectionReport is the result of the detection job.
+type DetectionReport struct {
+	Games      int `json:"games"`
+	Candidates int `json:"candidates"`
+}
@@ -0,0 +1,10 @@
+package models
+
+import "github.com/Alexander272/games-library/pkg/apperror"
+
+var (
+	ErrCandidateNotFound = apperror.NotFound("DUPLICATE_CANDIDATE_NOT_FOUND", "duplicate candidate doesn't exists")
+	ErrDetectionRunning  = apperror.Conflict("DUPLICATE_DETECTION_RUNNING", "duplicate detection is already running")
+	ErrSameGame          = apperror.Validation("SAME_GAME_MERGE", "game can't be merged into itself")
+	ErrMergeUnsupported  = apperror.Conflict("MERGE_UNSUPPORTED", "merge needs transactions, which aren't supported by the database")
+)
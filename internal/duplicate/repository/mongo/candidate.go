@@ -0,0 +1,162 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/duplicate/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CandidateRepo struct {
+	db    *mongo.Collection
+	games string
+}
+
+func NewCandidateRepo(db *mongo.Database, collection, gamesCollection string) *CandidateRepo {
+	return &CandidateRepo{
+		db:    db.Collection(collection),
+		games: gamesCollection,
+	}
+}
+
+// Save upserts the candidates found by the detection job. The status of known pairs is kept,
+// so dismissed candidates stay dismissed.
+func (r *CandidateRepo) Save(ctx context.Context, candidates []models.Candidate) error {
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(candidates))
+	for _, c := range candidates {
+		first, second, err := toObjectIds(c.GameIds[0], c.GameIds[1])
+		if err != nil {
+			return err
+		}
+
+		filter := bson.M{"gameIds": bson.A{first, second}}
+		update := bson.M{
+			"$set": bson.M{
+				"score":      c.Score,
+				"reasons":    c.Reasons,
+				"detectedAt": c.DetectedAt,
+			},
+			"$setOnInsert": bson.M{"status": models.StatusOpen},
+		}
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	}
+
+	res, err := r.db.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Matched %v documents, updated %v documents and upserted %v documents.\n", res.MatchedCount, res.ModifiedCount, res.UpsertedCount)
+	return nil
+}
+
+// RemoveOlder removes open candidates that weren't found by the last detection.
+func (r *CandidateRepo) RemoveOlder(ctx context.Context, detectedAt time.Time) error {
+	res, err := r.db.DeleteMany(ctx, bson.M{"status": models.StatusOpen, "detectedAt": bson.M{"$lt": detectedAt}})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Delete %v documents.\n", res.DeletedCount)
+	return nil
+}
+
+// RemoveByGame removes all candidates with the game, e.g. after it was merged.
+func (r *CandidateRepo) RemoveByGame(ctx context.Context, gameId string) error {
+	gid, err := primitive.ObjectIDFromHex(gameId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	res, err := r.db.DeleteMany(ctx, bson.M{"gameIds": gid})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Delete %v documents.\n", res.DeletedCount)
+	return nil
+}
+
+// GetAll returns the candidates with the games data, the most similar first.
+func (r *CandidateRepo) GetAll(ctx context.Context, filter models.CandidateFilter) (candidates []models.Candidate, count int64, err error) {
+	status := filter.Status
+	if status == "" {
+		status = models.StatusOpen
+	}
+	match := bson.M{"status": status}
+	if filter.MinScore > 0 {
+		match["score"] = bson.M{"$gte": filter.MinScore}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+	if filter.Skip > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: filter.Skip}})
+	}
+	if filter.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: filter.Limit}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$lookup", Value: bson.M{
+		"from":         r.games,
+		"localField":   "gameIds",
+		"foreignField": "_id",
+		"as":           "games",
+	}}})
+
+	cur, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return candidates, count, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &candidates); err != nil {
+		return candidates, count, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	count, err = r.db.CountDocuments(ctx, match)
+	if err != nil {
+		return candidates, count, fmt.Errorf("failed to count documents. error: %w", err)
+	}
+
+	return candidates, count, nil
+}
+
+func (r *CandidateRepo) SetStatus(ctx context.Context, candidateId, status string) error {
+	oid, err := primitive.ObjectIDFromHex(candidateId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{"status": status}})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrCandidateNotFound
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+func toObjectIds(first, second string) (primitive.ObjectID, primitive.ObjectID, error) {
+	firstOid, err := primitive.ObjectIDFromHex(first)
+	if err != nil {
+		return firstOid, primitive.NilObjectID, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	secondOid, err := primitive.ObjectIDFromHex(second)
+	if err != nil {
+		return firstOid, secondOid, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	return firstOid, secondOid, nil
+}
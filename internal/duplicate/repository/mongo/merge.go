@@ -0,0 +1,348 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/duplicate/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Collections are the collections referencing games. Games, Redirects and Candidates are changed
+// by the other repos inside the merge transaction.
+type Collections struct {
+	Games        string
+	Redirects    string
+	Candidates   string
+	Library      string
+	Achievements string
+	Unlocks      string
+	Sessions     string
+	Loans        string
+	Lists        string
+	Prices       string
+	Alerts       string
+}
+
+// MergeRepo moves everything referencing one game to another. Move doesn't start a transaction itself,
+// it's called by the merge service inside Transaction together with the updates of the games.
+// Transactions need a replica set or a sharded cluster, Prepare checks it on start.
+type MergeRepo struct {
+	db           *mongo.Database
+	collections  Collections
+	transactions bool
+	client       *mongo.Client
+	library      *mongo.Collection
+	achievements *mongo.Collection
+	unlocks      *mongo.Collection
+	sessions     *mongo.Collection
+	loans        *mongo.Collection
+	lists        *mongo.Collection
+	prices       *mongo.Collection
+	alerts       *mongo.Collection
+}
+
+func NewMergeRepo(db *mongo.Database, collections Collections) *MergeRepo {
+	return &MergeRepo{
+		db:           db,
+		collections:  collections,
+		client:       db.Client(),
+		library:      db.Collection(collections.Library),
+		achievements: db.Collection(collections.Achievements),
+		unlocks:      db.Collection(collections.Unlocks),
+		sessions:     db.Collection(collections.Sessions),
+		loans:        db.Collection(collections.Loans),
+		lists:        db.Collection(collections.Lists),
+		prices:       db.Collection(collections.Prices),
+		alerts:       db.Collection(collections.Alerts),
+	}
+}
+
+type entryDoc struct {
+	Id        primitive.ObjectID `bson:"_id"`
+	UserId    primitive.ObjectID `bson:"userId"`
+	Platform  string             `bson:"platform"`
+	Ownership string             `bson:"ownership"`
+	Rating    int                `bson:"rating"`
+	Note      string             `bson:"note"`
+}
+
+type priceDoc struct {
+	Id       primitive.ObjectID `bson:"_id"`
+	Store    string             `bson:"store"`
+	Currency string             `bson:"currency"`
+	Date     time.Time          `bson:"date"`
+}
+
+type alertDoc struct {
+	Id     primitive.ObjectID `bson:"_id"`
+	UserId primitive.ObjectID `bson:"userId"`
+}
+
+// Prepare checks that the server supports transactions and creates the missing collections of the merge,
+// because mongo before 4.4 can't create a collection inside a transaction, e.g. by the upsert of the first redirect.
+// On a standalone server the merge is disabled and Transaction returns models.ErrMergeUnsupported.
+func (r *MergeRepo) Prepare(ctx context.Context) error {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	// isMaster is used instead of hello, which appeared in 4.4.2
+	if err := r.db.RunCommand(ctx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&hello); err != nil {
+		return fmt.Errorf("failed to get server info. error: %w", err)
+	}
+	r.transactions = hello.SetName != "" || hello.Msg == "isdbgrid"
+	if !r.transactions {
+		logger.Errorf("Merging games is disabled: transactions need a replica set or a sharded cluster.")
+		return nil
+	}
+
+	names, err := r.db.ListCollectionNames(ctx, bson.M{})
+	if err != nil {
+		return fmt.Errorf("failed to list collections. error: %w", err)
+	}
+	existing := make(map[string]bool, len(names))
+	for _, name := range names {
+		existing[name] = true
+	}
+
+	c := r.collections
+	for _, name := range []string{
+		c.Games, c.Redirects, c.Candidates, c.Library, c.Achievements, c.Unlocks,
+		c.Sessions, c.Loans, c.Lists, c.Prices, c.Alerts,
+	} {
+		if name == "" || existing[name] {
+			continue
+		}
+		err := r.db.CreateCollection(ctx, name)
+		var cmdErr mongo.CommandError
+		// the collection can be created by another instance in the meantime
+		if err != nil && !(errors.As(err, &cmdErr) && cmdErr.Code == namespaceExists) {
+			return fmt.Errorf("failed to create collection %s. error: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// namespaceExists is the code of the error of creating the existing collection.
+const namespaceExists = 48
+
+// Transaction runs fn in a transaction. The queries of all repositories made with the context passed
+// to fn are the part of it. fn can be called again if the transaction fails with a transient error.
+func (r *MergeRepo) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !r.transactions {
+		return models.ErrMergeUnsupported
+	}
+
+	session, err := r.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start session. error: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
+
+func (r *MergeRepo) Move(ctx context.Context, fromGameId, toGameId string) (report models.MergeReport, err error) {
+	from, to, err := toObjectIds(fromGameId, toGameId)
+	if err != nil {
+		return report, err
+	}
+
+	// library entries go first, because loans of the removed entries are moved to the surviving ones
+	if report.Library, err = r.moveLibrary(ctx, from, to); err != nil {
+		return report, fmt.Errorf("failed to move library entries. error: %w", err)
+	}
+
+	filter := bson.M{"gameId": from}
+	update := bson.M{"$set": bson.M{"gameId": to}}
+	if report.Achievements, err = updateMany(ctx, r.achievements, filter, update); err != nil {
+		return report, fmt.Errorf("failed to move achievements. error: %w", err)
+	}
+	if report.Unlocks, err = updateMany(ctx, r.unlocks, filter, update); err != nil {
+		return report, fmt.Errorf("failed to move unlocked achievements. error: %w", err)
+	}
+	if report.Sessions, err = updateMany(ctx, r.sessions, filter, update); err != nil {
+		return report, fmt.Errorf("failed to move play sessions. error: %w", err)
+	}
+	if report.Loans, err = updateMany(ctx, r.loans, filter, update); err != nil {
+		return report, fmt.Errorf("failed to move loans. error: %w", err)
+	}
+
+	if report.Lists, err = r.moveLists(ctx, from, to); err != nil {
+		return report, fmt.Errorf("failed to move list items. error: %w", err)
+	}
+	if report.Prices, err = r.movePrices(ctx, from, to); err != nil {
+		return report, fmt.Errorf("failed to move prices. error: %w", err)
+	}
+	if report.Alerts, err = r.moveAlerts(ctx, from, to); err != nil {
+		return report, fmt.Errorf("failed to move price alerts. error: %w", err)
+	}
+
+	return report, nil
+}
+
+// moveLibrary moves the entries to the surviving game. If the user has both games, the surviving
+// entry is kept and its empty fields (rating, note, etc.) are filled from the removed one.
+func (r *MergeRepo) moveLibrary(ctx context.Context, from, to primitive.ObjectID) (moved int64, err error) {
+	var entries []entryDoc
+	if err := find(ctx, r.library, bson.M{"gameId": from}, &entries); err != nil {
+		return moved, err
+	}
+
+	for _, e := range entries {
+		var existing entryDoc
+		err := r.library.FindOne(ctx, bson.M{"userId": e.UserId, "gameId": to}).Decode(&existing)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			if _, err := r.library.UpdateOne(ctx, bson.M{"_id": e.Id}, bson.M{"$set": bson.M{"gameId": to}}); err != nil {
+				return moved, fmt.Errorf("failed to execute query. error: %w", err)
+			}
+			moved++
+			continue
+		}
+		if err != nil {
+			return moved, fmt.Errorf("failed to execute query. error: %w", err)
+		}
+
+		fill := bson.M{}
+		if existing.Rating == 0 && e.Rating != 0 {
+			fill["rating"] = e.Rating
+		}
+		if existing.Note == "" && e.Note != "" {
+			fill["note"] = e.Note
+		}
+		if existing.Platform == "" && e.Platform != "" {
+			fill["platform"] = e.Platform
+		}
+		if existing.Ownership == "" && e.Ownership != "" {
+			fill["ownership"] = e.Ownership
+		}
+		if len(fill) > 0 {
+			if _, err := r.library.UpdateOne(ctx, bson.M{"_id": existing.Id}, bson.M{"$set": fill}); err != nil {
+				return moved, fmt.Errorf("failed to execute query. error: %w", err)
+			}
+		}
+
+		if _, err := r.loans.UpdateMany(ctx, bson.M{"entryId": e.Id}, bson.M{"$set": bson.M{"entryId": existing.Id}}); err != nil {
+			return moved, fmt.Errorf("failed to execute query. error: %w", err)
+		}
+		if _, err := r.library.DeleteOne(ctx, bson.M{"_id": e.Id}); err != nil {
+			return moved, fmt.Errorf("failed to execute query. error: %w", err)
+		}
+		moved++
+	}
+
+	return moved, nil
+}
+
+// moveLists replaces the game in list items. Lists that already have the surviving game lose the duplicate.
+func (r *MergeRepo) moveLists(ctx context.Context, from, to primitive.ObjectID) (moved int64, err error) {
+	now := time.Now()
+
+	pulled, err := updateMany(ctx, r.lists,
+		bson.M{"items.gameId": bson.M{"$all": bson.A{from, to}}},
+		bson.M{"$pull": bson.M{"items": bson.M{"gameId": from}}, "$set": bson.M{"updatedAt": now}},
+	)
+	if err != nil {
+		return moved, err
+	}
+
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{Filters: []interface{}{bson.M{"item.gameId": from}}})
+	res, err := r.lists.UpdateMany(ctx,
+		bson.M{"items.gameId": from},
+		bson.M{"$set": bson.M{"items.$[item].gameId": to, "updatedAt": now}},
+		opts,
+	)
+	if err != nil {
+		return moved, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return pulled + res.ModifiedCount, nil
+}
+
+// movePrices moves the observations. Observations of the same store, currency and date
+// as the surviving game has are removed.
+func (r *MergeRepo) movePrices(ctx context.Context, from, to primitive.ObjectID) (moved int64, err error) {
+	var prices []priceDoc
+	if err := find(ctx, r.prices, bson.M{"gameId": from}, &prices); err != nil {
+		return moved, err
+	}
+
+	for _, p := range prices {
+		count, err := r.prices.CountDocuments(ctx, bson.M{"gameId": to, "store": p.Store, "currency": p.Currency, "date": p.Date})
+		if err != nil {
+			return moved, fmt.Errorf("failed to count documents. error: %w", err)
+		}
+		if err := moveOrRemove(ctx, r.prices, p.Id, to, count > 0); err != nil {
+			return moved, err
+		}
+		moved++
+	}
+
+	return moved, nil
+}
+
+// moveAlerts moves the alerts. If the user has the alert for the surviving game, it is kept.
+func (r *MergeRepo) moveAlerts(ctx context.Context, from, to primitive.ObjectID) (moved int64, err error) {
+	var alerts []alertDoc
+	if err := find(ctx, r.alerts, bson.M{"gameId": from}, &alerts); err != nil {
+		return moved, err
+	}
+
+	for _, a := range alerts {
+		count, err := r.alerts.CountDocuments(ctx, bson.M{"userId": a.UserId, "gameId": to})
+		if err != nil {
+			return moved, fmt.Errorf("failed to count documents. error: %w", err)
+		}
+		if err := moveOrRemove(ctx, r.alerts, a.Id, to, count > 0); err != nil {
+			return moved, err
+		}
+		moved++
+	}
+
+	return moved, nil
+}
+
+func moveOrRemove(ctx context.Context, collection *mongo.Collection, id, to primitive.ObjectID, remove bool) error {
+	var err error
+	if remove {
+		_, err = collection.DeleteOne(ctx, bson.M{"_id": id})
+	} else {
+		_, err = collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"gameId": to}})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	return nil
+}
+
+func find(ctx context.Context, collection *mongo.Collection, filter bson.M, docs interface{}) error {
+	cur, err := collection.Find(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, docs); err != nil {
+		return fmt.Errorf("failed to decode document. error: %w", err)
+	}
+	return nil
+}
+
+func updateMany(ctx context.Context, collection *mongo.Collection, filter, update bson.M) (int64, error) {
+	res, err := collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return res.ModifiedCount, nil
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/duplicate/models"
+	duplicate "github.com/Alexander272/games-library/internal/duplicate/repository/mongo"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Collections are the collections referencing games, which are updated by the merge.
+type Collections = duplicate.Collections
+
+type ICandidate interface {
+	Save(ctx context.Context, candidates []models.Candidate) error
+	RemoveOlder(ctx context.Context, detectedAt time.Time) error
+	RemoveByGame(ctx context.Context, gameId string) error
+	GetAll(ctx context.Context, filter models.CandidateFilter) ([]models.Candidate, int64, error)
+	SetStatus(ctx context.Context, candidateId, status string) error
+}
+
+type IMerge interface {
+	Prepare(ctx context.Context) error
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	Move(ctx context.Context, fromGameId, toGameId string) (models.MergeReport, error)
+}
+
+func NewCandidateRepo(db *mongo.Database, collection, gamesCollection string) ICandidate {
+	return duplicate.NewCandidateRepo(db, collection, gamesCollection)
+}
+
+func NewMergeRepo(db *mongo.Database, collections Collections) IMerge {
+	return duplicate.NewMergeRepo(db, collections)
+}
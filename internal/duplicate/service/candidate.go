@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Alexander272/games-library/internal/duplicate/models"
+	"github.com/Alexander272/games-library/internal/duplicate/repository"
+)
+
+type CandidateService struct {
+	repo repository.ICandidate
+}
+
+func NewCandidateService(repo repository.ICandidate) *CandidateService {
+	return &CandidateService{
+		repo: repo,
+	}
+}
+
+func (s *CandidateService) GetAll(ctx context.Context, filter models.CandidateFilter) (candidates []models.Candidate, count int64, err error) {
+	candidates, count, err = s.repo.GetAll(ctx, filter)
+	if err != nil {
+		return candidates, count, fmt.Errorf("failed to get duplicate candidates. error: %w", err)
+	}
+	if len(candidates) == 0 {
+		return candidates, count, models.ErrCandidateNotFound
+	}
+	return candidates, count, nil
+}
+
+// Dismiss marks the pair as different games, so it isn't reported again.
+func (s *CandidateService) Dismiss(ctx context.Context, candidateId string) error {
+	if err := s.repo.SetStatus(ctx, candidateId, models.StatusDismissed); err != nil {
+		if errors.Is(err, models.ErrCandidateNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to dismiss duplicate candidate. error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/duplicate/models"
+	"github.com/Alexander272/games-library/internal/duplicate/repository"
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/pkg/lifecycle"
+	"github.com/Alexander272/games-library/pkg/logger"
+)
+
+const defaultInterval = 24 * time.Hour
+
+// DetectionService finds probable duplicates among all games by normalised titles,
+// release years and developers.
+type DetectionService struct {
+	games      gameRepo.IGame
+	candidates repository.ICandidate
+	background *lifecycle.Background
+
+	running int32
+}
+
+func NewDetectionService(games gameRepo.IGame, candidates repository.ICandidate, background *lifecycle.Background) *DetectionService {
+	return &DetectionService{
+		games:      games,
+		candidates: candidates,
+		background: background,
+	}
+}
+
+// Detect searches for duplicates. Only one detection runs at a time.
+func (s *DetectionService) Detect(ctx context.Context) (report models.DetectionReport, err error) {
+	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
+		return report, models.ErrDetectionRunning
+	}
+	defer atomic.StoreInt32(&s.running, 0)
+
+	return s.detect(ctx)
+}
+
+// Start runs the detection in the background.
+func (s *DetectionService) Start() error {
+	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
+		return models.ErrDetectionRunning
+	}
+
+	s.background.Go(func(ctx context.Context) {
+		defer atomic.StoreInt32(&s.running, 0)
+		if _, err := s.detect(ctx); err != nil {
+			logger.Errorf("failed to detect duplicates. error: %s", err.Error())
+		}
+	})
+	return nil
+}
+
+// Run searches for duplicates every interval until the context is cancelled.
+func (s *DetectionService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Detect(ctx); err != nil {
+			logger.Errorf("failed to detect duplicates. error: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *DetectionService) detect(ctx context.Context) (report models.DetectionReport, err error) {
+	start := time.Now()
+
+	var games []gameInfo
+	err = s.games.Export(ctx, gameModels.GameFilter{}, func(game gameModels.Game) error {
+		games = append(games, newGameInfo(game))
+		return nil
+	})
+	if err != nil {
+		return report, fmt.Errorf("failed to get games. error: %w", err)
+	}
+	report.Games = len(games)
+
+	pairs := findDuplicates(games)
+	candidates := make([]models.Candidate, 0, len(pairs))
+	for _, p := range pairs {
+		// ids are sorted, so the same pair is always saved to the same document
+		first, second := games[p.first].id, games[p.second].id
+		if first > second {
+			first, second = second, first
+		}
+		candidates = append(candidates, models.Candidate{
+			GameIds:    []string{first, second},
+			Score:      p.score,
+			Reasons:    p.reasons,
+			DetectedAt: start,
+		})
+	}
+	report.Candidates = len(candidates)
+
+	if err := s.candidates.Save(ctx, candidates); err != nil {
+		return report, fmt.Errorf("failed to save duplicate candidates. error: %w", err)
+	}
+	if err := s.candidates.RemoveOlder(ctx, start); err != nil {
+		return report, fmt.Errorf("failed to remove old duplicate candidates. error: %w", err)
+	}
+
+	logger.Infof("Found %d duplicate candidates among %d games in %s.", report.Candidates, report.Games, time.Since(start))
+	return report, nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/duplicate/models"
+	"github.com/Alexander272/games-library/internal/duplicate/repository"
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/pkg/logger"
+)
+
+type MergeService struct {
+	repo       repository.IMerge
+	candidates repository.ICandidate
+	games      gameRepo.IGame
+	redirects  gameRepo.IRedirect
+}
+
+func NewMergeService(repo repository.IMerge, candidates repository.ICandidate, games gameRepo.IGame, redirects gameRepo.IRedirect) *MergeService {
+	return &MergeService{
+		repo:       repo,
+		candidates: candidates,
+		games:      games,
+		redirects:  redirects,
+	}
+}
+
+// Merge moves library entries, ratings, achievements, sessions, loans, list items and prices
+// of the duplicate to the surviving game, fills the empty game fields from the duplicate
+// and removes it. The old slug and id of the duplicate redirect to the surviving game.
+// All steps run in one transaction, so a failed merge changes nothing.
+func (s *MergeService) Merge(ctx context.Context, dto models.MergeDTO) (report models.MergeReport, err error) {
+	if dto.SurvivorId == dto.DuplicateId {
+		return report, models.ErrSameGame
+	}
+
+	err = s.repo.Transaction(ctx, func(ctx context.Context) (err error) {
+		report, err = s.merge(ctx, dto)
+		return err
+	})
+	if err != nil {
+		return report, err
+	}
+
+	logger.Infof("Merged game %s into %s.", dto.DuplicateId, dto.SurvivorId)
+	return report, nil
+}
+
+func (s *MergeService) merge(ctx context.Context, dto models.MergeDTO) (report models.MergeReport, err error) {
+	survivor, err := s.getGame(ctx, dto.SurvivorId)
+	if err != nil {
+		return report, err
+	}
+	duplicate, err := s.getGame(ctx, dto.DuplicateId)
+	if err != nil {
+		return report, err
+	}
+
+	report, err = s.repo.Move(ctx, duplicate.Id, survivor.Id)
+	if err != nil {
+		return report, fmt.Errorf("failed to move game references. error: %w", err)
+	}
+
+	if update, ok := mergeGames(survivor, duplicate); ok {
+		if err := s.games.Update(ctx, update); err != nil {
+			return report, fmt.Errorf("failed to update game. error: %w", err)
+		}
+	}
+
+	if err := s.redirects.Retarget(ctx, duplicate.Id, survivor.Id); err != nil {
+		return report, fmt.Errorf("failed to update redirects. error: %w", err)
+	}
+	for _, from := range []string{duplicate.Slug, duplicate.Id} {
+		if from == "" {
+			continue
+		}
+		redirect := gameModels.Redirect{From: from, GameId: survivor.Id, CreatedAt: time.Now()}
+		if err := s.redirects.Set(ctx, redirect); err != nil {
+			return report, fmt.Errorf("failed to set redirect. error: %w", err)
+		}
+		report.Redirects = append(report.Redirects, from)
+	}
+
+	if err := s.games.Remove(ctx, duplicate.Id); err != nil {
+		return report, fmt.Errorf("failed to remove game. error: %w", err)
+	}
+	if err := s.candidates.RemoveByGame(ctx, duplicate.Id); err != nil {
+		return report, fmt.Errorf("failed to remove duplicate candidates. error: %w", err)
+	}
+	return report, nil
+}
+
+func (s *MergeService) getGame(ctx context.Context, gameId string) (game gameModels.Game, err error) {
+	game, err = s.games.GetById(ctx, gameId)
+	if err != nil {
+		if errors.Is(err, gameModels.ErrGameNotFound) {
+			return game, err
+		}
+		return game, fmt.Errorf("failed to get game by id. error: %w", err)
+	}
+	return game, nil
+}
+
+// mergeGames returns the update of the surviving game with the empty fields taken from the duplicate,
+// the lists and translations joined and the releases of the duplicate added. It reports whether there is anything to update.
+func mergeGames(survivor, duplicate gameModels.Game) (update gameModels.Game, ok bool) {
+	update.Id = survivor.Id
+
+	if survivor.ExternalId == "" && duplicate.ExternalId != "" {
+		update.ExternalId, ok = duplicate.ExternalId, true
+	}
+	if survivor.Description == "" && duplicate.Description != "" {
+		update.Description, ok = duplicate.Description, true
+	}
+	if survivor.Developer == "" && duplicate.Developer != "" {
+		update.Developer, ok = duplicate.Developer, true
+	}
+	if survivor.Publisher == "" && duplicate.Publisher != "" {
+		update.Publisher, ok = duplicate.Publisher, true
+	}
+	if survivor.ReleaseDate.IsZero() && !duplicate.ReleaseDate.IsZero() {
+		update.ReleaseDate, ok = duplicate.ReleaseDate, true
+	}
+
+	if genres, added := union(survivor.Genres, duplicate.Genres); added {
+		update.Genres, ok = genres, true
+	}
+	if tags, added := union(survivor.Tags, duplicate.Tags); added {
+		update.Tags, ok = tags, true
+	}
+	if platforms, added := union(survivor.Platforms, duplicate.Platforms); added {
+		update.Platforms, ok = platforms, true
+	}
+
+	if translations, added := mergeTranslations(survivor.Translations, duplicate.Translations); added {
+		update.Translations, ok = translations, true
+	}
+
+	releases := append([]gameModels.Release(nil), survivor.Releases...)
+	known := make(map[string]bool, len(releases))
+	for _, r := range releases {
+		known[r.Id] = true
+	}
+	for _, r := range duplicate.Releases {
+		if !known[r.Id] {
+			releases = append(releases, r)
+		}
+	}
+	if len(releases) > len(survivor.Releases) {
+		update.Releases, ok = releases, true
+	}
+
+	return update, ok
+}
+
+// union adds the values missing in the first list. It reports whether anything was added.
+func union(first, second []string) ([]string, bool) {
+	known := make(map[string]bool, len(first))
+	for _, v := range first {
+		known[v] = true
+	}
+
+	res := append([]string(nil), first...)
+	for _, v := range second {
+		if !known[v] {
+			res = append(res, v)
+			known[v] = true
+		}
+	}
+	return res, len(res) > len(first)
+}
+
+// mergeTranslations adds the locales missing in the first list and fills the empty title and description
+// of the same locale. It reports whether anything was changed.
+func mergeTranslations(first, second []gameModels.Translation) ([]gameModels.Translation, bool) {
+	res := append([]gameModels.Translation(nil), first...)
+	index := make(map[string]int, len(res))
+	for i, t := range res {
+		index[t.Locale] = i
+	}
+
+	changed := false
+	for _, t := range second {
+		i, ok := index[t.Locale]
+		if !ok {
+			index[t.Locale] = len(res)
+			res = append(res, t)
+			changed = true
+			continue
+		}
+		if res[i].Title == "" && t.Title != "" {
+			res[i].Title, changed = t.Title, true
+		}
+		if res[i].Description == "" && t.Description != "" {
+			res[i].Description, changed = t.Description, true
+		}
+	}
+	return res, changed
+}
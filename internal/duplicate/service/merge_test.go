@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/duplicate/models"
+	"github.com/Alexander272/games-library/internal/duplicate/repository"
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+)
+
+func TestMergeGames(t *testing.T) {
+	date := time.Date(2015, 5, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		survivor  gameModels.Game
+		duplicate gameModels.Game
+		want      gameModels.Game
+		wantOk    bool
+	}{
+		{
+			name:      "empty fields are filled",
+			survivor:  gameModels.Game{Id: "1", Title: "The Witcher 3", Developer: "CD Projekt Red"},
+			duplicate: gameModels.Game{Id: "2", Developer: "CDPR", Publisher: "CD Projekt", Description: "RPG", ReleaseDate: date},
+			want:      gameModels.Game{Id: "1", Publisher: "CD Projekt", Description: "RPG", ReleaseDate: date},
+			wantOk:    true,
+		},
+		{
+			name:      "lists are joined",
+			survivor:  gameModels.Game{Id: "1", Genres: []string{"RPG"}, Platforms: []string{"PC"}},
+			duplicate: gameModels.Game{Id: "2", Genres: []string{"RPG", "Action"}, Platforms: []string{"PC"}, Tags: []string{"open world"}},
+			want:      gameModels.Game{Id: "1", Genres: []string{"RPG", "Action"}, Tags: []string{"open world"}},
+			wantOk:    true,
+		},
+		{
+			name:     "translations are joined by locale",
+			survivor: gameModels.Game{Id: "1", Translations: []gameModels.Translation{{Locale: "en", Title: "The Witcher 3"}}},
+			duplicate: gameModels.Game{Id: "2", Translations: []gameModels.Translation{
+				{Locale: "en", Title: "Witcher 3", Description: "RPG"},
+				{Locale: "ru", Title: "Ведьмак 3"},
+			}},
+			want: gameModels.Game{Id: "1", Translations: []gameModels.Translation{
+				{Locale: "en", Title: "The Witcher 3", Description: "RPG"},
+				{Locale: "ru", Title: "Ведьмак 3"},
+			}},
+			wantOk: true,
+		},
+		{
+			name:      "releases are added",
+			survivor:  gameModels.Game{Id: "1", Releases: []gameModels.Release{{Id: "a"}}},
+			duplicate: gameModels.Game{Id: "2", Releases: []gameModels.Release{{Id: "a"}, {Id: "b"}}},
+			want:      gameModels.Game{Id: "1", Releases: []gameModels.Release{{Id: "a"}, {Id: "b"}}},
+			wantOk:    true,
+		},
+		{
+			name:      "nothing to update",
+			survivor:  gameModels.Game{Id: "1", Developer: "Supergiant", Genres: []string{"Roguelike"}, Translations: []gameModels.Translation{{Locale: "ru", Title: "Хейдес"}}},
+			duplicate: gameModels.Game{Id: "2", Genres: []string{"Roguelike"}, Translations: []gameModels.Translation{{Locale: "ru", Title: "Hades"}}},
+			want:      gameModels.Game{Id: "1"},
+			wantOk:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := mergeGames(tt.survivor, tt.duplicate)
+			if ok != tt.wantOk {
+				t.Fatalf("mergeGames() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeGames() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// txKey marks the context passed to the function of the transaction.
+type txKey struct{}
+
+// fakeMerge runs the transaction without a database and checks that Move is called inside it.
+type fakeMerge struct {
+	repository.IMerge
+	committed bool
+}
+
+func (f *fakeMerge) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		return err
+	}
+	f.committed = true
+	return nil
+}
+
+func (f *fakeMerge) Move(ctx context.Context, fromGameId, toGameId string) (models.MergeReport, error) {
+	if ctx.Value(txKey{}) == nil {
+		return models.MergeReport{}, errors.New("move outside the transaction")
+	}
+	return models.MergeReport{Library: 2}, nil
+}
+
+type fakeCandidates struct {
+	repository.ICandidate
+	removed []string
+}
+
+func (f *fakeCandidates) RemoveByGame(ctx context.Context, gameId string) error {
+	f.removed = append(f.removed, gameId)
+	return nil
+}
+
+type fakeGames struct {
+	gameRepo.IGame
+	games     map[string]gameModels.Game
+	removeErr error
+}
+
+func (f *fakeGames) GetById(ctx context.Context, gameId string) (gameModels.Game, error) {
+	game, ok := f.games[gameId]
+	if !ok {
+		return game, gameModels.ErrGameNotFound
+	}
+	return game, nil
+}
+
+func (f *fakeGames) Update(ctx context.Context, game gameModels.Game) error {
+	stored := f.games[game.Id]
+	stored.Developer = game.Developer
+	f.games[game.Id] = stored
+	return nil
+}
+
+func (f *fakeGames) Remove(ctx context.Context, gameId string) error {
+	if f.removeErr != nil {
+		return f.removeErr
+	}
+	delete(f.games, gameId)
+	return nil
+}
+
+type fakeRedirects struct {
+	gameRepo.IRedirect
+	set map[string]string
+}
+
+func (f *fakeRedirects) Retarget(ctx context.Context, fromGameId, toGameId string) error {
+	return nil
+}
+
+func (f *fakeRedirects) Set(ctx context.Context, redirect gameModels.Redirect) error {
+	f.set[redirect.From] = redirect.GameId
+	return nil
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name          string
+		dto           models.MergeDTO
+		removeErr     error
+		wantErr       error
+		wantCommitted bool
+	}{
+		{"merged", models.MergeDTO{SurvivorId: "1", DuplicateId: "2"}, nil, nil, true},
+		{"same game", models.MergeDTO{SurvivorId: "1", DuplicateId: "1"}, nil, models.ErrSameGame, false},
+		{"unknown duplicate", models.MergeDTO{SurvivorId: "1", DuplicateId: "3"}, nil, gameModels.ErrGameNotFound, false},
+		{"failed removal", models.MergeDTO{SurvivorId: "1", DuplicateId: "2"}, errors.New("connection refused"), nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merge := &fakeMerge{}
+			candidates := &fakeCandidates{}
+			games := &fakeGames{
+				games: map[string]gameModels.Game{
+					"1": {Id: "1", Slug: "witcher-3", Title: "The Witcher 3"},
+					"2": {Id: "2", Slug: "witcher-3-wild-hunt", Title: "Witcher 3: Wild Hunt", Developer: "CD Projekt Red"},
+				},
+				removeErr: tt.removeErr,
+			}
+			redirects := &fakeRedirects{set: map[string]string{}}
+			s := NewMergeService(merge, candidates, games, redirects)
+
+			report, err := s.Merge(context.Background(), tt.dto)
+			if merge.committed != tt.wantCommitted {
+				t.Errorf("committed = %v, want %v", merge.committed, tt.wantCommitted)
+			}
+			if !tt.wantCommitted {
+				if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+					t.Errorf("Merge() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Merge() error = %v", err)
+			}
+
+			if report.Library != 2 || !reflect.DeepEqual(report.Redirects, []string{"witcher-3-wild-hunt", "2"}) {
+				t.Errorf("report = %+v", report)
+			}
+			if want := map[string]string{"witcher-3-wild-hunt": "1", "2": "1"}; !reflect.DeepEqual(redirects.set, want) {
+				t.Errorf("redirects = %v, want %v", redirects.set, want)
+			}
+			if _, ok := games.games["2"]; ok || games.games["1"].Developer != "CD Projekt Red" {
+				t.Errorf("games = %+v, want the duplicate merged into the survivor", games.games)
+			}
+			if !reflect.DeepEqual(candidates.removed, []string{"2"}) {
+				t.Errorf("removed candidates of %v, want 2", candidates.removed)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/duplicate/models"
+)
+
+type ICandidate interface {
+	GetAll(ctx context.Context, filter models.CandidateFilter) ([]models.Candidate, int64, error)
+	Dismiss(ctx context.Context, candidateId string) error
+}
+
+type IDetection interface {
+	Detect(ctx context.Context) (models.DetectionReport, error)
+	Start() error
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type IMerge interface {
+	Merge(ctx context.Context, dto models.MergeDTO) (models.MergeReport, error)
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+)
+
+// Weights of the features in the duplicate score.
+const (
+	titleWeight     = 0.6
+	yearWeight      = 0.2
+	developerWeight = 0.2
+)
+
+const (
+	// minScore is the lowest score of the reported candidates.
+	minScore = 0.75
+	// minTitleScore skips pairs with different titles even if the year and the developer match.
+	minTitleScore = 0.6
+)
+
+// ignoredWords don't tell games apart: articles and edition names.
+var ignoredWords = map[string]bool{
+	"the": true, "a": true, "an": true, "of": true, "and": true,
+	"edition": true, "goty": true, "definitive": true, "complete": true, "deluxe": true,
+	"ultimate": true, "enhanced": true,
+}
+
+// romanNumerals are replaced with digits, so "Final Fantasy VII" matches "Final Fantasy 7".
+var romanNumerals = map[string]string{
+	"ii": "2", "iii": "3", "iv": "4", "v": "5", "vi": "6", "vii": "7", "viii": "8", "ix": "9", "x": "10",
+}
+
+type gameInfo struct {
+	id        string
+	words     map[string]bool
+	numbers   string
+	year      int
+	developer string
+}
+
+func newGameInfo(game gameModels.Game) gameInfo {
+	info := gameInfo{
+		id:        game.Id,
+		words:     make(map[string]bool),
+		year:      releaseYear(game),
+		developer: strings.Join(normalize(game.Developer), " "),
+	}
+
+	var numbers []string
+	for _, w := range normalize(game.Title) {
+		if ignoredWords[w] {
+			continue
+		}
+		if n, ok := romanNumerals[w]; ok {
+			w = n
+		}
+		if isNumber(w) {
+			numbers = append(numbers, w)
+		}
+		info.words[w] = true
+	}
+	sort.Strings(numbers)
+	info.numbers = strings.Join(numbers, " ")
+	return info
+}
+
+// normalize splits the string into lowercased words without punctuation.
+func normalize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func isNumber(s string) bool {
+	for _, r := range s {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// releaseYear is the year of the first release of the game, 0 if it isn't known.
+func releaseYear(game gameModels.Game) int {
+	first := game.ReleaseDate
+	for _, r := range game.Releases {
+		if !r.Date.IsZero() && (first.IsZero() || r.Date.Before(first)) {
+			first = r.Date
+		}
+	}
+	if first.IsZero() {
+		return 0
+	}
+	return first.Year()
+}
+
+type pair struct {
+	first   int
+	second  int
+	score   float64
+	reasons []string
+}
+
+// findDuplicates compares games sharing at least one title word and returns pairs
+// with the score not lower than minScore, the most similar first.
+func findDuplicates(games []gameInfo) []pair {
+	index := make(map[string][]int)
+	for i, g := range games {
+		for w := range g.words {
+			index[w] = append(index[w], i)
+		}
+	}
+
+	var pairs []pair
+	for i, g := range games {
+		compared := make(map[int]bool)
+		for w := range g.words {
+			for _, j := range index[w] {
+				if j <= i || compared[j] {
+					continue
+				}
+				compared[j] = true
+
+				if p, ok := compare(g, games[j]); ok {
+					p.first, p.second = i, j
+					pairs = append(pairs, p)
+				}
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].score != pairs[j].score {
+			return pairs[i].score > pairs[j].score
+		}
+		return games[pairs[i].first].id < games[pairs[j].first].id
+	})
+	return pairs
+}
+
+func compare(a, b gameInfo) (p pair, ok bool) {
+	// sequels differ only by the number, e.g. "The Witcher 2" and "The Witcher 3"
+	if a.numbers != b.numbers {
+		return p, false
+	}
+
+	title := titleSimilarity(a.words, b.words)
+	if title < minTitleScore {
+		return p, false
+	}
+	p.reasons = append(p.reasons, "similar titles")
+
+	// the year and the developer are counted only if they are known for both games,
+	// the score is the weighted mean of the known features
+	score, weights := titleWeight*title, titleWeight
+	if a.year != 0 && b.year != 0 {
+		year := 0.0
+		switch diff := a.year - b.year; {
+		case diff == 0:
+			year = 1
+			p.reasons = append(p.reasons, "same release year")
+		case diff == 1 || diff == -1:
+			year = 0.5
+		}
+		score += yearWeight * year
+		weights += yearWeight
+	}
+
+	if a.developer != "" && b.developer != "" {
+		developer := titleSimilarity(wordSet(a.developer), wordSet(b.developer))
+		if developer == 1 {
+			p.reasons = append(p.reasons, "same developer")
+		}
+		score += developerWeight * developer
+		weights += developerWeight
+	}
+
+	p.score = score / weights
+	return p, p.score >= minScore
+}
+
+// titleSimilarity is the Jaccard index of the words. If all words of one title are in the other one,
+// e.g. "Witcher 3" and "The Witcher 3: Wild Hunt", the titles are considered almost the same.
+func titleSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	common := 0
+	for w := range a {
+		if b[w] {
+			common++
+		}
+	}
+
+	smaller := len(a)
+	if len(b) < smaller {
+		smaller = len(b)
+	}
+	jaccard := float64(common) / float64(len(a)+len(b)-common)
+	containment := 0.9 * float64(common) / float64(smaller)
+	if containment > jaccard {
+		return containment
+	}
+	return jaccard
+}
+
+func wordSet(s string) map[string]bool {
+	set := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		set[w] = true
+	}
+	return set
+}
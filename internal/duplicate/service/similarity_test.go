@@ -0,0 +1,116 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+)
+
+func TestCompare(t *testing.T) {
+	year := func(y int) time.Time { return time.Date(y, 5, 19, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name      string
+		a, b      gameModels.Game
+		wantOk    bool
+		wantScore float64
+	}{
+		{
+			name:      "subtitle without year and developer",
+			a:         gameModels.Game{Title: "The Witcher 3"},
+			b:         gameModels.Game{Title: "Witcher 3: Wild Hunt"},
+			wantOk:    true,
+			wantScore: 0.9,
+		},
+		{
+			name:      "same title, year and developer",
+			a:         gameModels.Game{Title: "Hollow Knight", Developer: "Team Cherry", ReleaseDate: year(2017)},
+			b:         gameModels.Game{Title: "Hollow Knight", Developer: "Team Cherry", ReleaseDate: year(2017)},
+			wantOk:    true,
+			wantScore: 1,
+		},
+		{
+			name:      "roman numerals and edition",
+			a:         gameModels.Game{Title: "Final Fantasy VII"},
+			b:         gameModels.Game{Title: "Final Fantasy 7 Definitive Edition"},
+			wantOk:    true,
+			wantScore: 1,
+		},
+		{
+			name:      "year of the first release",
+			a:         gameModels.Game{Title: "Hades", Releases: []gameModels.Release{{Date: year(2021)}, {Date: year(2020)}}},
+			b:         gameModels.Game{Title: "Hades", ReleaseDate: year(2020)},
+			wantOk:    true,
+			wantScore: 1,
+		},
+		{
+			name:   "sequel",
+			a:      gameModels.Game{Title: "The Witcher 2"},
+			b:      gameModels.Game{Title: "The Witcher 3"},
+			wantOk: false,
+		},
+		{
+			name:   "remake years later by another developer",
+			a:      gameModels.Game{Title: "Doom", Developer: "id Software", ReleaseDate: year(1993)},
+			b:      gameModels.Game{Title: "Doom", Developer: "Bethesda", ReleaseDate: year(2016)},
+			wantOk: false,
+		},
+		{
+			name:   "different titles",
+			a:      gameModels.Game{Title: "Dark Souls", Developer: "FromSoftware", ReleaseDate: year(2011)},
+			b:      gameModels.Game{Title: "Dark Light", Developer: "FromSoftware", ReleaseDate: year(2011)},
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ok := compare(newGameInfo(tt.a), newGameInfo(tt.b))
+			if ok != tt.wantOk {
+				t.Fatalf("compare() = %v with score %.3f, want %v", ok, p.score, tt.wantOk)
+			}
+			if ok && math.Abs(p.score-tt.wantScore) > 1e-9 {
+				t.Errorf("score = %.3f, want %.3f", p.score, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestTitleSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"witcher 3", "witcher 3", 1},
+		{"witcher 3", "witcher 3 wild hunt", 0.9},
+		{"dark souls", "dark light", 0.45},
+		{"hades", "celeste", 0},
+		{"", "hades", 0},
+	}
+	for _, tt := range tests {
+		if got := titleSimilarity(wordSet(tt.a), wordSet(tt.b)); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("titleSimilarity(%q, %q) = %.3f, want %.3f", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	games := []gameInfo{
+		newGameInfo(gameModels.Game{Id: "1", Title: "The Witcher 3"}),
+		newGameInfo(gameModels.Game{Id: "2", Title: "Celeste"}),
+		newGameInfo(gameModels.Game{Id: "3", Title: "Witcher 3: Wild Hunt"}),
+		newGameInfo(gameModels.Game{Id: "4", Title: "Witcher III"}),
+	}
+
+	pairs := findDuplicates(games)
+	want := [][2]int{{0, 3}, {0, 2}, {2, 3}}
+	if len(pairs) != len(want) {
+		t.Fatalf("pairs = %+v, want %v", pairs, want)
+	}
+	for i, p := range pairs {
+		if p.first != want[i][0] || p.second != want[i][1] {
+			t.Errorf("pair %d = (%d, %d), want %v", i, p.first, p.second, want[i])
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/Alexander272/games-library/internal/duplicate/models"
+	"github.com/Alexander272/games-library/internal/service"
+	"github.com/Alexander272/games-library/internal/transport/middleware"
+	"github.com/Alexander272/games-library/internal/transport/validation"
+	userModels "github.com/Alexander272/games-library/internal/user/models"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services   *service.Services
+	middleware *middleware.Middleware
+}
+
+func NewHandler(services *service.Services, middleware *middleware.Middleware) *Handler {
+	return &Handler{
+		services:   services,
+		middleware: middleware,
+	}
+}
+
+func (h *Handler) Init(api *gin.RouterGroup) {
+	admin := api.Group("", h.middleware.UserIdentity, h.middleware.AccessForRoles(userModels.RoleAdmin), h.middleware.ObjectIds("id"))
+	{
+		admin.GET("/duplicates", h.getAll)
+		admin.POST("/duplicates/detect", h.detect)
+		admin.POST("/duplicates/:id/dismiss", h.dismiss)
+		admin.POST("/games/:id/merge", h.merge)
+	}
+}
+
+// @Summary Get All
+// @Security ApiKeyAuth
+// @Tags duplicates
+// @Description получение возможных дубликатов игр с оценкой сходства
+// @ID getDuplicates
+// @Accept json
+// @Produce json
+// @Param filter query models.CandidateFilter false "filter"
+// @Success 200 {object} dataResponse{data=[]models.Candidate}
+// @Failure 400,401,403,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /duplicates [get]
+func (h *Handler) getAll(c *gin.Context) {
+	var filter models.CandidateFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(validation.Query(err))
+		return
+	}
+
+	candidates, count, err := h.services.Duplicate.GetAll(c, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: candidates, Count: count})
+}
+
+// @Summary Detect
+// @Security ApiKeyAuth
+// @Tags duplicates
+// @Description запуск поиска дубликатов вне расписания
+// @ID detectDuplicates
+// @Accept json
+// @Produce json
+// @Success 202 {object} response
+// @Failure 401,403,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /duplicates/detect [post]
+func (h *Handler) detect(c *gin.Context) {
+	if err := h.services.Detection.Start(); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusAccepted, response{Message: "Detection started"})
+}
+
+// @Summary Dismiss
+// @Security ApiKeyAuth
+// @Tags duplicates
+// @Description отметка, что игры не являются дубликатами. пара больше не будет предлагаться
+// @ID dismissDuplicate
+// @Accept json
+// @Produce json
+// @Param id path string true "candidate id"
+// @Success 200 {object} response
+// @Failure 401,403,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /duplicates/{id}/dismiss [post]
+func (h *Handler) dismiss(c *gin.Context) {
+	if err := h.services.Duplicate.Dismiss(c, c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response{Message: "Dismissed successfully"})
+}
+
+// @Summary Merge
+// @Security ApiKeyAuth
+// @Tags duplicates
+// @Description слияние дубликата с игрой. записи библиотек, оценки, достижения, сессии, списки и цены переносятся, дубликат удаляется, а его slug перенаправляет на игру. слияние выполняется в транзакции, поэтому mongo должна быть запущена как replica set или sharded cluster, иначе возвращается MERGE_UNSUPPORTED
+// @ID mergeGames
+// @Accept json
+// @Produce json
+// @Param id path string true "surviving game id"
+// @Param merge body models.MergeDTO true "duplicate info"
+// @Success 200 {object} dataResponse{data=models.MergeReport}
+// @Failure 400,401,403,404,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id}/merge [post]
+func (h *Handler) merge(c *gin.Context) {
+	var dto models.MergeDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.SurvivorId = c.Param("id")
+
+	report, err := h.services.Merge.Merge(c, dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: report})
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/internal/game/service"
+	"github.com/Alexander272/games-library/pkg/lifecycle"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IGameRepo interface {
+	repository.IGame
+}
+type IRedirectRepo interface {
+	repository.IRedirect
+}
+type ICompanyRepo interface {
+	repository.ICompany
+}
+type ITermRepo interface {
+	repository.ITerm
+}
+type IImportRepo interface {
+	repository.IImport
+}
+
+type IGameService interface {
+	service.IGame
+}
+type ICompanyService interface {
+	service.ICompany
+}
+type ITermService interface {
+	service.ITerm
+}
+type IImportService interface {
+	service.IImport
+}
+
+func NewGameRepo(db *mongo.Database, collection string) IGameRepo {
+	return repository.NewGameRepo(db, collection)
+}
+func NewRedirectRepo(db *mongo.Database, collection string) IRedirectRepo {
+	return repository.NewRedirectRepo(db, collection)
+}
+func NewCompanyRepo(db *mongo.Database, collection string) ICompanyRepo {
+	return repository.NewCompanyRepo(db, collection)
+}
+func NewTermRepo(db *mongo.Database, collection string) ITermRepo {
+	return repository.NewTermRepo(db, collection)
+}
+func NewImportRepo(db *mongo.Database, collection string) IImportRepo {
+	return repository.NewImportRepo(db, collection)
+}
+
+func NewGameService(repo repository.IGame, redirects repository.IRedirect, terms repository.ITerm, companies repository.ICompany) IGameService {
+	return service.NewGameService(repo, redirects, terms, companies)
+}
+func NewCompanyService(repo repository.ICompany, games repository.IGame) ICompanyService {
+	return service.NewCompanyService(repo, games)
+}
+func NewTermService(repo repository.ITerm) ITermService {
+	return service.NewTermService(repo)
+}
+func NewImportService(games repository.IGame, redirects repository.IRedirect, companies repository.ICompany, jobs repository.IImport, background *lifecycle.Background) IImportService {
+	return service.NewImportService(games, redirects, companies, jobs, background)
+}
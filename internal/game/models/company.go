@@ -0,0 +1,19 @@
+package models
+
+// Company is the developer or the publisher of games. Games store the name of the company,
+// the company gives the name a slug for the urls. The old slugs of the renamed company are kept
+// in the history and redirect to it.
+type Company struct {
+	Id      string   `json:"id" bson:"_id,omitempty"`
+	Slug    string   `json:"slug" bson:"slug"`
+	Name    string   `json:"name" bson:"name"`
+	History []string `json:"-" bson:"history,omitempty"`
+}
+
+// UpdateCompanyDTO renames the company. The games of the company get the new name,
+// the slug is generated from the name if it isn't set.
+type UpdateCompanyDTO struct {
+	Id   string `json:"-"`
+	Name string `json:"name" binding:"max=256"`
+	Slug string `json:"slug" binding:"omitempty,slug"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import "github.com/Alexander272/games-library/pkg/apperror"
+
+var (
+	ErrGameNotFound     = apperror.NotFound("GAME_NOT_FOUND", "game doesn't exists")
+	ErrGameExists       = apperror.Conflict("GAME_EXISTS", "game with the same slug already exists")
+	ErrInvalidSlug      = apperror.Validation("INVALID_SLUG", "slug must contain only lowercase latin letters, digits and dashes")
+	ErrReleaseNotFound  = apperror.NotFound("RELEASE_NOT_FOUND", "release doesn't exists")
+	ErrRedirectNotFound = apperror.NotFound("REDIRECT_NOT_FOUND", "redirect doesn't exists")
+	ErrCompanyNotFound  = apperror.NotFound("COMPANY_NOT_FOUND", "company doesn't exists")
+	ErrCompanyExists    = apperror.Conflict("COMPANY_EXISTS", "company with the same name or slug already exists")
+	ErrTermNotFound     = apperror.NotFound("TERM_NOT_FOUND", "term doesn't exists")
+	ErrUnknownTermKind  = apperror.Validation("UNKNOWN_TERM_KIND", "unknown term kind, supported are genre, tag and platform")
+	ErrInvalidBarcode   = apperror.Validation("INVALID_BARCODE", "invalid EAN barcode")
+	ErrJobNotFound      = apperror.NotFound("IMPORT_JOB_NOT_FOUND", "import job doesn't exists")
+	ErrUnknownFormat    = apperror.Validation("UNKNOWN_FILE_FORMAT", "unknown file format")
+)
@@ -0,0 +1,112 @@
+package models
+
+import "time"
+
+type Game struct {
+	Id          string    `json:"id" bson:"_id,omitempty"`
+	Slug        string    `json:"slug" bson:"slug,omitempty"`
+	ExternalId  string    `json:"externalId,omitempty" bson:"externalId,omitempty"`
+	Title       string    `json:"title" bson:"title,omitempty"`
+	Description string    `json:"description" bson:"description,omitempty"`
+	Developer   string    `json:"developer" bson:"developer,omitempty"`
+	Publisher   string    `json:"publisher" bson:"publisher,omitempty"`
+	Genres      []string  `json:"genres" bson:"genres,omitempty"`
+	Tags        []string  `json:"tags" bson:"tags,omitempty"`
+	Platforms   []string  `json:"platforms" bson:"platforms,omitempty"`
+	ReleaseDate time.Time `json:"releaseDate" bson:"releaseDate,omitempty"`
+	Releases    []Release `json:"releases" bson:"releases,omitempty"`
+
+	Translations []Translation `json:"translations,omitempty" bson:"translations,omitempty"`
+	// GenreNames, TagNames and PlatformNames are the genres, tags and platforms in the requested language.
+	GenreNames    []string `json:"genreNames,omitempty" bson:"-"`
+	TagNames      []string `json:"tagNames,omitempty" bson:"-"`
+	PlatformNames []string `json:"platformNames,omitempty" bson:"-"`
+}
+
+// GameFilter selects games for listings and exports. The release fields are applied
+// to the same release, e.g. platform=PS4 and year=2021 means "released on PS4 in 2021".
+type GameFilter struct {
+	Search    string `form:"search"`
+	Genre     string `form:"genre"`
+	Tag       string `form:"tag"`
+	Platform  string `form:"platform"`
+	Developer string `form:"developer"`
+	Publisher string `form:"publisher"`
+
+	ReleasePlatform string `form:"releasePlatform"`
+	ReleaseRegion   string `form:"releaseRegion"`
+	ReleaseYear     int    `form:"releaseYear" binding:"omitempty,min=1950,max=2100"`
+	Edition         string `form:"edition"`
+
+	Limit int64 `form:"limit" binding:"omitempty,min=1,max=100"`
+	Skip  int64 `form:"skip" binding:"omitempty,min=0"`
+
+	// Locale is the language of the returned games, it is set from the request.
+	Locale string `form:"-"`
+}
+
+func NewGame(dto CreateGameDTO) Game {
+	return Game{
+		Slug:        dto.Slug,
+		ExternalId:  dto.ExternalId,
+		Title:       dto.Title,
+		Description: dto.Description,
+		Developer:   dto.Developer,
+		Publisher:   dto.Publisher,
+		Genres:      dto.Genres,
+		Tags:        dto.Tags,
+		Platforms:   dto.Platforms,
+		ReleaseDate: dto.ReleaseDate,
+
+		Translations: dto.Translations,
+	}
+}
+
+type CreateGameDTO struct {
+	Slug        string    `json:"slug" binding:"omitempty,slug"`
+	ExternalId  string    `json:"externalId"`
+	Title       string    `json:"title" binding:"required,min=1,max=256"`
+	Description string    `json:"description"`
+	Developer   string    `json:"developer"`
+	Publisher   string    `json:"publisher"`
+	Genres      []string  `json:"genres"`
+	Tags        []string  `json:"tags"`
+	Platforms   []string  `json:"platforms"`
+	ReleaseDate time.Time `json:"releaseDate"`
+
+	Translations []Translation `json:"translations" binding:"omitempty,dive"`
+}
+
+func UpdateGame(dto UpdateGameDTO) Game {
+	return Game{
+		Id:          dto.Id,
+		Slug:        dto.Slug,
+		ExternalId:  dto.ExternalId,
+		Title:       dto.Title,
+		Description: dto.Description,
+		Developer:   dto.Developer,
+		Publisher:   dto.Publisher,
+		Genres:      dto.Genres,
+		Tags:        dto.Tags,
+		Platforms:   dto.Platforms,
+		ReleaseDate: dto.ReleaseDate,
+
+		Translations: dto.Translations,
+	}
+}
+
+type UpdateGameDTO struct {
+	Id          string    `json:"id"`
+	Slug        string    `json:"slug" binding:"omitempty,slug"`
+	ExternalId  string    `json:"externalId"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Developer   string    `json:"developer"`
+	Publisher   string    `json:"publisher"`
+	Genres      []string  `json:"genres"`
+	Tags        []string  `json:"tags"`
+	Platforms   []string  `json:"platforms"`
+	ReleaseDate time.Time `json:"releaseDate"`
+
+	Translations []Translation `json:"translations" binding:"omitempty,dive"`
+}
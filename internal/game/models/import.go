@@ -0,0 +1,79 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+const (
+	JobPending  = "pending"
+	JobRunning  = "running"
+	JobDone     = "done"
+	JobFailed   = "failed"
+	maxRowError = 1000
+)
+
+// ImportRow is a single record of the import file. Columns of the csv file
+// are mapped to the fields by their json names, translations are read from
+// the title_<locale> and description_<locale> columns, e.g. title_ru.
+type ImportRow struct {
+	Slug        string   `json:"slug"`
+	ExternalId  string   `json:"externalId"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Developer   string   `json:"developer"`
+	Publisher   string   `json:"publisher"`
+	Genres      []string `json:"genres"`
+	Tags        []string `json:"tags"`
+	Platforms   []string `json:"platforms"`
+	ReleaseDate string   `json:"releaseDate"`
+
+	Translations []Translation `json:"translations"`
+}
+
+type RowError struct {
+	Row     int    `json:"row" bson:"row"`
+	Field   string `json:"field,omitempty" bson:"field,omitempty"`
+	Message string `json:"message" bson:"message"`
+}
+
+func (e RowError) Error() string {
+	if e.Field != "" {
+		return fmt.Sprintf("row %d: %s: %s", e.Row, e.Field, e.Message)
+	}
+	return fmt.Sprintf("row %d: %s", e.Row, e.Message)
+}
+
+type ImportReport struct {
+	Total     int        `json:"total" bson:"total"`
+	Processed int        `json:"processed" bson:"processed"`
+	Created   int        `json:"created" bson:"created"`
+	Updated   int        `json:"updated" bson:"updated"`
+	Failed    int        `json:"failed" bson:"failed"`
+	Errors    []RowError `json:"errors" bson:"errors"`
+}
+
+// AddError saves the row error. Only the first maxRowError errors are kept
+// so that the broken file does not blow up the job document.
+func (r *ImportReport) AddError(e RowError) {
+	r.Failed++
+	if len(r.Errors) < maxRowError {
+		r.Errors = append(r.Errors, e)
+	}
+}
+
+type ImportJob struct {
+	Id         string       `json:"id" bson:"_id,omitempty"`
+	Status     string       `json:"status" bson:"status"`
+	Format     string       `json:"format" bson:"format"`
+	Filename   string       `json:"filename" bson:"filename"`
+	Error      string       `json:"error,omitempty" bson:"error,omitempty"`
+	Report     ImportReport `json:"report" bson:"report"`
+	CreatedAt  time.Time    `json:"createdAt" bson:"createdAt"`
+	FinishedAt time.Time    `json:"finishedAt,omitempty" bson:"finishedAt,omitempty"`
+}
@@ -0,0 +1,117 @@
+package models
+
+// Translation is the title and the description of the game in the language.
+// The title and the description of the game itself are used when there is no translation.
+type Translation struct {
+	Locale      string `json:"locale" bson:"locale" binding:"required,oneof=en ru"`
+	Title       string `json:"title" bson:"title,omitempty" binding:"max=256"`
+	Description string `json:"description" bson:"description,omitempty"`
+}
+
+const (
+	TermGenre    = "genre"
+	TermTag      = "tag"
+	TermPlatform = "platform"
+)
+
+// Term is the name of the genre, tag or platform in different languages. Games store the key.
+type Term struct {
+	Kind  string            `json:"kind" bson:"kind"`
+	Key   string            `json:"key" bson:"key"`
+	Names map[string]string `json:"names" bson:"names"`
+}
+
+type TermFilter struct {
+	Kind string `form:"kind" binding:"omitempty,oneof=genre tag platform"`
+}
+
+type TermDTO struct {
+	Kind  string            `json:"-"`
+	Key   string            `json:"-"`
+	Names map[string]string `json:"names" binding:"required,min=1,dive,keys,oneof=en ru,endkeys,required,max=128"`
+}
+
+// IsTermKind reports whether the kind is genre, tag or platform.
+func IsTermKind(kind string) bool {
+	return kind == TermGenre || kind == TermTag || kind == TermPlatform
+}
+
+// Terms are names of genres, tags and platforms by the kind and the key.
+type Terms map[string]map[string]map[string]string
+
+func NewTerms(terms []Term) Terms {
+	res := make(Terms)
+	for _, t := range terms {
+		if res[t.Kind] == nil {
+			res[t.Kind] = make(map[string]map[string]string)
+		}
+		res[t.Kind][t.Key] = t.Names
+	}
+	return res
+}
+
+// Name returns the name of the term in the first locale of the chain that has it, or the key itself.
+func (t Terms) Name(kind, key string, chain []string) string {
+	names := t[kind][key]
+	for _, l := range chain {
+		if name := names[l]; name != "" {
+			return name
+		}
+	}
+	return key
+}
+
+// Localize replaces the title and the description with the translation to the first locale
+// of the chain that has them and fills the names of genres, tags and platforms.
+func (g *Game) Localize(chain []string, terms Terms) {
+	g.Title = g.translate(chain, func(t Translation) string { return t.Title }, g.Title)
+	g.Description = g.translate(chain, func(t Translation) string { return t.Description }, g.Description)
+
+	g.GenreNames = make([]string, 0, len(g.Genres))
+	for _, genre := range g.Genres {
+		g.GenreNames = append(g.GenreNames, terms.Name(TermGenre, genre, chain))
+	}
+	g.TagNames = make([]string, 0, len(g.Tags))
+	for _, tag := range g.Tags {
+		g.TagNames = append(g.TagNames, terms.Name(TermTag, tag, chain))
+	}
+	g.PlatformNames = make([]string, 0, len(g.Platforms))
+	for _, platform := range g.Platforms {
+		g.PlatformNames = append(g.PlatformNames, terms.Name(TermPlatform, platform, chain))
+	}
+}
+
+// TermKeys returns the keys of genres, tags and platforms of the games by the kind,
+// so only the terms used by them are loaded.
+func TermKeys(games ...Game) map[string][]string {
+	keys := make(map[string][]string)
+	seen := make(map[string]map[string]bool)
+	add := func(kind string, values []string) {
+		if seen[kind] == nil {
+			seen[kind] = make(map[string]bool)
+		}
+		for _, v := range values {
+			if !seen[kind][v] {
+				seen[kind][v] = true
+				keys[kind] = append(keys[kind], v)
+			}
+		}
+	}
+	for _, g := range games {
+		add(TermGenre, g.Genres)
+		add(TermTag, g.Tags)
+		add(TermPlatform, g.Platforms)
+	}
+	return keys
+}
+
+func (g *Game) translate(chain []string, field func(Translation) string, fallback string) string {
+	for _, l := range chain {
+		for _, t := range g.Translations {
+			if t.Locale == l && field(t) != "" {
+				return field(t)
+			}
+		}
+	}
+	return fallback
+}
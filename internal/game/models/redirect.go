@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// Redirect points the old slug or id of the game to the game that replaced it,
+// e.g. after the duplicate was merged into another game. The old slugs of companies are kept in the company.
+type Redirect struct {
+	From      string    `json:"from" bson:"_id"`
+	GameId    string    `json:"gameId" bson:"gameId"`
+	CreatedAt time.Time `json:"createdAt" bson:"createdAt"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+const (
+	EditionStandard = "standard"
+	EditionDeluxe   = "deluxe"
+	EditionGOTY     = "goty"
+)
+
+// Release is a release of the game on the platform in the region. The same game
+// may have several releases with different dates and editions.
+type Release struct {
+	Id        string    `json:"id" bson:"id"`
+	Platform  string    `json:"platform" bson:"platform"`
+	Region    string    `json:"region" bson:"region"`
+	Date      time.Time `json:"date" bson:"date"`
+	Edition   string    `json:"edition" bson:"edition"`
+	Barcode   string    `json:"barcode,omitempty" bson:"barcode,omitempty"`
+	AgeRating string    `json:"ageRating,omitempty" bson:"ageRating,omitempty"`
+}
+
+func NewRelease(dto ReleaseDTO) Release {
+	return Release{
+		Id:        dto.Id,
+		Platform:  dto.Platform,
+		Region:    dto.Region,
+		Date:      dto.Date,
+		Edition:   dto.Edition,
+		Barcode:   dto.Barcode,
+		AgeRating: dto.AgeRating,
+	}
+}
+
+type ReleaseDTO struct {
+	Id        string    `json:"-"`
+	GameId    string    `json:"-"`
+	Platform  string    `json:"platform" binding:"required,max=64"`
+	Region    string    `json:"region" binding:"required,max=16"`
+	Date      time.Time `json:"date" binding:"required"`
+	Edition   string    `json:"edition" binding:"max=64"`
+	Barcode   string    `json:"barcode" binding:"omitempty,numeric,min=8,max=13"`
+	AgeRating string    `json:"ageRating" binding:"max=32"`
+}
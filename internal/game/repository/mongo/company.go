@@ -0,0 +1,134 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CompanyRepo struct {
+	db *mongo.Collection
+}
+
+func NewCompanyRepo(db *mongo.Database, collection string) *CompanyRepo {
+	return &CompanyRepo{
+		db: db.Collection(collection),
+	}
+}
+
+// EnsureIndexes creates the unique indexes of the name, the slug and the old slugs,
+// so concurrent requests can't create the same company twice or give two companies the same slug.
+func (r *CompanyRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.db.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "name", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "slug", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{
+			Keys:    bson.D{{Key: "history", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"history": bson.M{"$exists": true}}),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create indexes. error: %w", err)
+	}
+	return nil
+}
+
+func (r *CompanyRepo) Create(ctx context.Context, company models.Company) (id string, err error) {
+	res, err := r.db.InsertOne(ctx, bson.M{"name": company.Name, "slug": company.Slug})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return id, models.ErrCompanyExists
+		}
+		return id, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	oid, ok := res.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return id, fmt.Errorf("failed to convert objectid")
+	}
+	logger.Tracef("Created document with oid %s.\n", oid)
+	return oid.Hex(), nil
+}
+
+func (r *CompanyRepo) GetAll(ctx context.Context) (companies []models.Company, err error) {
+	cur, err := r.db.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+	if err != nil {
+		return companies, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &companies); err != nil {
+		return companies, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return companies, nil
+}
+
+func (r *CompanyRepo) GetById(ctx context.Context, companyId string) (company models.Company, err error) {
+	oid, err := primitive.ObjectIDFromHex(companyId)
+	if err != nil {
+		return company, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	return r.findOne(ctx, bson.M{"_id": oid})
+}
+
+func (r *CompanyRepo) GetBySlug(ctx context.Context, slug string) (company models.Company, err error) {
+	return r.findOne(ctx, bson.M{"slug": slug})
+}
+
+func (r *CompanyRepo) GetByName(ctx context.Context, name string) (company models.Company, err error) {
+	return r.findOne(ctx, bson.M{"name": name})
+}
+
+// GetByOldSlug returns the company that had the slug before it was renamed.
+func (r *CompanyRepo) GetByOldSlug(ctx context.Context, slug string) (company models.Company, err error) {
+	return r.findOne(ctx, bson.M{"history": slug})
+}
+
+func (r *CompanyRepo) findOne(ctx context.Context, filter bson.M) (company models.Company, err error) {
+	res := r.db.FindOne(ctx, filter)
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return company, models.ErrCompanyNotFound
+		}
+		return company, fmt.Errorf("failed to execute query. error: %w", res.Err())
+	}
+	if err := res.Decode(&company); err != nil {
+		return company, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return company, nil
+}
+
+// Update sets the name, the slug and the old slugs of the company.
+func (r *CompanyRepo) Update(ctx context.Context, company models.Company) error {
+	oid, err := primitive.ObjectIDFromHex(company.Id)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	update := bson.M{"$set": bson.M{"name": company.Name, "slug": company.Slug, "history": company.History}}
+	if len(company.History) == 0 {
+		update = bson.M{"$set": bson.M{"name": company.Name, "slug": company.Slug}, "$unset": bson.M{"history": ""}}
+	}
+
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.ErrCompanyExists
+		}
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrCompanyNotFound
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
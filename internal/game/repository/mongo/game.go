@@ -0,0 +1,336 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type GameRepo struct {
+	db *mongo.Collection
+}
+
+func NewGameRepo(db *mongo.Database, collection string) *GameRepo {
+	return &GameRepo{
+		db: db.Collection(collection),
+	}
+}
+
+// EnsureIndexes creates the unique indexes of the slug and the external id, so concurrent requests
+// can't give two games the same slug or import the same game twice. Games without them aren't indexed.
+func (r *GameRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.db.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "slug", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"slug": bson.M{"$exists": true}}),
+		},
+		{
+			Keys:    bson.D{{Key: "externalId", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"externalId": bson.M{"$exists": true}}),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create indexes. error: %w", err)
+	}
+	return nil
+}
+
+func (r *GameRepo) Create(ctx context.Context, game models.Game) (id string, err error) {
+	res, err := r.db.InsertOne(ctx, game)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return id, models.ErrGameExists
+		}
+		return id, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	oid, ok := res.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return id, fmt.Errorf("failed to convert objectid")
+	}
+	logger.Tracef("Created document with oid %s.\n", oid)
+	return oid.Hex(), nil
+}
+
+func (r *GameRepo) GetAll(ctx context.Context, filter models.GameFilter) (games []models.Game, count int64, err error) {
+	query := buildFilter(filter)
+
+	opts := options.Find().SetSort(bson.D{{Key: "title", Value: 1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(filter.Limit)
+	}
+	if filter.Skip > 0 {
+		opts.SetSkip(filter.Skip)
+	}
+
+	cur, err := r.db.Find(ctx, query, opts)
+	if err != nil {
+		return games, count, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &games); err != nil {
+		return games, count, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	count, err = r.db.CountDocuments(ctx, query)
+	if err != nil {
+		return games, count, fmt.Errorf("failed to count documents. error: %w", err)
+	}
+
+	return games, count, nil
+}
+
+// Export iterates over all games matching the filter without loading them into memory.
+func (r *GameRepo) Export(ctx context.Context, filter models.GameFilter, fn func(models.Game) error) error {
+	opts := options.Find().SetSort(bson.D{{Key: "title", Value: 1}})
+	cur, err := r.db.Find(ctx, buildFilter(filter), opts)
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var game models.Game
+		if err := cur.Decode(&game); err != nil {
+			return fmt.Errorf("failed to decode document. error: %w", err)
+		}
+		if err := fn(game); err != nil {
+			return err
+		}
+	}
+	if err := cur.Err(); err != nil {
+		return fmt.Errorf("failed to iterate cursor. error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GameRepo) GetById(ctx context.Context, gameId string) (game models.Game, err error) {
+	oid, err := primitive.ObjectIDFromHex(gameId)
+	if err != nil {
+		return game, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	return r.findOne(ctx, bson.M{"_id": oid})
+}
+
+func (r *GameRepo) GetBySlug(ctx context.Context, slug string) (game models.Game, err error) {
+	return r.findOne(ctx, bson.M{"slug": slug})
+}
+
+func (r *GameRepo) GetByExternalId(ctx context.Context, externalId string) (game models.Game, err error) {
+	return r.findOne(ctx, bson.M{"externalId": externalId})
+}
+
+func (r *GameRepo) findOne(ctx context.Context, filter bson.M) (game models.Game, err error) {
+	res := r.db.FindOne(ctx, filter)
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return game, models.ErrGameNotFound
+		}
+		return game, fmt.Errorf("failed to execute query. error: %w", res.Err())
+	}
+	if err := res.Decode(&game); err != nil {
+		return game, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return game, nil
+}
+
+func (r *GameRepo) Update(ctx context.Context, game models.Game) error {
+	oid, err := primitive.ObjectIDFromHex(game.Id)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	updateObj, err := toUpdate(game)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": updateObj})
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.ErrGameExists
+		}
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrGameNotFound
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+// GetCompanyNames returns the distinct names of the developers and the publishers of the games.
+func (r *GameRepo) GetCompanyNames(ctx context.Context) ([]string, error) {
+	known := make(map[string]bool)
+	var names []string
+	for _, field := range []string{"developer", "publisher"} {
+		values, err := r.db.Distinct(ctx, field, bson.M{field: bson.M{"$exists": true}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute query. error: %w", err)
+		}
+		for _, v := range values {
+			if name, ok := v.(string); ok && name != "" && !known[name] {
+				known[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names, nil
+}
+
+// RenameCompany replaces the name of the developer and the publisher in the games.
+func (r *GameRepo) RenameCompany(ctx context.Context, from, to string) error {
+	for _, field := range []string{"developer", "publisher"} {
+		res, err := r.db.UpdateMany(ctx, bson.M{field: from}, bson.M{"$set": bson.M{field: to}})
+		if err != nil {
+			return fmt.Errorf("failed to execute query. error: %w", err)
+		}
+		logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	}
+	return nil
+}
+
+func (r *GameRepo) Remove(ctx context.Context, gameId string) error {
+	oid, err := primitive.ObjectIDFromHex(gameId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	res, err := r.db.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return models.ErrGameNotFound
+	}
+
+	logger.Tracef("Delete %v documents.\n", res.DeletedCount)
+	return nil
+}
+
+func (r *GameRepo) AddRelease(ctx context.Context, gameId string, release models.Release) error {
+	oid, err := primitive.ObjectIDFromHex(gameId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$push": bson.M{"releases": release}})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrGameNotFound
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+func (r *GameRepo) UpdateRelease(ctx context.Context, gameId string, release models.Release) error {
+	oid, err := primitive.ObjectIDFromHex(gameId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	filter := bson.M{"_id": oid, "releases.id": release.Id}
+	res, err := r.db.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"releases.$": release}})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrReleaseNotFound
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+func (r *GameRepo) RemoveRelease(ctx context.Context, gameId, releaseId string) error {
+	oid, err := primitive.ObjectIDFromHex(gameId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	filter := bson.M{"_id": oid, "releases.id": releaseId}
+	res, err := r.db.UpdateOne(ctx, filter, bson.M{"$pull": bson.M{"releases": bson.M{"id": releaseId}}})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrReleaseNotFound
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+func toUpdate(game models.Game) (bson.M, error) {
+	gameByte, err := bson.Marshal(game)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal document. error: %w", err)
+	}
+
+	var updateObj bson.M
+	if err := bson.Unmarshal(gameByte, &updateObj); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal document. error: %w", err)
+	}
+	delete(updateObj, "_id")
+
+	return updateObj, nil
+}
+
+func buildFilter(filter models.GameFilter) bson.M {
+	query := bson.M{}
+	if filter.Search != "" {
+		// every translation of the title is searched too
+		search := primitive.Regex{Pattern: regexp.QuoteMeta(filter.Search), Options: "i"}
+		query["$or"] = bson.A{bson.M{"title": search}, bson.M{"translations.title": search}}
+	}
+	if filter.Genre != "" {
+		query["genres"] = filter.Genre
+	}
+	if filter.Tag != "" {
+		query["tags"] = filter.Tag
+	}
+	if filter.Platform != "" {
+		query["platforms"] = filter.Platform
+	}
+	if filter.Developer != "" {
+		query["developer"] = filter.Developer
+	}
+	if filter.Publisher != "" {
+		query["publisher"] = filter.Publisher
+	}
+
+	release := bson.M{}
+	if filter.ReleasePlatform != "" {
+		release["platform"] = filter.ReleasePlatform
+	}
+	if filter.ReleaseRegion != "" {
+		release["region"] = filter.ReleaseRegion
+	}
+	if filter.Edition != "" {
+		release["edition"] = filter.Edition
+	}
+	if filter.ReleaseYear != 0 {
+		from := time.Date(filter.ReleaseYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+		release["date"] = bson.M{"$gte": from, "$lt": from.AddDate(1, 0, 0)}
+	}
+	if len(release) > 0 {
+		query["releases"] = bson.M{"$elemMatch": release}
+	}
+
+	return query
+}
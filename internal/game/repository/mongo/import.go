@@ -0,0 +1,79 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ImportRepo struct {
+	db *mongo.Collection
+}
+
+func NewImportRepo(db *mongo.Database, collection string) *ImportRepo {
+	return &ImportRepo{
+		db: db.Collection(collection),
+	}
+}
+
+func (r *ImportRepo) Create(ctx context.Context, job models.ImportJob) (id string, err error) {
+	res, err := r.db.InsertOne(ctx, job)
+	if err != nil {
+		return id, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	oid, ok := res.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return id, fmt.Errorf("failed to convert objectid")
+	}
+	return oid.Hex(), nil
+}
+
+func (r *ImportRepo) GetById(ctx context.Context, jobId string) (job models.ImportJob, err error) {
+	oid, err := primitive.ObjectIDFromHex(jobId)
+	if err != nil {
+		return job, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	res := r.db.FindOne(ctx, bson.M{"_id": oid})
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return job, models.ErrJobNotFound
+		}
+		return job, fmt.Errorf("failed to execute query. error: %w", res.Err())
+	}
+	if err := res.Decode(&job); err != nil {
+		return job, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return job, nil
+}
+
+func (r *ImportRepo) Update(ctx context.Context, job models.ImportJob) error {
+	oid, err := primitive.ObjectIDFromHex(job.Id)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	update := bson.M{"$set": bson.M{
+		"status":     job.Status,
+		"error":      job.Error,
+		"report":     job.Report,
+		"finishedAt": job.FinishedAt,
+	}}
+
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrJobNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RedirectRepo stores the old slug or id as the _id of the redirect, so it is unique without another index.
+type RedirectRepo struct {
+	db *mongo.Collection
+}
+
+func NewRedirectRepo(db *mongo.Database, collection string) *RedirectRepo {
+	return &RedirectRepo{
+		db: db.Collection(collection),
+	}
+}
+
+// Set creates the redirect or points the existing one to the new game.
+func (r *RedirectRepo) Set(ctx context.Context, redirect models.Redirect) error {
+	gid, err := primitive.ObjectIDFromHex(redirect.GameId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	update := bson.M{"$set": bson.M{"gameId": gid, "createdAt": redirect.CreatedAt}}
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": redirect.From}, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+func (r *RedirectRepo) Get(ctx context.Context, from string) (redirect models.Redirect, err error) {
+	res := r.db.FindOne(ctx, bson.M{"_id": from})
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return redirect, models.ErrRedirectNotFound
+		}
+		return redirect, fmt.Errorf("failed to execute query. error: %w", res.Err())
+	}
+	if err := res.Decode(&redirect); err != nil {
+		return redirect, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return redirect, nil
+}
+
+// Retarget points all redirects to the old game to the new one, so redirects are never chained.
+func (r *RedirectRepo) Retarget(ctx context.Context, fromGameId, toGameId string) error {
+	fromOid, err := primitive.ObjectIDFromHex(fromGameId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	toOid, err := primitive.ObjectIDFromHex(toGameId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	res, err := r.db.UpdateMany(ctx, bson.M{"gameId": fromOid}, bson.M{"$set": bson.M{"gameId": toOid}})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+func (r *RedirectRepo) RemoveByGame(ctx context.Context, gameId string) error {
+	oid, err := primitive.ObjectIDFromHex(gameId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	res, err := r.db.DeleteMany(ctx, bson.M{"gameId": oid})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Delete %v documents.\n", res.DeletedCount)
+	return nil
+}
@@ -0,0 +1,91 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type TermRepo struct {
+	db *mongo.Collection
+}
+
+func NewTermRepo(db *mongo.Database, collection string) *TermRepo {
+	return &TermRepo{
+		db: db.Collection(collection),
+	}
+}
+
+// Set creates the term or replaces its names.
+func (r *TermRepo) Set(ctx context.Context, term models.Term) error {
+	filter := bson.M{"kind": term.Kind, "key": term.Key}
+	update := bson.M{"$set": bson.M{"names": term.Names}}
+
+	res, err := r.db.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+// GetAll returns the terms of the kind or all terms if the kind is empty.
+func (r *TermRepo) GetAll(ctx context.Context, kind string) (terms []models.Term, err error) {
+	filter := bson.M{}
+	if kind != "" {
+		filter["kind"] = kind
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "kind", Value: 1}, {Key: "key", Value: 1}})
+	cur, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return terms, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &terms); err != nil {
+		return terms, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return terms, nil
+}
+
+// GetByKeys returns the terms with the keys grouped by the kind.
+func (r *TermRepo) GetByKeys(ctx context.Context, keys map[string][]string) (terms []models.Term, err error) {
+	or := bson.A{}
+	for kind, values := range keys {
+		if len(values) > 0 {
+			or = append(or, bson.M{"kind": kind, "key": bson.M{"$in": values}})
+		}
+	}
+	if len(or) == 0 {
+		return terms, nil
+	}
+
+	cur, err := r.db.Find(ctx, bson.M{"$or": or})
+	if err != nil {
+		return terms, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &terms); err != nil {
+		return terms, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return terms, nil
+}
+
+func (r *TermRepo) Remove(ctx context.Context, kind, key string) error {
+	res, err := r.db.DeleteOne(ctx, bson.M{"kind": kind, "key": key})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return models.ErrTermNotFound
+	}
+
+	logger.Tracef("Delete %v documents.\n", res.DeletedCount)
+	return nil
+}
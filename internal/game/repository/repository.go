@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	game "github.com/Alexander272/games-library/internal/game/repository/mongo"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IGame interface {
+	EnsureIndexes(ctx context.Context) error
+	Create(ctx context.Context, game models.Game) (string, error)
+	GetAll(ctx context.Context, filter models.GameFilter) ([]models.Game, int64, error)
+	Export(ctx context.Context, filter models.GameFilter, fn func(models.Game) error) error
+	GetById(ctx context.Context, gameId string) (models.Game, error)
+	GetBySlug(ctx context.Context, slug string) (models.Game, error)
+	GetByExternalId(ctx context.Context, externalId string) (models.Game, error)
+	Update(ctx context.Context, game models.Game) error
+	GetCompanyNames(ctx context.Context) ([]string, error)
+	RenameCompany(ctx context.Context, from, to string) error
+	Remove(ctx context.Context, gameId string) error
+	AddRelease(ctx context.Context, gameId string, release models.Release) error
+	UpdateRelease(ctx context.Context, gameId string, release models.Release) error
+	RemoveRelease(ctx context.Context, gameId, releaseId string) error
+}
+
+type IRedirect interface {
+	Set(ctx context.Context, redirect models.Redirect) error
+	Get(ctx context.Context, from string) (models.Redirect, error)
+	Retarget(ctx context.Context, fromGameId, toGameId string) error
+	RemoveByGame(ctx context.Context, gameId string) error
+}
+
+type ICompany interface {
+	EnsureIndexes(ctx context.Context) error
+	Create(ctx context.Context, company models.Company) (string, error)
+	GetAll(ctx context.Context) ([]models.Company, error)
+	GetById(ctx context.Context, companyId string) (models.Company, error)
+	GetBySlug(ctx context.Context, slug string) (models.Company, error)
+	GetByName(ctx context.Context, name string) (models.Company, error)
+	GetByOldSlug(ctx context.Context, slug string) (models.Company, error)
+	Update(ctx context.Context, company models.Company) error
+}
+
+type ITerm interface {
+	Set(ctx context.Context, term models.Term) error
+	GetAll(ctx context.Context, kind string) ([]models.Term, error)
+	GetByKeys(ctx context.Context, keys map[string][]string) ([]models.Term, error)
+	Remove(ctx context.Context, kind, key string) error
+}
+
+type IImport interface {
+	Create(ctx context.Context, job models.ImportJob) (string, error)
+	GetById(ctx context.Context, jobId string) (models.ImportJob, error)
+	Update(ctx context.Context, job models.ImportJob) error
+}
+
+func NewGameRepo(db *mongo.Database, collection string) IGame {
+	return game.NewGameRepo(db, collection)
+}
+
+func NewRedirectRepo(db *mongo.Database, collection string) IRedirect {
+	return game.NewRedirectRepo(db, collection)
+}
+
+func NewCompanyRepo(db *mongo.Database, collection string) ICompany {
+	return game.NewCompanyRepo(db, collection)
+}
+
+func NewTermRepo(db *mongo.Database, collection string) ITerm {
+	return game.NewTermRepo(db, collection)
+}
+
+func NewImportRepo(db *mongo.Database, collection string) IImport {
+	return game.NewImportRepo(db, collection)
+}
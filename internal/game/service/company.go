@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/Alexander272/games-library/pkg/slug"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// defaultCompanySlug is used for names without letters and digits.
+const defaultCompanySlug = "company"
+
+type CompanyService struct {
+	repo  repository.ICompany
+	games repository.IGame
+}
+
+func NewCompanyService(repo repository.ICompany, games repository.IGame) *CompanyService {
+	return &CompanyService{
+		repo:  repo,
+		games: games,
+	}
+}
+
+func (s *CompanyService) GetAll(ctx context.Context) (companies []models.Company, err error) {
+	companies, err = s.repo.GetAll(ctx)
+	if err != nil {
+		return companies, fmt.Errorf("failed to get companies. error: %w", err)
+	}
+	if len(companies) == 0 {
+		return companies, models.ErrCompanyNotFound
+	}
+	return companies, nil
+}
+
+// GetByIdOrSlug finds the company by its id or its current slug.
+func (s *CompanyService) GetByIdOrSlug(ctx context.Context, idOrSlug string) (company models.Company, err error) {
+	if primitive.IsValidObjectID(idOrSlug) {
+		company, err = s.repo.GetById(ctx, idOrSlug)
+	} else {
+		company, err = s.repo.GetBySlug(ctx, idOrSlug)
+	}
+	if err != nil {
+		if errors.Is(err, models.ErrCompanyNotFound) {
+			return company, err
+		}
+		return company, fmt.Errorf("failed to get company. error: %w", err)
+	}
+	return company, nil
+}
+
+// GetRedirect returns the company that had the slug before it was renamed.
+func (s *CompanyService) GetRedirect(ctx context.Context, from string) (company models.Company, err error) {
+	company, err = s.repo.GetByOldSlug(ctx, from)
+	if err != nil {
+		if errors.Is(err, models.ErrCompanyNotFound) {
+			return company, models.ErrRedirectNotFound
+		}
+		return company, fmt.Errorf("failed to get company by old slug. error: %w", err)
+	}
+	return company, nil
+}
+
+// Update renames the company and its games. When the name changes and the slug isn't set, a new slug
+// is generated. The old slug is kept in the history, so it redirects to the company.
+func (s *CompanyService) Update(ctx context.Context, dto models.UpdateCompanyDTO) error {
+	current, err := s.GetByIdOrSlug(ctx, dto.Id)
+	if err != nil {
+		return err
+	}
+
+	company := current
+	if dto.Name != "" && dto.Name != current.Name {
+		if _, err := s.repo.GetByName(ctx, dto.Name); err == nil {
+			return models.ErrCompanyExists
+		} else if !errors.Is(err, models.ErrCompanyNotFound) {
+			return fmt.Errorf("failed to get company by name. error: %w", err)
+		}
+		company.Name = dto.Name
+	}
+
+	switch {
+	case dto.Slug != "" && dto.Slug != current.Slug:
+		taken, err := s.slugTaken(ctx, dto.Slug, current.Id)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return models.ErrCompanyExists
+		}
+		company.Slug = dto.Slug
+	case dto.Slug == "" && company.Name != current.Name:
+		company.Slug, err = s.makeSlug(ctx, company.Name, current.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	if company.Slug != current.Slug {
+		company.History = moveSlug(current.History, current.Slug, company.Slug)
+	}
+
+	if err := s.repo.Update(ctx, company); err != nil {
+		if errors.Is(err, models.ErrCompanyNotFound) || errors.Is(err, models.ErrCompanyExists) {
+			return err
+		}
+		return fmt.Errorf("failed to update company. error: %w", err)
+	}
+
+	if company.Name != current.Name {
+		if err := s.games.RenameCompany(ctx, current.Name, company.Name); err != nil {
+			return fmt.Errorf("failed to rename company in games. error: %w", err)
+		}
+	}
+	return nil
+}
+
+// Ensure creates the companies with the names that don't exist yet, e.g. the developer of the new game.
+func (s *CompanyService) Ensure(ctx context.Context, names ...string) error {
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if err := s.ensure(ctx, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureAttempts limits the retries when a concurrent request takes the name or the generated slug.
+const ensureAttempts = 3
+
+func (s *CompanyService) ensure(ctx context.Context, name string) error {
+	for attempt := 1; ; attempt++ {
+		_, err := s.repo.GetByName(ctx, name)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, models.ErrCompanyNotFound) {
+			return fmt.Errorf("failed to get company by name. error: %w", err)
+		}
+
+		companySlug, err := s.makeSlug(ctx, name, "")
+		if err != nil {
+			return err
+		}
+		_, err = s.repo.Create(ctx, models.Company{Name: name, Slug: companySlug})
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, models.ErrCompanyExists) || attempt == ensureAttempts {
+			return fmt.Errorf("failed to create company. error: %w", err)
+		}
+	}
+}
+
+// Sync creates the companies of the games saved before the companies were stored separately.
+func (s *CompanyService) Sync(ctx context.Context) error {
+	names, err := s.games.GetCompanyNames(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get company names. error: %w", err)
+	}
+	companies, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get companies. error: %w", err)
+	}
+
+	known := make(map[string]bool, len(companies))
+	for _, c := range companies {
+		known[c.Name] = true
+	}
+	var missing []string
+	for _, name := range names {
+		if !known[name] {
+			missing = append(missing, name)
+		}
+	}
+
+	if err := s.Ensure(ctx, missing...); err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		logger.Infof("Created %d companies of the games.", len(missing))
+	}
+	return nil
+}
+
+// makeSlug generates the slug from the name, e.g. "valve", "valve-2" if "valve" is taken.
+func (s *CompanyService) makeSlug(ctx context.Context, name, companyId string) (string, error) {
+	return uniqueSlug(name, defaultCompanySlug, func(candidate string) (bool, error) {
+		return s.slugTaken(ctx, candidate, companyId)
+	})
+}
+
+// slugTaken reports whether another company has the slug or has it in the history.
+func (s *CompanyService) slugTaken(ctx context.Context, companySlug, companyId string) (bool, error) {
+	company, err := s.repo.GetBySlug(ctx, companySlug)
+	if err == nil {
+		return company.Id != companyId, nil
+	}
+	if !errors.Is(err, models.ErrCompanyNotFound) {
+		return false, fmt.Errorf("failed to get company by slug. error: %w", err)
+	}
+
+	company, err = s.repo.GetByOldSlug(ctx, companySlug)
+	if err == nil {
+		return company.Id != companyId, nil
+	}
+	if !errors.Is(err, models.ErrCompanyNotFound) {
+		return false, fmt.Errorf("failed to get company by old slug. error: %w", err)
+	}
+	return false, nil
+}
+
+// moveSlug adds the old slug to the history. The new slug is removed from it, when the company
+// gets back one of its old slugs.
+func moveSlug(history []string, from, to string) []string {
+	res := make([]string, 0, len(history)+1)
+	for _, s := range history {
+		if s != to && s != from {
+			res = append(res, s)
+		}
+	}
+	if from != "" {
+		res = append(res, from)
+	}
+	return res
+}
+
+// uniqueSlug generates the slug from the name with a numeric suffix when it's taken.
+func uniqueSlug(name, fallback string, taken func(slug string) (bool, error)) (string, error) {
+	base := slug.Make(name)
+	if base == "" {
+		base = fallback
+	}
+
+	unique, err := slug.Unique(base, taken)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate slug. error: %w", err)
+	}
+	return unique, nil
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/internal/game/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeCompanies keeps the companies in memory by id and rejects the same names and slugs like the indexes.
+type fakeCompanies struct {
+	repository.ICompany
+	companies map[string]models.Company
+}
+
+func newFakeCompanies(companies ...models.Company) *fakeCompanies {
+	f := &fakeCompanies{companies: make(map[string]models.Company)}
+	for _, c := range companies {
+		f.companies[c.Id] = c
+	}
+	return f
+}
+
+func (f *fakeCompanies) Create(ctx context.Context, company models.Company) (string, error) {
+	for _, c := range f.companies {
+		if c.Name == company.Name || c.Slug == company.Slug {
+			return "", models.ErrCompanyExists
+		}
+	}
+	company.Id = primitive.NewObjectID().Hex()
+	f.companies[company.Id] = company
+	return company.Id, nil
+}
+
+func (f *fakeCompanies) GetAll(ctx context.Context) (companies []models.Company, err error) {
+	for _, c := range f.companies {
+		companies = append(companies, c)
+	}
+	return companies, nil
+}
+
+func (f *fakeCompanies) GetById(ctx context.Context, companyId string) (models.Company, error) {
+	return f.find(func(c models.Company) bool { return c.Id == companyId })
+}
+
+func (f *fakeCompanies) GetBySlug(ctx context.Context, slug string) (models.Company, error) {
+	return f.find(func(c models.Company) bool { return c.Slug == slug })
+}
+
+func (f *fakeCompanies) GetByName(ctx context.Context, name string) (models.Company, error) {
+	return f.find(func(c models.Company) bool { return c.Name == name })
+}
+
+func (f *fakeCompanies) GetByOldSlug(ctx context.Context, slug string) (models.Company, error) {
+	return f.find(func(c models.Company) bool {
+		for _, s := range c.History {
+			if s == slug {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (f *fakeCompanies) Update(ctx context.Context, company models.Company) error {
+	if _, ok := f.companies[company.Id]; !ok {
+		return models.ErrCompanyNotFound
+	}
+	f.companies[company.Id] = company
+	return nil
+}
+
+func (f *fakeCompanies) find(match func(models.Company) bool) (models.Company, error) {
+	for _, c := range f.companies {
+		if match(c) {
+			return c, nil
+		}
+	}
+	return models.Company{}, models.ErrCompanyNotFound
+}
+
+// companyGames records the renamed companies and returns the names of the games.
+type companyGames struct {
+	repository.IGame
+	names   []string
+	renamed map[string]string
+}
+
+func (f *companyGames) GetCompanyNames(ctx context.Context) ([]string, error) {
+	return f.names, nil
+}
+
+func (f *companyGames) RenameCompany(ctx context.Context, from, to string) error {
+	f.renamed[from] = to
+	return nil
+}
+
+func TestCompanyUpdate(t *testing.T) {
+	id, otherId := primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()
+	tests := []struct {
+		name        string
+		dto         models.UpdateCompanyDTO
+		wantErr     error
+		wantSlug    string
+		wantHistory []string
+		wantRenamed map[string]string
+	}{
+		{
+			name:        "renamed",
+			dto:         models.UpdateCompanyDTO{Name: "CD Projekt"},
+			wantSlug:    "cd-projekt",
+			wantHistory: []string{"cdpr", "cd-projekt-red"},
+			wantRenamed: map[string]string{"CD Projekt Red": "CD Projekt"},
+		},
+		{
+			name:        "old slug is back",
+			dto:         models.UpdateCompanyDTO{Slug: "cdpr"},
+			wantSlug:    "cdpr",
+			wantHistory: []string{"cd-projekt-red"},
+			wantRenamed: map[string]string{},
+		},
+		{"slug of another company", models.UpdateCompanyDTO{Slug: "valve"}, models.ErrCompanyExists, "", nil, nil},
+		{"old slug of another company", models.UpdateCompanyDTO{Slug: "valve-software"}, models.ErrCompanyExists, "", nil, nil},
+		{"name of another company", models.UpdateCompanyDTO{Name: "Valve"}, models.ErrCompanyExists, "", nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			companies := newFakeCompanies(
+				models.Company{Id: id, Name: "CD Projekt Red", Slug: "cd-projekt-red", History: []string{"cdpr"}},
+				models.Company{Id: otherId, Name: "Valve", Slug: "valve", History: []string{"valve-software"}},
+			)
+			games := &companyGames{renamed: map[string]string{}}
+			s := NewCompanyService(companies, games)
+
+			tt.dto.Id = "cd-projekt-red"
+			err := s.Update(context.Background(), tt.dto)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Update() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			company := companies.companies[id]
+			if company.Slug != tt.wantSlug || !reflect.DeepEqual(company.History, tt.wantHistory) {
+				t.Errorf("slug = %q, history = %v, want %q, %v", company.Slug, company.History, tt.wantSlug, tt.wantHistory)
+			}
+			if !reflect.DeepEqual(games.renamed, tt.wantRenamed) {
+				t.Errorf("renamed in games = %v, want %v", games.renamed, tt.wantRenamed)
+			}
+		})
+	}
+}
+
+func TestCompanySync(t *testing.T) {
+	companies := newFakeCompanies(models.Company{Id: primitive.NewObjectID().Hex(), Name: "Valve Corp", Slug: "valve"})
+	games := &companyGames{names: []string{"Valve Corp", "Valve", "Supergiant Games"}}
+	s := NewCompanyService(companies, games)
+
+	if err := s.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	want := map[string]string{"Valve Corp": "valve", "Valve": "valve-2", "Supergiant Games": "supergiant-games"}
+	got := make(map[string]string)
+	for _, c := range companies.companies {
+		got[c.Name] = c.Slug
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("companies = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/pkg/export"
+	"github.com/Alexander272/games-library/pkg/locale"
+)
+
+// gameRecord has the same columns as the import file, so the exported file can be imported back.
+type gameRecord struct {
+	models.Game
+}
+
+func gameHeader() []string {
+	header := []string{"slug", "externalId", "title", "description", "developer", "publisher", "genres", "tags", "platforms", "releaseDate"}
+	for _, l := range locale.Supported {
+		header = append(header, "title_"+l, "description_"+l)
+	}
+	return header
+}
+
+func (r gameRecord) Values() []string {
+	releaseDate := ""
+	if !r.ReleaseDate.IsZero() {
+		releaseDate = r.ReleaseDate.Format("2006-01-02")
+	}
+
+	values := []string{
+		r.Slug,
+		r.ExternalId,
+		r.Title,
+		r.Description,
+		r.Developer,
+		r.Publisher,
+		strings.Join(r.Genres, "; "),
+		strings.Join(r.Tags, "; "),
+		strings.Join(r.Platforms, "; "),
+		releaseDate,
+	}
+	for _, l := range locale.Supported {
+		var translation models.Translation
+		for _, t := range r.Translations {
+			if t.Locale == l {
+				translation = t
+			}
+		}
+		values = append(values, translation.Title, translation.Description)
+	}
+	return values
+}
+
+// Export writes all games matching the filter to w. Games are read from the cursor one by one.
+func (s *GameService) Export(ctx context.Context, format string, filter models.GameFilter, w io.Writer) error {
+	writer, err := export.NewWriter(format, w, gameHeader())
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Export(ctx, filter, func(game models.Game) error {
+		return writer.Write(gameRecord{game})
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/pkg/export"
+)
+
+// TestExportImportRoundTrip checks that the exported csv file can be imported back.
+func TestExportImportRoundTrip(t *testing.T) {
+	game := models.Game{
+		Slug:         "witcher-3",
+		ExternalId:   "292030",
+		Title:        "The Witcher 3: Wild Hunt",
+		Developer:    "CD Projekt Red",
+		Genres:       []string{"RPG", "Action"},
+		Platforms:    []string{"PC"},
+		ReleaseDate:  time.Date(2015, 5, 19, 0, 0, 0, 0, time.UTC),
+		Translations: []models.Translation{{Locale: "ru", Title: "Ведьмак 3: Дикая Охота"}},
+	}
+
+	var buf bytes.Buffer
+	w, err := export.NewWriter(export.CSV, &buf, gameHeader())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(gameRecord{game}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := newRowReader(models.FormatCSV, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	row, err := reader.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, rowErr := validateRow(row)
+	if rowErr != nil {
+		t.Fatalf("validateRow() error = %v", rowErr)
+	}
+	if !reflect.DeepEqual(got, game) {
+		t.Errorf("imported game = %+v, want %+v", got, game)
+	}
+}
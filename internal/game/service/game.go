@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/pkg/locale"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/Alexander272/games-library/pkg/slug"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// defaultSlug is used for titles without letters and digits.
+const defaultSlug = "game"
+
+type GameService struct {
+	repo      repository.IGame
+	redirects repository.IRedirect
+	terms     repository.ITerm
+	companies *CompanyService
+}
+
+func NewGameService(repo repository.IGame, redirects repository.IRedirect, terms repository.ITerm, companies repository.ICompany) *GameService {
+	return &GameService{
+		repo:      repo,
+		redirects: redirects,
+		terms:     terms,
+		companies: NewCompanyService(companies, repo),
+	}
+}
+
+// Create saves the game. If the slug isn't set, it is generated from the title
+// with a numeric suffix when the slug is already taken.
+func (s *GameService) Create(ctx context.Context, dto models.CreateGameDTO) (id string, err error) {
+	return s.create(ctx, models.NewGame(dto))
+}
+
+// create saves the game with the checked or generated slug, the import creates games with it too.
+func (s *GameService) create(ctx context.Context, game models.Game) (id string, err error) {
+	if game.Slug != "" {
+		if err := s.checkSlug(ctx, game.Slug, ""); err != nil {
+			return id, err
+		}
+	} else {
+		game.Slug, err = s.makeSlug(ctx, game.Title, "")
+		if err != nil {
+			return id, err
+		}
+	}
+
+	id, err = s.repo.Create(ctx, game)
+	if err != nil {
+		if errors.Is(err, models.ErrGameExists) {
+			return id, err
+		}
+		return id, fmt.Errorf("failed to create game. error: %w", err)
+	}
+
+	game.Id = id
+	s.ensureCompanies(ctx, game)
+	return id, nil
+}
+
+func (s *GameService) GetAll(ctx context.Context, filter models.GameFilter) (games []models.Game, count int64, err error) {
+	games, count, err = s.repo.GetAll(ctx, filter)
+	if err != nil {
+		return games, count, fmt.Errorf("failed to get games. error: %w", err)
+	}
+	if len(games) == 0 {
+		return games, count, models.ErrGameNotFound
+	}
+
+	if err := s.localize(ctx, filter.Locale, games...); err != nil {
+		return games, count, err
+	}
+	return games, count, nil
+}
+
+func (s *GameService) GetById(ctx context.Context, gameId string) (game models.Game, err error) {
+	game, err = s.repo.GetById(ctx, gameId)
+	if err != nil {
+		if errors.Is(err, models.ErrGameNotFound) {
+			return game, err
+		}
+		return game, fmt.Errorf("failed to get game by id. error: %w", err)
+	}
+
+	return game, nil
+}
+
+// GetByIdOrSlug finds the game by its id or its current slug and translates it to the locale.
+func (s *GameService) GetByIdOrSlug(ctx context.Context, idOrSlug, locale string) (game models.Game, err error) {
+	game, err = s.get(ctx, idOrSlug)
+	if err != nil {
+		return game, err
+	}
+
+	games := []models.Game{game}
+	if err := s.localize(ctx, locale, games...); err != nil {
+		return game, err
+	}
+	return games[0], nil
+}
+
+// get finds the game by its id or its current slug without translating it.
+func (s *GameService) get(ctx context.Context, idOrSlug string) (game models.Game, err error) {
+	if primitive.IsValidObjectID(idOrSlug) {
+		game, err = s.repo.GetById(ctx, idOrSlug)
+	} else {
+		game, err = s.repo.GetBySlug(ctx, idOrSlug)
+	}
+	if err != nil {
+		if errors.Is(err, models.ErrGameNotFound) {
+			return game, err
+		}
+		return game, fmt.Errorf("failed to get game. error: %w", err)
+	}
+	return game, nil
+}
+
+// localize translates the games to the locale falling back to the default locale
+// and to the original values. Only the terms used by the games are loaded.
+func (s *GameService) localize(ctx context.Context, l string, games ...models.Game) error {
+	terms, err := s.terms.GetByKeys(ctx, models.TermKeys(games...))
+	if err != nil {
+		return fmt.Errorf("failed to get terms. error: %w", err)
+	}
+
+	dictionary := models.NewTerms(terms)
+	chain := locale.Chain(l)
+	for i := range games {
+		games[i].Localize(chain, dictionary)
+	}
+	return nil
+}
+
+// GetRedirect returns the game that has the old slug or id now, e.g. after it was renamed
+// or merged with the duplicate.
+func (s *GameService) GetRedirect(ctx context.Context, from string) (game models.Game, err error) {
+	redirect, err := s.redirects.Get(ctx, from)
+	if err != nil {
+		if errors.Is(err, models.ErrRedirectNotFound) {
+			return game, err
+		}
+		return game, fmt.Errorf("failed to get redirect. error: %w", err)
+	}
+
+	game, err = s.repo.GetById(ctx, redirect.GameId)
+	if err != nil {
+		if errors.Is(err, models.ErrGameNotFound) {
+			return game, models.ErrRedirectNotFound
+		}
+		return game, fmt.Errorf("failed to get game by id. error: %w", err)
+	}
+	return game, nil
+}
+
+// Update changes the game. When the title changes and the slug isn't set, a new slug is generated.
+// The old slug is kept in the history, so it redirects to the game.
+func (s *GameService) Update(ctx context.Context, dto models.UpdateGameDTO) error {
+	return s.update(ctx, models.UpdateGame(dto))
+}
+
+// update changes the game keeping the slug history, the import updates games with it too.
+func (s *GameService) update(ctx context.Context, game models.Game) error {
+	// the stored title is compared, the translation would make an unchanged title look renamed
+	current, err := s.get(ctx, game.Id)
+	if err != nil {
+		return err
+	}
+	game.Id = current.Id
+
+	switch {
+	case game.Slug != "" && game.Slug != current.Slug:
+		if err := s.checkSlug(ctx, game.Slug, current.Id); err != nil {
+			return err
+		}
+	case game.Slug == "" && game.Title != "" && game.Title != current.Title:
+		game.Slug, err = s.makeSlug(ctx, game.Title, current.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.repo.Update(ctx, game)
+	if err != nil {
+		if errors.Is(err, models.ErrGameNotFound) || errors.Is(err, models.ErrGameExists) {
+			return err
+		}
+		return fmt.Errorf("failed to update game. error: %w", err)
+	}
+
+	if game.Slug != "" && game.Slug != current.Slug && current.Slug != "" {
+		redirect := models.Redirect{From: current.Slug, GameId: current.Id, CreatedAt: time.Now()}
+		if err := s.redirects.Set(ctx, redirect); err != nil {
+			return fmt.Errorf("failed to save slug history. error: %w", err)
+		}
+	}
+
+	s.ensureCompanies(ctx, game)
+	return nil
+}
+
+// ensureCompanies creates the companies of the developer and the publisher of the saved game.
+// The game is saved already, so the error is only logged, the companies are created on the next start.
+func (s *GameService) ensureCompanies(ctx context.Context, game models.Game) {
+	if err := s.companies.Ensure(ctx, game.Developer, game.Publisher); err != nil {
+		logger.Errorf("failed to create companies of game %s. error: %s", game.Id, err.Error())
+	}
+}
+
+func (s *GameService) Remove(ctx context.Context, gameId string) error {
+	err := s.repo.Remove(ctx, gameId)
+	if err != nil {
+		if errors.Is(err, models.ErrGameNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to remove game. error: %w", err)
+	}
+
+	if err := s.redirects.RemoveByGame(ctx, gameId); err != nil {
+		return fmt.Errorf("failed to remove slug history. error: %w", err)
+	}
+	return nil
+}
+
+// checkSlug validates the slug set by the user and checks that no other game has it now or had it before.
+func (s *GameService) checkSlug(ctx context.Context, gameSlug, gameId string) error {
+	if !slug.IsValid(gameSlug) {
+		return models.ErrInvalidSlug
+	}
+
+	taken, err := s.slugTaken(ctx, gameSlug, gameId)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return models.ErrGameExists
+	}
+	return nil
+}
+
+// makeSlug generates the slug from the title, e.g. "doom", "doom-2" if "doom" is taken.
+func (s *GameService) makeSlug(ctx context.Context, title, gameId string) (string, error) {
+	return uniqueSlug(title, defaultSlug, func(candidate string) (bool, error) {
+		return s.slugTaken(ctx, candidate, gameId)
+	})
+}
+
+// slugTaken reports whether another game has the slug or has it in the history.
+func (s *GameService) slugTaken(ctx context.Context, gameSlug, gameId string) (bool, error) {
+	game, err := s.repo.GetBySlug(ctx, gameSlug)
+	if err == nil {
+		return game.Id != gameId, nil
+	}
+	if !errors.Is(err, models.ErrGameNotFound) {
+		return false, fmt.Errorf("failed to get game by slug. error: %w", err)
+	}
+
+	redirect, err := s.redirects.Get(ctx, gameSlug)
+	if err == nil {
+		return redirect.GameId != gameId, nil
+	}
+	if !errors.Is(err, models.ErrRedirectNotFound) {
+		return false, fmt.Errorf("failed to get redirect. error: %w", err)
+	}
+	return false, nil
+}
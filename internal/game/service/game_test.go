@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/internal/game/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeGames keeps the games in memory by id, the other methods of the repo aren't used by the services.
+type fakeGames struct {
+	repository.IGame
+	games map[string]models.Game
+}
+
+func newFakeGames(games ...models.Game) *fakeGames {
+	f := &fakeGames{games: make(map[string]models.Game)}
+	for _, game := range games {
+		f.games[game.Id] = game
+	}
+	return f
+}
+
+func (f *fakeGames) Create(ctx context.Context, game models.Game) (string, error) {
+	if _, err := f.GetBySlug(ctx, game.Slug); err == nil {
+		return "", models.ErrGameExists
+	}
+	game.Id = primitive.NewObjectID().Hex()
+	f.games[game.Id] = game
+	return game.Id, nil
+}
+
+func (f *fakeGames) GetById(ctx context.Context, gameId string) (models.Game, error) {
+	game, ok := f.games[gameId]
+	if !ok {
+		return game, models.ErrGameNotFound
+	}
+	return game, nil
+}
+
+func (f *fakeGames) GetBySlug(ctx context.Context, slug string) (models.Game, error) {
+	for _, game := range f.games {
+		if game.Slug == slug {
+			return game, nil
+		}
+	}
+	return models.Game{}, models.ErrGameNotFound
+}
+
+func (f *fakeGames) GetByExternalId(ctx context.Context, externalId string) (models.Game, error) {
+	for _, game := range f.games {
+		if game.ExternalId == externalId {
+			return game, nil
+		}
+	}
+	return models.Game{}, models.ErrGameNotFound
+}
+
+// Update sets the non-empty fields like $set of the game without the empty fields.
+func (f *fakeGames) Update(ctx context.Context, game models.Game) error {
+	current, ok := f.games[game.Id]
+	if !ok {
+		return models.ErrGameNotFound
+	}
+	if game.Slug != "" {
+		current.Slug = game.Slug
+	}
+	if game.Title != "" {
+		current.Title = game.Title
+	}
+	if game.Translations != nil {
+		current.Translations = game.Translations
+	}
+	f.games[game.Id] = current
+	return nil
+}
+
+type fakeRedirects struct {
+	repository.IRedirect
+	redirects map[string]models.Redirect
+}
+
+func (f *fakeRedirects) Set(ctx context.Context, redirect models.Redirect) error {
+	if f.redirects == nil {
+		f.redirects = make(map[string]models.Redirect)
+	}
+	f.redirects[redirect.From] = redirect
+	return nil
+}
+
+func (f *fakeRedirects) Get(ctx context.Context, from string) (models.Redirect, error) {
+	redirect, ok := f.redirects[from]
+	if !ok {
+		return redirect, models.ErrRedirectNotFound
+	}
+	return redirect, nil
+}
+
+// fakeTerms returns the terms with the requested keys and keeps the last request.
+type fakeTerms struct {
+	repository.ITerm
+	terms []models.Term
+	keys  map[string][]string
+}
+
+func (f *fakeTerms) GetByKeys(ctx context.Context, keys map[string][]string) (terms []models.Term, err error) {
+	f.keys = keys
+	for _, t := range f.terms {
+		for _, key := range keys[t.Kind] {
+			if t.Key == key {
+				terms = append(terms, t)
+			}
+		}
+	}
+	return terms, nil
+}
+
+func TestLocalize(t *testing.T) {
+	terms := &fakeTerms{terms: []models.Term{
+		{Kind: models.TermGenre, Key: "rpg", Names: map[string]string{"en": "RPG", "ru": "Ролевая"}},
+		{Kind: models.TermPlatform, Key: "pc", Names: map[string]string{"en": "PC", "ru": "ПК"}},
+		{Kind: models.TermPlatform, Key: "switch", Names: map[string]string{"en": "Switch"}},
+		{Kind: models.TermTag, Key: "unused", Names: map[string]string{"ru": "Не используется"}},
+	}}
+	games := []models.Game{
+		{Title: "Hades", Genres: []string{"rpg"}, Platforms: []string{"pc", "switch"}},
+		{Title: "Doom", Genres: []string{"shooter"}, Platforms: []string{"pc"}, Translations: []models.Translation{{Locale: "ru", Title: "Дум"}}},
+	}
+	s := NewGameService(nil, nil, terms, newFakeCompanies())
+
+	if err := s.localize(context.Background(), "ru", games...); err != nil {
+		t.Fatalf("localize() error = %v", err)
+	}
+
+	wantKeys := map[string][]string{
+		models.TermGenre:    {"rpg", "shooter"},
+		models.TermPlatform: {"pc", "switch"},
+	}
+	if !reflect.DeepEqual(terms.keys, wantKeys) {
+		t.Errorf("requested terms = %v, want %v", terms.keys, wantKeys)
+	}
+	if want := []string{"ПК", "Switch"}; !reflect.DeepEqual(games[0].PlatformNames, want) {
+		t.Errorf("platform names = %v, want %v", games[0].PlatformNames, want)
+	}
+	if want := []string{"shooter"}; games[1].Title != "Дум" || !reflect.DeepEqual(games[1].GenreNames, want) {
+		t.Errorf("game = %q %v, want Дум %v", games[1].Title, games[1].GenreNames, want)
+	}
+}
+
+func TestUpdateSlug(t *testing.T) {
+	id := primitive.NewObjectID().Hex()
+	tests := []struct {
+		name          string
+		title         string
+		wantSlug      string
+		wantRedirects int
+	}{
+		// the translation to the default locale differs from the title, the title itself isn't changed
+		{"same title", "The Witcher 3", "the-witcher-3", 0},
+		{"renamed", "The Witcher 3 GOTY", "the-witcher-3-goty", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			games := newFakeGames(models.Game{
+				Id:           id,
+				Slug:         "the-witcher-3",
+				Title:        "The Witcher 3",
+				Translations: []models.Translation{{Locale: "en", Title: "The Witcher 3: Wild Hunt"}},
+			})
+			redirects := &fakeRedirects{}
+			s := NewGameService(games, redirects, nil, newFakeCompanies())
+
+			if err := s.Update(context.Background(), models.UpdateGameDTO{Id: id, Title: tt.title}); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+			if got := games.games[id].Slug; got != tt.wantSlug {
+				t.Errorf("slug = %q, want %q", got, tt.wantSlug)
+			}
+			if len(redirects.redirects) != tt.wantRedirects {
+				t.Errorf("redirects = %v, want %d", redirects.redirects, tt.wantRedirects)
+			}
+		})
+	}
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/pkg/lifecycle"
+	"github.com/Alexander272/games-library/pkg/locale"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/Alexander272/games-library/pkg/slug"
+)
+
+// progressStep is the number of rows after which the job progress is saved.
+const progressStep = 100
+
+// finishTimeout limits the last update of the job interrupted by the shutdown.
+const finishTimeout = 5 * time.Second
+
+// ImportService creates and updates the games with GameService, so the slugs are checked, generated
+// and kept in the history the same way as in the api.
+type ImportService struct {
+	repo       repository.IGame
+	games      *GameService
+	jobs       repository.IImport
+	background *lifecycle.Background
+}
+
+func NewImportService(repo repository.IGame, redirects repository.IRedirect, companies repository.ICompany, jobs repository.IImport, background *lifecycle.Background) *ImportService {
+	return &ImportService{
+		repo: repo,
+		// the import doesn't translate games, so the terms aren't needed
+		games:      NewGameService(repo, redirects, nil, companies),
+		jobs:       jobs,
+		background: background,
+	}
+}
+
+// Start saves the file to the temporary directory and runs the import in the background.
+// It returns the id of the job whose progress can be polled with GetJob.
+func (s *ImportService) Start(ctx context.Context, format, filename string, file io.Reader) (id string, err error) {
+	if format != models.FormatCSV && format != models.FormatJSONL {
+		return id, models.ErrUnknownFormat
+	}
+
+	tmp, err := os.CreateTemp("", "games-import-*."+format)
+	if err != nil {
+		return id, fmt.Errorf("failed to create temp file. error: %w", err)
+	}
+	if _, err := io.Copy(tmp, file); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return id, fmt.Errorf("failed to save file. error: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return id, fmt.Errorf("failed to save file. error: %w", err)
+	}
+
+	job := models.ImportJob{
+		Status:    models.JobPending,
+		Format:    format,
+		Filename:  filename,
+		Report:    models.ImportReport{Errors: []models.RowError{}},
+		CreatedAt: time.Now(),
+	}
+	id, err = s.jobs.Create(ctx, job)
+	if err != nil {
+		os.Remove(tmp.Name())
+		return id, fmt.Errorf("failed to create import job. error: %w", err)
+	}
+	job.Id = id
+
+	s.background.Go(func(ctx context.Context) {
+		s.run(ctx, job, tmp.Name())
+	})
+
+	return id, nil
+}
+
+func (s *ImportService) GetJob(ctx context.Context, jobId string) (job models.ImportJob, err error) {
+	job, err = s.jobs.GetById(ctx, jobId)
+	if err != nil {
+		if errors.Is(err, models.ErrJobNotFound) {
+			return job, err
+		}
+		return job, fmt.Errorf("failed to get import job. error: %w", err)
+	}
+	return job, nil
+}
+
+// run imports the file. It stops on shutdown and the job is saved as failed.
+func (s *ImportService) run(ctx context.Context, job models.ImportJob, path string) {
+	defer os.Remove(path)
+
+	job.Status = models.JobRunning
+	total, err := s.countRows(job.Format, path)
+	if err == nil {
+		job.Report.Total = total
+		if err = s.jobs.Update(ctx, job); err != nil {
+			logger.Errorf("failed to update import job %s. error: %s", job.Id, err.Error())
+		}
+
+		var file *os.File
+		file, err = os.Open(path)
+		if err == nil {
+			job.Report, err = s.Import(ctx, job.Format, file, func(report models.ImportReport) {
+				job.Report = report
+				if err := s.jobs.Update(ctx, job); err != nil {
+					logger.Errorf("failed to update import job %s. error: %s", job.Id, err.Error())
+				}
+			})
+			file.Close()
+		}
+	}
+
+	job.Status = models.JobDone
+	if err != nil {
+		logger.Errorf("import job %s failed. error: %s", job.Id, err.Error())
+		job.Status = models.JobFailed
+		job.Error = err.Error()
+	}
+	job.FinishedAt = time.Now()
+
+	// the context of the job may be already cancelled by the shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), finishTimeout)
+	defer cancel()
+	if err := s.jobs.Update(ctx, job); err != nil {
+		logger.Errorf("failed to update import job %s. error: %s", job.Id, err.Error())
+	}
+}
+
+// countRows reads the file once without touching the database to know the total number of rows.
+func (s *ImportService) countRows(format, path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open file. error: %w", err)
+	}
+	defer file.Close()
+
+	reader, err := newRowReader(format, file)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	for {
+		_, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return total, nil
+		}
+		var rowErr models.RowError
+		if err != nil && !errors.As(err, &rowErr) {
+			return total, err
+		}
+		total++
+	}
+}
+
+// Import reads the file row by row, validates each row and creates the game or updates the one
+// with the same external id or slug.
+// Invalid rows don't stop the import, they are collected in the report. The progress callback is called
+// periodically and once more when the file ends.
+func (s *ImportService) Import(ctx context.Context, format string, file io.Reader, progress func(models.ImportReport)) (report models.ImportReport, err error) {
+	report.Errors = []models.RowError{}
+
+	reader, err := newRowReader(format, file)
+	if err != nil {
+		return report, err
+	}
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var rowErr models.RowError
+		if err != nil && !errors.As(err, &rowErr) {
+			return report, fmt.Errorf("failed to read file. error: %w", err)
+		}
+		report.Processed++
+
+		if err != nil {
+			report.AddError(rowErr)
+		} else {
+			s.importRow(ctx, &report, row, reader.Line())
+		}
+
+		// the progress is checked after every row, the invalid ones too
+		if progress != nil && report.Processed%progressStep == 0 {
+			progress(report)
+		}
+	}
+
+	if report.Total < report.Processed {
+		report.Total = report.Processed
+	}
+	if progress != nil {
+		progress(report)
+	}
+
+	return report, nil
+}
+
+// importRow validates the row and saves the game, the result is added to the report.
+func (s *ImportService) importRow(ctx context.Context, report *models.ImportReport, row models.ImportRow, line int) {
+	game, rowErr := validateRow(row)
+	if rowErr != nil {
+		rowErr.Row = line
+		report.AddError(*rowErr)
+		return
+	}
+
+	current, err := s.findGame(ctx, game)
+	switch {
+	case errors.Is(err, models.ErrGameNotFound):
+		if _, err := s.games.create(ctx, game); err != nil {
+			report.AddError(models.RowError{Row: line, Message: err.Error()})
+			return
+		}
+		report.Created++
+	case err != nil:
+		report.AddError(models.RowError{Row: line, Message: err.Error()})
+	default:
+		game.Id = current.Id
+		if err := s.games.update(ctx, game); err != nil {
+			report.AddError(models.RowError{Row: line, Message: err.Error()})
+			return
+		}
+		report.Updated++
+	}
+}
+
+// findGame returns the game updated by the row: the one with its external id or, when the row has none,
+// the one with its slug or the slug of its title.
+func (s *ImportService) findGame(ctx context.Context, game models.Game) (models.Game, error) {
+	if game.ExternalId != "" {
+		return s.repo.GetByExternalId(ctx, game.ExternalId)
+	}
+
+	gameSlug := game.Slug
+	if gameSlug == "" {
+		gameSlug = slug.Make(game.Title)
+	}
+	return s.repo.GetBySlug(ctx, gameSlug)
+}
+
+func validateRow(row models.ImportRow) (game models.Game, rowErr *models.RowError) {
+	title := strings.TrimSpace(row.Title)
+	if title == "" {
+		return game, &models.RowError{Field: "title", Message: "title is required"}
+	}
+	if len(title) > 256 {
+		return game, &models.RowError{Field: "title", Message: "title is too long"}
+	}
+
+	// the empty slug is generated when the game is saved
+	gameSlug := strings.TrimSpace(row.Slug)
+	if gameSlug != "" && !slug.IsValid(gameSlug) {
+		return game, &models.RowError{Field: "slug", Message: fmt.Sprintf("invalid slug %q", gameSlug)}
+	}
+
+	for _, t := range row.Translations {
+		if !locale.IsSupported(t.Locale) {
+			return game, &models.RowError{Field: "translations", Message: fmt.Sprintf("unsupported locale %q", t.Locale)}
+		}
+		if len(t.Title) > 256 {
+			return game, &models.RowError{Field: "translations", Message: "title is too long"}
+		}
+	}
+
+	var releaseDate time.Time
+	if row.ReleaseDate != "" {
+		date, err := parseDate(row.ReleaseDate)
+		if err != nil {
+			return game, &models.RowError{Field: "releaseDate", Message: err.Error()}
+		}
+		releaseDate = date
+	}
+
+	return models.Game{
+		Slug:        gameSlug,
+		ExternalId:  strings.TrimSpace(row.ExternalId),
+		Title:       title,
+		Description: row.Description,
+		Developer:   strings.TrimSpace(row.Developer),
+		Publisher:   strings.TrimSpace(row.Publisher),
+		Genres:      row.Genres,
+		Tags:        row.Tags,
+		Platforms:   row.Platforms,
+		ReleaseDate: releaseDate,
+
+		Translations: row.Translations,
+	}, nil
+}
+
+func parseDate(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", time.RFC3339, "02.01.2006"} {
+		if date, err := time.Parse(layout, strings.TrimSpace(value)); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected format is YYYY-MM-DD", value)
+}
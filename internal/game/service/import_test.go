@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCsvReader(t *testing.T) {
+	file := "Title, Slug, Genres, ReleaseDate, title_ru\n" +
+		"The Witcher 3, witcher-3, RPG; Action, 2015-05-19, Ведьмак 3\n" +
+		"\"broken, 1\n"
+
+	reader, err := newRowReader(models.FormatCSV, strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := reader.Read()
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := models.ImportRow{
+		Slug:         "witcher-3",
+		Title:        "The Witcher 3",
+		Genres:       []string{"RPG", "Action"},
+		ReleaseDate:  "2015-05-19",
+		Translations: []models.Translation{{Locale: "ru", Title: "Ведьмак 3"}},
+	}
+	if !reflect.DeepEqual(row, want) {
+		t.Errorf("Read() = %+v, want %+v", row, want)
+	}
+	if reader.Line() != 2 {
+		t.Errorf("Line() = %d, want 2", reader.Line())
+	}
+
+	var rowErr models.RowError
+	if _, err := reader.Read(); !errors.As(err, &rowErr) {
+		t.Errorf("Read() of the broken row error = %v, want RowError", err)
+	}
+}
+
+func TestCsvReaderWithoutTitle(t *testing.T) {
+	if _, err := newRowReader(models.FormatCSV, strings.NewReader("slug,genres\n")); err == nil {
+		t.Error("file without the title column is accepted")
+	}
+}
+
+func TestJsonlReader(t *testing.T) {
+	file := `{"title": "Hades", "genres": ["Roguelike"]}
+
+not json
+{"title": "Celeste"}
+`
+	reader, err := newRowReader(models.FormatJSONL, strings.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		title string
+		line  int
+		isErr bool
+	}{
+		{"Hades", 1, false},
+		// the empty line is skipped
+		{"", 3, true},
+		{"Celeste", 4, false},
+	}
+	for _, tt := range tests {
+		row, err := reader.Read()
+		var rowErr models.RowError
+		if tt.isErr != errors.As(err, &rowErr) {
+			t.Fatalf("line %d: Read() error = %v", tt.line, err)
+		}
+		if row.Title != tt.title || reader.Line() != tt.line {
+			t.Errorf("Read() = %q at line %d, want %q at line %d", row.Title, reader.Line(), tt.title, tt.line)
+		}
+	}
+	if _, err := reader.Read(); !errors.Is(err, io.EOF) {
+		t.Errorf("Read() at the end error = %v, want EOF", err)
+	}
+}
+
+func TestValidateRow(t *testing.T) {
+	tests := []struct {
+		name      string
+		row       models.ImportRow
+		wantSlug  string
+		wantField string
+	}{
+		{"slug is generated on save", models.ImportRow{Title: "  Hollow Knight "}, "", ""},
+		{"own slug", models.ImportRow{Title: "Hades", Slug: "hades-2020"}, "hades-2020", ""},
+		{"missing title", models.ImportRow{Title: " "}, "", "title"},
+		{"long title", models.ImportRow{Title: strings.Repeat("a", 257)}, "", "title"},
+		{"invalid slug", models.ImportRow{Title: "Hades", Slug: "Hades!"}, "", "slug"},
+		{"unsupported locale", models.ImportRow{Title: "Hades", Translations: []models.Translation{{Locale: "xx", Title: "Hades"}}}, "", "translations"},
+		{"invalid date", models.ImportRow{Title: "Hades", ReleaseDate: "next year"}, "", "releaseDate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, rowErr := validateRow(tt.row)
+			if tt.wantField != "" {
+				if rowErr == nil || rowErr.Field != tt.wantField {
+					t.Fatalf("validateRow() error = %v, want the error of %s", rowErr, tt.wantField)
+				}
+				return
+			}
+			if rowErr != nil {
+				t.Fatalf("validateRow() error = %v", rowErr)
+			}
+			if game.Slug != tt.wantSlug {
+				t.Errorf("slug = %q, want %q", game.Slug, tt.wantSlug)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	want := time.Date(2015, 5, 19, 0, 0, 0, 0, time.UTC)
+	for _, value := range []string{"2015-05-19", "2015-05-19T00:00:00Z", "19.05.2015"} {
+		if got, err := parseDate(value); err != nil || !got.Equal(want) {
+			t.Errorf("parseDate(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+}
+
+// TestImportProgress checks that the progress is saved when the step ends on an invalid row.
+func TestImportProgress(t *testing.T) {
+	var file strings.Builder
+	file.WriteString("title\n")
+	for i := 1; i <= 2*progressStep; i++ {
+		if i == progressStep {
+			// the invalid row on the step
+			file.WriteString("\"\"\n")
+			continue
+		}
+		fmt.Fprintf(&file, "Game %d\n", i)
+	}
+
+	s := NewImportService(newFakeGames(), &fakeRedirects{}, newFakeCompanies(), nil, nil)
+	var calls []int
+	report, err := s.Import(context.Background(), models.FormatCSV, strings.NewReader(file.String()), func(r models.ImportReport) {
+		calls = append(calls, r.Processed)
+	})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if want := []int{progressStep, 2 * progressStep, 2 * progressStep}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("progress calls = %v, want %v", calls, want)
+	}
+	if report.Created != 2*progressStep-1 || report.Failed != 1 {
+		t.Errorf("report = %+v, want %d created and 1 failed", report, 2*progressStep-1)
+	}
+	if len(report.Errors) != 1 || report.Errors[0].Row != progressStep+1 {
+		t.Errorf("errors = %+v, want the error of line %d", report.Errors, progressStep+1)
+	}
+}
+
+// TestImportSlugs checks that the import checks, generates and keeps the history of slugs like the api.
+func TestImportSlugs(t *testing.T) {
+	doomId, quakeId := primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()
+	games := newFakeGames(
+		models.Game{Id: doomId, ExternalId: "ext-1", Slug: "doom", Title: "Doom"},
+		models.Game{Id: quakeId, Slug: "quake", Title: "Quake"},
+	)
+	redirects := &fakeRedirects{}
+	s := NewImportService(games, redirects, newFakeCompanies(), nil, nil)
+
+	file := "title,externalId,slug\n" +
+		// the slug of another game
+		"Doom,ext-1,quake\n" +
+		"Doom,ext-1,doom-1993\n" +
+		// the old slug of the renamed game isn't reused
+		"Doom,,\n"
+	report, err := s.Import(context.Background(), models.FormatCSV, strings.NewReader(file), nil)
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+
+	if report.Created != 1 || report.Updated != 1 || report.Failed != 1 {
+		t.Errorf("report = %+v, want 1 created, 1 updated and 1 failed", report)
+	}
+	if len(report.Errors) != 1 || report.Errors[0].Row != 2 {
+		t.Errorf("errors = %+v, want the error of line 2", report.Errors)
+	}
+	if got := games.games[doomId].Slug; got != "doom-1993" {
+		t.Errorf("slug = %q, want doom-1993", got)
+	}
+	if redirect, err := redirects.Get(context.Background(), "doom"); err != nil || redirect.GameId != doomId {
+		t.Errorf("redirect of doom = %+v, %v, want to %s", redirect, err, doomId)
+	}
+	if _, err := games.GetBySlug(context.Background(), "doom-2"); err != nil {
+		t.Errorf("new game slug: %v, want doom-2", err)
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/pkg/locale"
+)
+
+const maxLineSize = 1 << 20
+
+// rowReader reads import rows one by one. A malformed row is returned as models.RowError,
+// any other error means that the file can't be read further.
+type rowReader interface {
+	Read() (models.ImportRow, error)
+	Line() int
+}
+
+func newRowReader(format string, r io.Reader) (rowReader, error) {
+	switch format {
+	case models.FormatCSV:
+		return newCsvReader(r)
+	case models.FormatJSONL:
+		return newJsonlReader(r), nil
+	default:
+		return nil, models.ErrUnknownFormat
+	}
+}
+
+type csvReader struct {
+	r       *csv.Reader
+	columns map[string]int
+}
+
+func newCsvReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header. error: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("required column 'title' is missing")
+	}
+
+	return &csvReader{r: reader, columns: columns}, nil
+}
+
+func (r *csvReader) Read() (row models.ImportRow, err error) {
+	record, err := r.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return row, models.RowError{Row: parseErr.Line, Message: parseErr.Err.Error()}
+		}
+		return row, err
+	}
+
+	return models.ImportRow{
+		Slug:        r.get(record, "slug"),
+		ExternalId:  r.get(record, "externalid"),
+		Title:       r.get(record, "title"),
+		Description: r.get(record, "description"),
+		Developer:   r.get(record, "developer"),
+		Publisher:   r.get(record, "publisher"),
+		Genres:      splitList(r.get(record, "genres")),
+		Tags:        splitList(r.get(record, "tags")),
+		Platforms:   splitList(r.get(record, "platforms")),
+		ReleaseDate: r.get(record, "releasedate"),
+
+		Translations: r.translations(record),
+	}, nil
+}
+
+func (r *csvReader) translations(record []string) []models.Translation {
+	var translations []models.Translation
+	for _, l := range locale.Supported {
+		t := models.Translation{
+			Locale:      l,
+			Title:       r.get(record, "title_"+l),
+			Description: r.get(record, "description_"+l),
+		}
+		if t.Title != "" || t.Description != "" {
+			translations = append(translations, t)
+		}
+	}
+	return translations
+}
+
+// Line returns the line of the last read record.
+func (r *csvReader) Line() int {
+	line, _ := r.r.FieldPos(0)
+	return line
+}
+
+func (r *csvReader) get(record []string, column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+type jsonlReader struct {
+	s    *bufio.Scanner
+	line int
+}
+
+func newJsonlReader(r io.Reader) *jsonlReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	return &jsonlReader{s: s}
+}
+
+func (r *jsonlReader) Read() (row models.ImportRow, err error) {
+	for r.s.Scan() {
+		r.line++
+		line := strings.TrimSpace(r.s.Text())
+		if line == "" {
+			continue
+		}
+
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			return row, models.RowError{Row: r.line, Message: err.Error()}
+		}
+		return row, nil
+	}
+	if err := r.s.Err(); err != nil {
+		return row, err
+	}
+	return row, io.EOF
+}
+
+// Line returns the line of the last read record.
+func (r *jsonlReader) Line() int {
+	return r.line
+}
+
+// splitList splits the csv cell with several values, e.g. "RPG; Action".
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	var list []string
+	for _, v := range strings.FieldsFunc(value, func(r rune) bool { return r == ';' || r == '|' }) {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func (s *GameService) AddRelease(ctx context.Context, dto models.ReleaseDTO) (id string, err error) {
+	if dto.Barcode != "" && !validEAN(dto.Barcode) {
+		return id, models.ErrInvalidBarcode
+	}
+
+	release := models.NewRelease(dto)
+	release.Id = primitive.NewObjectID().Hex()
+	if release.Edition == "" {
+		release.Edition = models.EditionStandard
+	}
+
+	if err := s.repo.AddRelease(ctx, dto.GameId, release); err != nil {
+		if errors.Is(err, models.ErrGameNotFound) {
+			return id, err
+		}
+		return id, fmt.Errorf("failed to add release. error: %w", err)
+	}
+
+	return release.Id, nil
+}
+
+func (s *GameService) UpdateRelease(ctx context.Context, dto models.ReleaseDTO) error {
+	if dto.Barcode != "" && !validEAN(dto.Barcode) {
+		return models.ErrInvalidBarcode
+	}
+
+	release := models.NewRelease(dto)
+	if release.Edition == "" {
+		release.Edition = models.EditionStandard
+	}
+
+	if err := s.repo.UpdateRelease(ctx, dto.GameId, release); err != nil {
+		if errors.Is(err, models.ErrReleaseNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to update release. error: %w", err)
+	}
+	return nil
+}
+
+func (s *GameService) RemoveRelease(ctx context.Context, gameId, releaseId string) error {
+	if err := s.repo.RemoveRelease(ctx, gameId, releaseId); err != nil {
+		if errors.Is(err, models.ErrReleaseNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to remove release. error: %w", err)
+	}
+	return nil
+}
+
+// validEAN checks the length and the check digit of EAN-8 and EAN-13 barcodes.
+func validEAN(code string) bool {
+	if len(code) != 8 && len(code) != 13 {
+		return false
+	}
+
+	sum := 0
+	for i := len(code) - 2; i >= 0; i-- {
+		d := int(code[i] - '0')
+		if d < 0 || d > 9 {
+			return false
+		}
+		// weights go 3, 1, 3, ... from the right, the check digit is excluded
+		if (len(code)-2-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+
+	check := int(code[len(code)-1] - '0')
+	return check >= 0 && check <= 9 && (10-sum%10)%10 == check
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+)
+
+type IGame interface {
+	Create(ctx context.Context, dto models.CreateGameDTO) (string, error)
+	GetAll(ctx context.Context, filter models.GameFilter) ([]models.Game, int64, error)
+	Export(ctx context.Context, format string, filter models.GameFilter, w io.Writer) error
+	GetById(ctx context.Context, gameId string) (models.Game, error)
+	GetByIdOrSlug(ctx context.Context, idOrSlug, locale string) (models.Game, error)
+	GetRedirect(ctx context.Context, from string) (models.Game, error)
+	Update(ctx context.Context, dto models.UpdateGameDTO) error
+	Remove(ctx context.Context, gameId string) error
+	AddRelease(ctx context.Context, dto models.ReleaseDTO) (string, error)
+	UpdateRelease(ctx context.Context, dto models.ReleaseDTO) error
+	RemoveRelease(ctx context.Context, gameId, releaseId string) error
+}
+
+type ICompany interface {
+	GetAll(ctx context.Context) ([]models.Company, error)
+	GetByIdOrSlug(ctx context.Context, idOrSlug string) (models.Company, error)
+	GetRedirect(ctx context.Context, from string) (models.Company, error)
+	Update(ctx context.Context, dto models.UpdateCompanyDTO) error
+	Sync(ctx context.Context) error
+}
+
+type ITerm interface {
+	Set(ctx context.Context, dto models.TermDTO) error
+	GetAll(ctx context.Context, filter models.TermFilter) ([]models.Term, error)
+	Remove(ctx context.Context, kind, key string) error
+}
+
+type IImport interface {
+	Start(ctx context.Context, format, filename string, file io.Reader) (string, error)
+	GetJob(ctx context.Context, jobId string) (models.ImportJob, error)
+	Import(ctx context.Context, format string, file io.Reader, progress func(models.ImportReport)) (models.ImportReport, error)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/internal/game/repository"
+)
+
+type TermService struct {
+	repo repository.ITerm
+}
+
+func NewTermService(repo repository.ITerm) *TermService {
+	return &TermService{
+		repo: repo,
+	}
+}
+
+// Set saves the names of the genre, tag or platform in different languages.
+func (s *TermService) Set(ctx context.Context, dto models.TermDTO) error {
+	if !models.IsTermKind(dto.Kind) {
+		return models.ErrUnknownTermKind
+	}
+
+	term := models.Term{Kind: dto.Kind, Key: dto.Key, Names: dto.Names}
+	if err := s.repo.Set(ctx, term); err != nil {
+		return fmt.Errorf("failed to set term. error: %w", err)
+	}
+	return nil
+}
+
+func (s *TermService) GetAll(ctx context.Context, filter models.TermFilter) (terms []models.Term, err error) {
+	terms, err = s.repo.GetAll(ctx, filter.Kind)
+	if err != nil {
+		return terms, fmt.Errorf("failed to get terms. error: %w", err)
+	}
+	if len(terms) == 0 {
+		return terms, models.ErrTermNotFound
+	}
+	return terms, nil
+}
+
+func (s *TermService) Remove(ctx context.Context, kind, key string) error {
+	if err := s.repo.Remove(ctx, kind, key); err != nil {
+		if errors.Is(err, models.ErrTermNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to remove term. error: %w", err)
+	}
+	return nil
+}
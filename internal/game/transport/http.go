@@ -0,0 +1,611 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/internal/service"
+	"github.com/Alexander272/games-library/internal/transport/errcode"
+	"github.com/Alexander272/games-library/internal/transport/middleware"
+	"github.com/Alexander272/games-library/internal/transport/validation"
+	userModels "github.com/Alexander272/games-library/internal/user/models"
+	"github.com/Alexander272/games-library/pkg/export"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services   *service.Services
+	middleware *middleware.Middleware
+}
+
+func NewHandler(services *service.Services, middleware *middleware.Middleware) *Handler {
+	return &Handler{
+		services:   services,
+		middleware: middleware,
+	}
+}
+
+func (h *Handler) Init(api *gin.RouterGroup) {
+	games := api.Group("/games")
+	{
+		games.GET("/", h.getAll)
+		games.GET("/export", h.middleware.UserIdentity, h.export)
+		games.GET("/:id", h.getById)
+		games.GET("/terms", h.getTerms)
+
+		admin := games.Group("", h.middleware.UserIdentity, h.middleware.AccessForRoles(userModels.RoleAdmin), h.middleware.ObjectIds("releaseId", "jobId"))
+		{
+			admin.POST("/", h.create)
+			admin.PATCH("/:id", h.update)
+			// the game is updated by id or slug, the other routes accept only ids
+			admin.DELETE("/:id", h.middleware.ObjectIds("id"), h.remove)
+			admin.POST("/:id/releases", h.middleware.ObjectIds("id"), h.addRelease)
+			admin.PATCH("/:id/releases/:releaseId", h.middleware.ObjectIds("id"), h.updateRelease)
+			admin.DELETE("/:id/releases/:releaseId", h.middleware.ObjectIds("id"), h.removeRelease)
+			admin.POST("/import", h.importGames)
+			admin.GET("/import/:jobId", h.getImportJob)
+			admin.PUT("/terms/:kind/:key", h.setTerm)
+			admin.DELETE("/terms/:kind/:key", h.removeTerm)
+		}
+	}
+
+	companies := api.Group("/companies")
+	{
+		companies.GET("/", h.getCompanies)
+		companies.GET("/:id", h.getCompany)
+		companies.PATCH("/:id", h.middleware.UserIdentity, h.middleware.AccessForRoles(userModels.RoleAdmin), h.updateCompany)
+	}
+}
+
+// @Summary Get All
+// @Tags games
+// @Description получение списка игр. поиск выполняется по названиям на всех языках
+// @ID getAllGames
+// @Accept json
+// @Produce json
+// @Param filter query models.GameFilter false "filter"
+// @Param lang query string false "language" Enums(en, ru)
+// @Param Accept-Language header string false "language"
+// @Success 200 {object} dataResponse{data=[]models.Game}
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games [get]
+func (h *Handler) getAll(c *gin.Context) {
+	var filter models.GameFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(validation.Query(err))
+		return
+	}
+	filter.Locale = middleware.GetLocale(c)
+
+	games, count, err := h.services.Game.GetAll(c, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: games, Count: count})
+}
+
+// @Summary Export
+// @Security ApiKeyAuth
+// @Tags games
+// @Description выгрузка игр в csv или jsonl файл. Поддерживаются те же фильтры, что и в списке игр
+// @ID exportGames
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "file format (csv, jsonl)" default(csv)
+// @Param filter query models.GameFilter false "filter"
+// @Success 200 {file} file
+// @Failure 400 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/export [get]
+func (h *Handler) export(c *gin.Context) {
+	var filter models.GameFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(validation.Query(err))
+		return
+	}
+
+	format := c.DefaultQuery("format", export.CSV)
+	if format != export.CSV && format != export.JSONL {
+		c.Error(export.ErrUnknownFormat)
+		return
+	}
+
+	filename := fmt.Sprintf("games-%s.%s", time.Now().Format("2006-01-02"), format)
+	res := export.NewResponse(c.Writer, format, filename)
+
+	if err := h.services.Game.Export(c, format, filter, res); err != nil {
+		if !res.Started() {
+			c.Error(err)
+			return
+		}
+		// the response is already partially sent, so the error can only be logged
+		logger.Errorf("failed to export games. error: %s", err.Error())
+		c.Abort()
+		return
+	}
+	res.Start()
+}
+
+// @Summary Get By Id
+// @Tags games
+// @Description получение данных игры по id или slug. по старому slug возвращается постоянное перенаправление
+// @ID getGameById
+// @Accept json
+// @Produce json
+// @Param id path string true "game id or slug"
+// @Param lang query string false "language" Enums(en, ru)
+// @Param Accept-Language header string false "language"
+// @Success 200 {object} dataResponse{data=models.Game}
+// @Success 301 {object} response
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id} [get]
+func (h *Handler) getById(c *gin.Context) {
+	if c.Param("id") == "" {
+		c.Error(errcode.ErrEmptyIdParam)
+		return
+	}
+
+	game, err := h.services.Game.GetByIdOrSlug(c, c.Param("id"), middleware.GetLocale(c))
+	if err != nil {
+		if errors.Is(err, models.ErrGameNotFound) {
+			h.redirect(c, err)
+			return
+		}
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dataResponse{Data: game})
+}
+
+// redirect sends the client to the game that had the requested slug or id, e.g. after renaming or merging duplicates.
+func (h *Handler) redirect(c *gin.Context, notFound error) {
+	game, err := h.services.Game.GetRedirect(c, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, models.ErrRedirectNotFound) {
+			c.Error(notFound)
+			return
+		}
+		c.Error(err)
+		return
+	}
+	target := game.Slug
+	if target == "" {
+		target = game.Id
+	}
+	c.Redirect(http.StatusMovedPermanently, path.Join(path.Dir(c.Request.URL.Path), target))
+}
+
+// @Summary Create
+// @Security ApiKeyAuth
+// @Tags games
+// @Description создание игры
+// @ID createGame
+// @Accept json
+// @Produce json
+// @Param game body models.CreateGameDTO true "game info"
+// @Success 201 {object} idResponse
+// @Failure 400,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games [post]
+func (h *Handler) create(c *gin.Context) {
+	var dto models.CreateGameDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+
+	id, err := h.services.Game.Create(c, dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/api/games/%s", id))
+	c.JSON(http.StatusCreated, idResponse{Id: id})
+}
+
+// @Summary Update
+// @Security ApiKeyAuth
+// @Tags games
+// @Description обновление игры
+// @ID updateGame
+// @Accept json
+// @Produce json
+// @Param id path string true "game id or slug"
+// @Param game body models.UpdateGameDTO true "game info"
+// @Success 200 {object} response
+// @Failure 400,404,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id} [patch]
+func (h *Handler) update(c *gin.Context) {
+	if c.Param("id") == "" {
+		c.Error(errcode.ErrEmptyIdParam)
+		return
+	}
+
+	var dto models.UpdateGameDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.Id = c.Param("id")
+
+	err := h.services.Game.Update(c, dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response{Message: "Game updated"})
+}
+
+// @Summary Remove
+// @Security ApiKeyAuth
+// @Tags games
+// @Description удаление игры
+// @ID removeGame
+// @Accept json
+// @Produce json
+// @Param id path string true "game id"
+// @Success 204 {object} response
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id} [delete]
+func (h *Handler) remove(c *gin.Context) {
+	err := h.services.Game.Remove(c, c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, response{Message: "Game removed"})
+}
+
+// @Summary Add Release
+// @Security ApiKeyAuth
+// @Tags games
+// @Description добавление релиза игры (платформа, регион, дата, издание)
+// @ID addRelease
+// @Accept json
+// @Produce json
+// @Param id path string true "game id"
+// @Param release body models.ReleaseDTO true "release info"
+// @Success 201 {object} idResponse
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id}/releases [post]
+func (h *Handler) addRelease(c *gin.Context) {
+	var dto models.ReleaseDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.GameId = c.Param("id")
+
+	id, err := h.services.Game.AddRelease(c, dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, idResponse{Id: id})
+}
+
+// @Summary Update Release
+// @Security ApiKeyAuth
+// @Tags games
+// @Description обновление релиза игры
+// @ID updateRelease
+// @Accept json
+// @Produce json
+// @Param id path string true "game id"
+// @Param releaseId path string true "release id"
+// @Param release body models.ReleaseDTO true "release info"
+// @Success 200 {object} response
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id}/releases/{releaseId} [patch]
+func (h *Handler) updateRelease(c *gin.Context) {
+	var dto models.ReleaseDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.GameId = c.Param("id")
+	dto.Id = c.Param("releaseId")
+
+	if err := h.services.Game.UpdateRelease(c, dto); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response{Message: "Release updated"})
+}
+
+// @Summary Remove Release
+// @Security ApiKeyAuth
+// @Tags games
+// @Description удаление релиза игры
+// @ID removeRelease
+// @Accept json
+// @Produce json
+// @Param id path string true "game id"
+// @Param releaseId path string true "release id"
+// @Success 204 {object} response
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id}/releases/{releaseId} [delete]
+func (h *Handler) removeRelease(c *gin.Context) {
+	if err := h.services.Game.RemoveRelease(c, c.Param("id"), c.Param("releaseId")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, response{Message: "Release removed"})
+}
+
+// @Summary Import
+// @Security ApiKeyAuth
+// @Tags games
+// @Description импорт игр из csv или jsonl файла. Импорт выполняется в фоне, прогресс можно получить по id задачи
+// @ID importGames
+// @Accept mpfd
+// @Produce json
+// @Param file formData file true "csv or jsonl file"
+// @Param format query string false "file format (csv, jsonl). By default it is taken from the file extension"
+// @Success 202 {object} idResponse
+// @Failure 400 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/import [post]
+func (h *Handler) importGames(c *gin.Context) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		c.Error(errcode.ErrFileNotFound)
+		return
+	}
+
+	format := c.Query("format")
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), ".")
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		c.Error(errcode.ErrFileOpenFailed)
+		return
+	}
+	defer file.Close()
+
+	id, err := h.services.Import.Start(c, format, header.Filename, file)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/api/games/import/%s", id))
+	c.JSON(http.StatusAccepted, idResponse{Id: id})
+}
+
+// @Summary Get Import Job
+// @Security ApiKeyAuth
+// @Tags games
+// @Description получение статуса и отчета задачи импорта
+// @ID getImportJob
+// @Accept json
+// @Produce json
+// @Param jobId path string true "job id"
+// @Success 200 {object} dataResponse{data=models.ImportJob}
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/import/{jobId} [get]
+func (h *Handler) getImportJob(c *gin.Context) {
+	job, err := h.services.Import.GetJob(c, c.Param("jobId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dataResponse{Data: job})
+}
+
+// @Summary Get Terms
+// @Tags games
+// @Description получение названий жанров, тегов и платформ на разных языках
+// @ID getGameTerms
+// @Accept json
+// @Produce json
+// @Param filter query models.TermFilter false "filter"
+// @Success 200 {object} dataResponse{data=[]models.Term}
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/terms [get]
+func (h *Handler) getTerms(c *gin.Context) {
+	var filter models.TermFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(validation.Query(err))
+		return
+	}
+
+	terms, err := h.services.Term.GetAll(c, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dataResponse{Data: terms, Count: int64(len(terms))})
+}
+
+// @Summary Set Term
+// @Security ApiKeyAuth
+// @Tags games
+// @Description установка названий жанра, тега или платформы на разных языках
+// @ID setGameTerm
+// @Accept json
+// @Produce json
+// @Param kind path string true "term kind" Enums(genre, tag, platform)
+// @Param key path string true "term key as stored in games"
+// @Param term body models.TermDTO true "term names"
+// @Success 200 {object} response
+// @Failure 400 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/terms/{kind}/{key} [put]
+func (h *Handler) setTerm(c *gin.Context) {
+	var dto models.TermDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.Kind = c.Param("kind")
+	dto.Key = c.Param("key")
+
+	if err := h.services.Term.Set(c, dto); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response{Message: "Term set"})
+}
+
+// @Summary Remove Term
+// @Security ApiKeyAuth
+// @Tags games
+// @Description удаление названий жанра, тега или платформы
+// @ID removeGameTerm
+// @Accept json
+// @Produce json
+// @Param kind path string true "term kind" Enums(genre, tag, platform)
+// @Param key path string true "term key"
+// @Success 204 {object} response
+// @Failure 404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/terms/{kind}/{key} [delete]
+func (h *Handler) removeTerm(c *gin.Context) {
+	if err := h.services.Term.Remove(c, c.Param("kind"), c.Param("key")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, response{Message: "Deleted successfully"})
+}
+
+// @Summary Get Companies
+// @Tags companies
+// @Description получение списка разработчиков и издателей
+// @ID getCompanies
+// @Accept json
+// @Produce json
+// @Success 200 {object} dataResponse{data=[]models.Company}
+// @Failure 404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /companies [get]
+func (h *Handler) getCompanies(c *gin.Context) {
+	companies, err := h.services.Company.GetAll(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dataResponse{Data: companies, Count: int64(len(companies))})
+}
+
+// @Summary Get Company
+// @Tags companies
+// @Description получение компании по id или slug. по старому slug возвращается постоянное перенаправление
+// @ID getCompany
+// @Accept json
+// @Produce json
+// @Param id path string true "company id or slug"
+// @Success 200 {object} dataResponse{data=models.Company}
+// @Success 301 {object} response
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /companies/{id} [get]
+func (h *Handler) getCompany(c *gin.Context) {
+	if c.Param("id") == "" {
+		c.Error(errcode.ErrEmptyIdParam)
+		return
+	}
+
+	company, err := h.services.Company.GetByIdOrSlug(c, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, models.ErrCompanyNotFound) {
+			h.redirectCompany(c, err)
+			return
+		}
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dataResponse{Data: company})
+}
+
+// redirectCompany sends the client to the company that had the requested slug before it was renamed.
+func (h *Handler) redirectCompany(c *gin.Context, notFound error) {
+	company, err := h.services.Company.GetRedirect(c, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, models.ErrRedirectNotFound) {
+			c.Error(notFound)
+			return
+		}
+		c.Error(err)
+		return
+	}
+	c.Redirect(http.StatusMovedPermanently, path.Join(path.Dir(c.Request.URL.Path), company.Slug))
+}
+
+// @Summary Update Company
+// @Security ApiKeyAuth
+// @Tags companies
+// @Description переименование компании. игры компании получают новое название, старый slug перенаправляет на новый
+// @ID updateCompany
+// @Accept json
+// @Produce json
+// @Param id path string true "company id or slug"
+// @Param company body models.UpdateCompanyDTO true "company info"
+// @Success 200 {object} response
+// @Failure 400,404,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /companies/{id} [patch]
+func (h *Handler) updateCompany(c *gin.Context) {
+	var dto models.UpdateCompanyDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.Id = c.Param("id")
+
+	if err := h.services.Company.Update(c, dto); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response{Message: "Company updated"})
+}
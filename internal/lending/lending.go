@@ -0,0 +1,32 @@
+package lending
+
+import (
+	"github.com/Alexander272/games-library/internal/lending/repository"
+	"github.com/Alexander272/games-library/internal/lending/service"
+	libraryRepo "github.com/Alexander272/games-library/internal/library/repository"
+	userRepo "github.com/Alexander272/games-library/internal/user/repository"
+	"github.com/Alexander272/games-library/pkg/notifier"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ILoanRepo interface {
+	repository.ILoan
+}
+
+type ILoanService interface {
+	service.ILoan
+}
+type IReminderService interface {
+	service.IReminder
+}
+
+func NewLoanRepo(db *mongo.Database, collection, gamesCollection string) ILoanRepo {
+	return repository.NewLoanRepo(db, collection, gamesCollection)
+}
+
+func NewLoanService(repo repository.ILoan, library libraryRepo.ILibrary, users userRepo.IUser) ILoanService {
+	return service.NewLoanService(repo, library, users)
+}
+func NewReminderService(repo repository.ILoan, users userRepo.IUser, notifier notifier.Notifier) IReminderService {
+	return service.NewReminderService(repo, users, notifier)
+}
@@ -0,0 +1,12 @@
+package models
+
+import "github.com/Alexander272/games-library/pkg/apperror"
+
+var (
+	ErrLoanNotFound    = apperror.NotFound("LOAN_NOT_FOUND", "loan doesn't exists")
+	ErrNotPhysical     = apperror.Validation("NOT_PHYSICAL_COPY", "only physical copies can be lent")
+	ErrAlreadyLent     = apperror.Conflict("ALREADY_LENT", "copy is already lent")
+	ErrAlreadyReturned = apperror.Conflict("ALREADY_RETURNED", "copy is already returned")
+	ErrNoBorrower      = apperror.Validation("NO_BORROWER", "borrower user or name is required")
+	ErrInvalidDueDate  = apperror.Validation("INVALID_DUE_DATE", "due date must be after the lent date")
+)
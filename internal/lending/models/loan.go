@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+)
+
+const (
+	StatusActive   = "active"
+	StatusOverdue  = "overdue"
+	StatusReturned = "returned"
+)
+
+// The recipients of the reminders about an overdue loan, each of them is reminded independently.
+const (
+	RecipientOwner    = "owner"
+	RecipientBorrower = "borrower"
+)
+
+// Loan is a physical copy from the owner's library lent to another user or to someone outside the app.
+type Loan struct {
+	Id           string           `json:"id" bson:"_id,omitempty"`
+	OwnerId      string           `json:"ownerId" bson:"ownerId"`
+	EntryId      string           `json:"entryId" bson:"entryId"`
+	GameId       string           `json:"gameId" bson:"gameId"`
+	Game         *gameModels.Game `json:"game,omitempty" bson:"game,omitempty"`
+	BorrowerId   string           `json:"borrowerId,omitempty" bson:"borrowerId,omitempty"`
+	BorrowerName string           `json:"borrowerName,omitempty" bson:"borrowerName,omitempty"`
+	LentAt       time.Time        `json:"lentAt" bson:"lentAt"`
+	DueAt        time.Time        `json:"dueAt" bson:"dueAt,omitempty"`
+	ReturnedAt   time.Time        `json:"returnedAt" bson:"returnedAt,omitempty"`
+	Overdue      bool             `json:"overdue" bson:"overdue"`
+	Note         string           `json:"note" bson:"note,omitempty"`
+
+	OwnerRemindedAt    time.Time `json:"-" bson:"ownerRemindedAt,omitempty"`
+	BorrowerRemindedAt time.Time `json:"-" bson:"borrowerRemindedAt,omitempty"`
+}
+
+type LoanFilter struct {
+	Status string `form:"status" binding:"omitempty,oneof=active overdue returned"`
+	Limit  int64  `form:"limit" binding:"omitempty,min=1,max=100"`
+	Skip   int64  `form:"skip" binding:"omitempty,min=0"`
+}
+
+func NewLoan(dto CreateLoanDTO) (Loan, error) {
+	if dto.BorrowerId == "" && dto.BorrowerName == "" {
+		return Loan{}, ErrNoBorrower
+	}
+
+	loan := Loan{
+		OwnerId:      dto.OwnerId,
+		EntryId:      dto.EntryId,
+		BorrowerId:   dto.BorrowerId,
+		BorrowerName: dto.BorrowerName,
+		LentAt:       dto.LentAt,
+		DueAt:        dto.DueAt,
+		Note:         dto.Note,
+	}
+	if loan.LentAt.IsZero() {
+		loan.LentAt = time.Now()
+	}
+	if !loan.DueAt.IsZero() && !loan.DueAt.After(loan.LentAt) {
+		return loan, ErrInvalidDueDate
+	}
+	return loan, nil
+}
+
+type CreateLoanDTO struct {
+	OwnerId      string    `json:"-"`
+	EntryId      string    `json:"entryId" binding:"required,objectid"`
+	BorrowerId   string    `json:"borrowerId" binding:"omitempty,objectid"`
+	BorrowerName string    `json:"borrowerName" binding:"max=128"`
+	LentAt       time.Time `json:"lentAt"`
+	DueAt        time.Time `json:"dueAt"`
+	Note         string    `json:"note" binding:"max=1024"`
+}
+
+type UpdateLoanDTO struct {
+	Id      string    `json:"-"`
+	OwnerId string    `json:"-"`
+	DueAt   time.Time `json:"dueAt"`
+	Note    string    `json:"note" binding:"max=1024"`
+}
+
+type ReturnLoanDTO struct {
+	Id         string    `json:"-"`
+	OwnerId    string    `json:"-"`
+	ReturnedAt time.Time `json:"returnedAt"`
+}
@@ -0,0 +1,350 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/lending/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LoanRepo struct {
+	db    *mongo.Collection
+	games string
+}
+
+func NewLoanRepo(db *mongo.Database, collection, gamesCollection string) *LoanRepo {
+	return &LoanRepo{
+		db:    db.Collection(collection),
+		games: gamesCollection,
+	}
+}
+
+// EnsureIndexes creates the unique index of the entry over the active loans, so concurrent requests
+// can't lend the same copy twice. A partial index can't select documents without returnedAt,
+// so the active loans are marked with the active field, which is removed on return.
+func (r *LoanRepo) EnsureIndexes(ctx context.Context) error {
+	// the loans created before the field existed
+	filter := bson.M{"returnedAt": bson.M{"$exists": false}, "active": bson.M{"$exists": false}}
+	if _, err := r.db.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"active": true}}); err != nil {
+		return fmt.Errorf("failed to mark active loans. error: %w", err)
+	}
+
+	_, err := r.db.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "entryId", Value: 1}},
+		Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"active": true}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create indexes. error: %w", err)
+	}
+	return nil
+}
+
+func (r *LoanRepo) Create(ctx context.Context, loan models.Loan) (id string, err error) {
+	ownerId, entryId, err := toObjectIds(loan.OwnerId, loan.EntryId)
+	if err != nil {
+		return id, err
+	}
+	gameId, err := primitive.ObjectIDFromHex(loan.GameId)
+	if err != nil {
+		return id, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	doc := bson.M{
+		"ownerId": ownerId,
+		"entryId": entryId,
+		"gameId":  gameId,
+		"lentAt":  loan.LentAt,
+		"overdue": false,
+		"active":  true,
+	}
+	if loan.BorrowerId != "" {
+		borrowerId, err := primitive.ObjectIDFromHex(loan.BorrowerId)
+		if err != nil {
+			return id, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+		}
+		doc["borrowerId"] = borrowerId
+	}
+	if loan.BorrowerName != "" {
+		doc["borrowerName"] = loan.BorrowerName
+	}
+	if !loan.DueAt.IsZero() {
+		doc["dueAt"] = loan.DueAt
+	}
+	if loan.Note != "" {
+		doc["note"] = loan.Note
+	}
+
+	res, err := r.db.InsertOne(ctx, doc)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return id, models.ErrAlreadyLent
+		}
+		return id, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	oid, ok := res.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return id, fmt.Errorf("failed to convert objectid")
+	}
+	logger.Tracef("Created document with oid %s.\n", oid)
+	return oid.Hex(), nil
+}
+
+func (r *LoanRepo) GetAll(ctx context.Context, ownerId string, filter models.LoanFilter) (loans []models.Loan, count int64, err error) {
+	oid, err := primitive.ObjectIDFromHex(ownerId)
+	if err != nil {
+		return loans, count, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	match := bson.M{"ownerId": oid}
+	switch filter.Status {
+	case models.StatusActive:
+		match["returnedAt"] = bson.M{"$exists": false}
+	case models.StatusOverdue:
+		match["returnedAt"] = bson.M{"$exists": false}
+		match["dueAt"] = bson.M{"$lt": time.Now()}
+	case models.StatusReturned:
+		match["returnedAt"] = bson.M{"$exists": true}
+	}
+
+	loans, err = r.find(ctx, match, filter.Skip, filter.Limit)
+	if err != nil {
+		return loans, count, err
+	}
+
+	count, err = r.db.CountDocuments(ctx, match)
+	if err != nil {
+		return loans, count, fmt.Errorf("failed to count documents. error: %w", err)
+	}
+
+	return loans, count, nil
+}
+
+// GetBorrowed returns copies currently lent to the user by other users.
+func (r *LoanRepo) GetBorrowed(ctx context.Context, borrowerId string) ([]models.Loan, error) {
+	oid, err := primitive.ObjectIDFromHex(borrowerId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	return r.find(ctx, bson.M{"borrowerId": oid, "returnedAt": bson.M{"$exists": false}}, 0, 0)
+}
+
+func (r *LoanRepo) GetById(ctx context.Context, ownerId, loanId string) (loan models.Loan, err error) {
+	uid, oid, err := toObjectIds(ownerId, loanId)
+	if err != nil {
+		return loan, err
+	}
+
+	loans, err := r.find(ctx, bson.M{"_id": oid, "ownerId": uid}, 0, 1)
+	if err != nil {
+		return loan, err
+	}
+	if len(loans) == 0 {
+		return loan, models.ErrLoanNotFound
+	}
+	return loans[0], nil
+}
+
+// GetActiveByEntry returns the loan of the library entry that is not returned yet.
+func (r *LoanRepo) GetActiveByEntry(ctx context.Context, entryId string) (loan models.Loan, err error) {
+	oid, err := primitive.ObjectIDFromHex(entryId)
+	if err != nil {
+		return loan, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	res := r.db.FindOne(ctx, bson.M{"entryId": oid, "returnedAt": bson.M{"$exists": false}})
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return loan, models.ErrLoanNotFound
+		}
+		return loan, fmt.Errorf("failed to execute query. error: %w", res.Err())
+	}
+	if err := res.Decode(&loan); err != nil {
+		return loan, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return loan, nil
+}
+
+// MarkOverdue sets the overdue flag of the active loans past the due date and returns the number of marked loans.
+func (r *LoanRepo) MarkOverdue(ctx context.Context, now time.Time) (int64, error) {
+	filter := bson.M{
+		"returnedAt": bson.M{"$exists": false},
+		"dueAt":      bson.M{"$lt": now},
+		"overdue":    false,
+	}
+
+	res, err := r.db.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"overdue": true}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return res.ModifiedCount, nil
+}
+
+// GetOverdue returns loans that are past the due date and where the owner or the borrower
+// wasn't reminded about since remindedBefore.
+func (r *LoanRepo) GetOverdue(ctx context.Context, now, remindedBefore time.Time) ([]models.Loan, error) {
+	match := bson.M{
+		"returnedAt": bson.M{"$exists": false},
+		"dueAt":      bson.M{"$lt": now},
+		"$or": bson.A{
+			bson.M{"ownerRemindedAt": bson.M{"$exists": false}},
+			bson.M{"ownerRemindedAt": bson.M{"$lt": remindedBefore}},
+			bson.M{
+				"borrowerId": bson.M{"$exists": true},
+				"$or": bson.A{
+					bson.M{"borrowerRemindedAt": bson.M{"$exists": false}},
+					bson.M{"borrowerRemindedAt": bson.M{"$lt": remindedBefore}},
+				},
+			},
+		},
+	}
+	return r.find(ctx, match, 0, 0)
+}
+
+func (r *LoanRepo) Update(ctx context.Context, loan models.Loan) error {
+	uid, oid, err := toObjectIds(loan.OwnerId, loan.Id)
+	if err != nil {
+		return err
+	}
+
+	updateObj := bson.M{"overdue": loan.Overdue}
+	if !loan.DueAt.IsZero() {
+		updateObj["dueAt"] = loan.DueAt
+	}
+	if loan.Note != "" {
+		updateObj["note"] = loan.Note
+	}
+
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": oid, "ownerId": uid}, bson.M{"$set": updateObj})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrLoanNotFound
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+func (r *LoanRepo) Return(ctx context.Context, ownerId, loanId string, returnedAt time.Time) error {
+	uid, oid, err := toObjectIds(ownerId, loanId)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": oid, "ownerId": uid, "returnedAt": bson.M{"$exists": false}}
+	update := bson.M{
+		"$set":   bson.M{"returnedAt": returnedAt, "overdue": false},
+		"$unset": bson.M{"active": ""},
+	}
+
+	res, err := r.db.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrLoanNotFound
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+// SetReminded saves the time of the last reminder sent to the recipient of the loan.
+func (r *LoanRepo) SetReminded(ctx context.Context, loanId, recipient string, remindedAt time.Time) error {
+	oid, err := primitive.ObjectIDFromHex(loanId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	var field string
+	switch recipient {
+	case models.RecipientOwner:
+		field = "ownerRemindedAt"
+	case models.RecipientBorrower:
+		field = "borrowerRemindedAt"
+	default:
+		return fmt.Errorf("unknown recipient %q", recipient)
+	}
+
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{field: remindedAt}})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+func (r *LoanRepo) Remove(ctx context.Context, ownerId, loanId string) error {
+	uid, oid, err := toObjectIds(ownerId, loanId)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.db.DeleteOne(ctx, bson.M{"_id": oid, "ownerId": uid})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return models.ErrLoanNotFound
+	}
+
+	logger.Tracef("Delete %v documents.\n", res.DeletedCount)
+	return nil
+}
+
+func (r *LoanRepo) find(ctx context.Context, match bson.M, skip, limit int64) (loans []models.Loan, err error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "lentAt", Value: -1}}}},
+	}
+	if skip > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: skip}})
+	}
+	if limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$lookup", Value: bson.M{
+			"from":         r.games,
+			"localField":   "gameId",
+			"foreignField": "_id",
+			"as":           "game",
+		}}},
+		bson.D{{Key: "$unwind", Value: bson.M{"path": "$game", "preserveNullAndEmptyArrays": true}}},
+	)
+
+	cur, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return loans, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &loans); err != nil {
+		return loans, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return loans, nil
+}
+
+func toObjectIds(first, second string) (primitive.ObjectID, primitive.ObjectID, error) {
+	firstOid, err := primitive.ObjectIDFromHex(first)
+	if err != nil {
+		return firstOid, primitive.NilObjectID, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	secondOid, err := primitive.ObjectIDFromHex(second)
+	if err != nil {
+		return firstOid, secondOid, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	return firstOid, secondOid, nil
+}
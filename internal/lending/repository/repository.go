@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/lending/models"
+	loan "github.com/Alexander272/games-library/internal/lending/repository/mongo"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ILoan interface {
+	EnsureIndexes(ctx context.Context) error
+	Create(ctx context.Context, loan models.Loan) (string, error)
+	GetAll(ctx context.Context, ownerId string, filter models.LoanFilter) ([]models.Loan, int64, error)
+	GetBorrowed(ctx context.Context, borrowerId string) ([]models.Loan, error)
+	GetById(ctx context.Context, ownerId, loanId string) (models.Loan, error)
+	GetActiveByEntry(ctx context.Context, entryId string) (models.Loan, error)
+	MarkOverdue(ctx context.Context, now time.Time) (int64, error)
+	GetOverdue(ctx context.Context, now, remindedBefore time.Time) ([]models.Loan, error)
+	Update(ctx context.Context, loan models.Loan) error
+	Return(ctx context.Context, ownerId, loanId string, returnedAt time.Time) error
+	SetReminded(ctx context.Context, loanId, recipient string, remindedAt time.Time) error
+	Remove(ctx context.Context, ownerId, loanId string) error
+}
+
+func NewLoanRepo(db *mongo.Database, collection, gamesCollection string) ILoan {
+	return loan.NewLoanRepo(db, collection, gamesCollection)
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/lending/models"
+	"github.com/Alexander272/games-library/internal/lending/repository"
+	libraryModels "github.com/Alexander272/games-library/internal/library/models"
+	libraryRepo "github.com/Alexander272/games-library/internal/library/repository"
+	userModels "github.com/Alexander272/games-library/internal/user/models"
+	userRepo "github.com/Alexander272/games-library/internal/user/repository"
+)
+
+type LoanService struct {
+	repo    repository.ILoan
+	library libraryRepo.ILibrary
+	users   userRepo.IUser
+}
+
+func NewLoanService(repo repository.ILoan, library libraryRepo.ILibrary, users userRepo.IUser) *LoanService {
+	return &LoanService{
+		repo:    repo,
+		library: library,
+		users:   users,
+	}
+}
+
+// Lend creates a loan of the owner's physical copy. A copy can have only one active loan.
+func (s *LoanService) Lend(ctx context.Context, dto models.CreateLoanDTO) (id string, err error) {
+	loan, err := models.NewLoan(dto)
+	if err != nil {
+		return id, err
+	}
+
+	entry, err := s.library.GetById(ctx, dto.OwnerId, dto.EntryId)
+	if err != nil {
+		if errors.Is(err, libraryModels.ErrEntryNotFound) {
+			return id, err
+		}
+		return id, fmt.Errorf("failed to get library entry. error: %w", err)
+	}
+	if entry.Ownership != libraryModels.OwnershipPhysical {
+		return id, models.ErrNotPhysical
+	}
+	loan.GameId = entry.GameId
+
+	_, err = s.repo.GetActiveByEntry(ctx, dto.EntryId)
+	if err == nil {
+		return id, models.ErrAlreadyLent
+	}
+	if !errors.Is(err, models.ErrLoanNotFound) {
+		return id, fmt.Errorf("failed to get active loan. error: %w", err)
+	}
+
+	if loan.BorrowerId != "" {
+		if _, err := s.users.GetById(ctx, loan.BorrowerId); err != nil {
+			if errors.Is(err, userModels.ErrUserNotFound) {
+				return id, err
+			}
+			return id, fmt.Errorf("failed to get borrower. error: %w", err)
+		}
+	}
+
+	// the copy can be lent by a concurrent request after the check, the index of the active loans rejects it
+	id, err = s.repo.Create(ctx, loan)
+	if err != nil {
+		if errors.Is(err, models.ErrAlreadyLent) {
+			return id, err
+		}
+		return id, fmt.Errorf("failed to create loan. error: %w", err)
+	}
+	return id, nil
+}
+
+func (s *LoanService) GetAll(ctx context.Context, ownerId string, filter models.LoanFilter) (loans []models.Loan, count int64, err error) {
+	loans, count, err = s.repo.GetAll(ctx, ownerId, filter)
+	if err != nil {
+		return loans, count, fmt.Errorf("failed to get loans. error: %w", err)
+	}
+	if len(loans) == 0 {
+		return loans, count, models.ErrLoanNotFound
+	}
+	return loans, count, nil
+}
+
+// GetLent returns the owner's copies that are currently lent out.
+func (s *LoanService) GetLent(ctx context.Context, ownerId string) ([]models.Loan, error) {
+	loans, _, err := s.GetAll(ctx, ownerId, models.LoanFilter{Status: models.StatusActive})
+	return loans, err
+}
+
+func (s *LoanService) GetBorrowed(ctx context.Context, borrowerId string) (loans []models.Loan, err error) {
+	loans, err = s.repo.GetBorrowed(ctx, borrowerId)
+	if err != nil {
+		return loans, fmt.Errorf("failed to get borrowed copies. error: %w", err)
+	}
+	if len(loans) == 0 {
+		return loans, models.ErrLoanNotFound
+	}
+	return loans, nil
+}
+
+func (s *LoanService) GetById(ctx context.Context, ownerId, loanId string) (loan models.Loan, err error) {
+	loan, err = s.repo.GetById(ctx, ownerId, loanId)
+	if err != nil {
+		if errors.Is(err, models.ErrLoanNotFound) {
+			return loan, err
+		}
+		return loan, fmt.Errorf("failed to get loan. error: %w", err)
+	}
+	return loan, nil
+}
+
+func (s *LoanService) Update(ctx context.Context, dto models.UpdateLoanDTO) error {
+	loan, err := s.GetById(ctx, dto.OwnerId, dto.Id)
+	if err != nil {
+		return err
+	}
+	if !loan.ReturnedAt.IsZero() {
+		return models.ErrAlreadyReturned
+	}
+
+	if !dto.DueAt.IsZero() {
+		if !dto.DueAt.After(loan.LentAt) {
+			return models.ErrInvalidDueDate
+		}
+		loan.DueAt = dto.DueAt
+	}
+	loan.Note = dto.Note
+	loan.Overdue = !loan.DueAt.IsZero() && loan.DueAt.Before(time.Now())
+
+	if err := s.repo.Update(ctx, loan); err != nil {
+		if errors.Is(err, models.ErrLoanNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to update loan. error: %w", err)
+	}
+	return nil
+}
+
+func (s *LoanService) Return(ctx context.Context, dto models.ReturnLoanDTO) error {
+	loan, err := s.GetById(ctx, dto.OwnerId, dto.Id)
+	if err != nil {
+		return err
+	}
+	if !loan.ReturnedAt.IsZero() {
+		return models.ErrAlreadyReturned
+	}
+
+	returnedAt := dto.ReturnedAt
+	if returnedAt.IsZero() {
+		returnedAt = time.Now()
+	}
+	if returnedAt.Before(loan.LentAt) {
+		returnedAt = loan.LentAt
+	}
+
+	if err := s.repo.Return(ctx, dto.OwnerId, dto.Id, returnedAt); err != nil {
+		if errors.Is(err, models.ErrLoanNotFound) {
+			return models.ErrAlreadyReturned
+		}
+		return fmt.Errorf("failed to return loan. error: %w", err)
+	}
+	return nil
+}
+
+func (s *LoanService) Remove(ctx context.Context, ownerId, loanId string) error {
+	if err := s.repo.Remove(ctx, ownerId, loanId); err != nil {
+		if errors.Is(err, models.ErrLoanNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to remove loan. error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Alexander272/games-library/internal/lending/models"
+	libraryModels "github.com/Alexander272/games-library/internal/library/models"
+	libraryRepo "github.com/Alexander272/games-library/internal/library/repository"
+)
+
+type fakeLibrary struct {
+	libraryRepo.ILibrary
+}
+
+func (f *fakeLibrary) GetById(ctx context.Context, userId, entryId string) (libraryModels.Entry, error) {
+	return libraryModels.Entry{Id: entryId, UserId: userId, GameId: "game", Ownership: libraryModels.OwnershipPhysical}, nil
+}
+
+// racingLoans finds no active loan, but the insert is rejected by the index like after a concurrent loan.
+type racingLoans struct {
+	fakeLoans
+}
+
+func (f *racingLoans) GetActiveByEntry(ctx context.Context, entryId string) (models.Loan, error) {
+	return models.Loan{}, models.ErrLoanNotFound
+}
+
+func (f *racingLoans) Create(ctx context.Context, loan models.Loan) (string, error) {
+	return "", models.ErrAlreadyLent
+}
+
+func TestLendConcurrent(t *testing.T) {
+	s := NewLoanService(&racingLoans{}, &fakeLibrary{}, &fakeUsers{})
+
+	_, err := s.Lend(context.Background(), models.CreateLoanDTO{OwnerId: "owner", EntryId: "entry", BorrowerName: "Alex"})
+	if !errors.Is(err, models.ErrAlreadyLent) {
+		t.Errorf("Lend() error = %v, want %v", err, models.ErrAlreadyLent)
+	}
+}
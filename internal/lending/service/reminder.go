@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/lending/models"
+	"github.com/Alexander272/games-library/internal/lending/repository"
+	userRepo "github.com/Alexander272/games-library/internal/user/repository"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/Alexander272/games-library/pkg/notifier"
+)
+
+const (
+	// remindEvery is the minimal time between two reminders about the same loan.
+	remindEvery     = 24 * time.Hour
+	defaultInterval = time.Hour
+)
+
+type ReminderService struct {
+	repo     repository.ILoan
+	users    userRepo.IUser
+	notifier notifier.Notifier
+}
+
+func NewReminderService(repo repository.ILoan, users userRepo.IUser, notifier notifier.Notifier) *ReminderService {
+	return &ReminderService{
+		repo:     repo,
+		users:    users,
+		notifier: notifier,
+	}
+}
+
+// CheckOverdue marks loans past the due date as overdue and reminds the owner and the borrower about them.
+// The loans are marked before the reminders are sent, so a failing notifier doesn't keep them active.
+// Every recipient is reminded at most once per remindEvery, a failed reminder of one of them is sent
+// again on the next check without reminding the other one.
+func (s *ReminderService) CheckOverdue(ctx context.Context) error {
+	now := time.Now()
+	marked, err := s.repo.MarkOverdue(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to mark overdue loans. error: %w", err)
+	}
+
+	remindedBefore := now.Add(-remindEvery)
+	loans, err := s.repo.GetOverdue(ctx, now, remindedBefore)
+	if err != nil {
+		return fmt.Errorf("failed to get overdue loans. error: %w", err)
+	}
+
+	for _, loan := range loans {
+		if err := s.remind(ctx, loan, now, remindedBefore); err != nil {
+			return err
+		}
+	}
+
+	logger.Debugf("Checked overdue loans: %d marked, %d to remind.", marked, len(loans))
+	return nil
+}
+
+// Run checks overdue loans every interval until the context is cancelled.
+func (s *ReminderService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.CheckOverdue(ctx); err != nil {
+			logger.Errorf("failed to check overdue loans. error: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// remind notifies the recipients of the loan who weren't reminded since remindedBefore. The failed
+// notifications are logged, only the errors of saving the reminders are returned.
+func (s *ReminderService) remind(ctx context.Context, loan models.Loan, now, remindedBefore time.Time) error {
+	title := loan.GameId
+	if loan.Game != nil {
+		title = loan.Game.Title
+	}
+	due := loan.DueAt.Format("2006-01-02")
+
+	owner, err := s.users.GetById(ctx, loan.OwnerId)
+	if err != nil {
+		logger.Errorf("failed to get owner of loan %s. error: %s", loan.Id, err.Error())
+		return nil
+	}
+	borrower := loan.BorrowerName
+	if loan.BorrowerId != "" {
+		user, err := s.users.GetById(ctx, loan.BorrowerId)
+		if err != nil {
+			logger.Errorf("failed to get borrower of loan %s. error: %s", loan.Id, err.Error())
+			return nil
+		}
+		if borrower == "" {
+			borrower = user.Name
+		}
+
+		if loan.BorrowerRemindedAt.Before(remindedBefore) {
+			msg := notifier.Message{
+				UserId:  user.Id,
+				Email:   user.Email,
+				Subject: "Please return the game",
+				Text:    fmt.Sprintf("%s was due back to %s on %s.", title, owner.Name, due),
+			}
+			if err := s.send(ctx, loan.Id, models.RecipientBorrower, msg, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	if loan.OwnerRemindedAt.Before(remindedBefore) {
+		msg := notifier.Message{
+			UserId:  owner.Id,
+			Email:   owner.Email,
+			Subject: "Lent game is overdue",
+			Text:    fmt.Sprintf("%s lent to %s was due back on %s.", title, borrower, due),
+		}
+		if err := s.send(ctx, loan.Id, models.RecipientOwner, msg, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send notifies the recipient and saves the time of the reminder if it's sent.
+func (s *ReminderService) send(ctx context.Context, loanId, recipient string, msg notifier.Message, now time.Time) error {
+	if err := s.notifier.Notify(ctx, msg); err != nil {
+		logger.Errorf("failed to send reminder about loan %s to %s. error: %s", loanId, recipient, err.Error())
+		return nil
+	}
+	if err := s.repo.SetReminded(ctx, loanId, recipient, now); err != nil {
+		return fmt.Errorf("failed to mark loan as reminded. error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/lending/models"
+	"github.com/Alexander272/games-library/internal/lending/repository"
+	userModels "github.com/Alexander272/games-library/internal/user/models"
+	userRepo "github.com/Alexander272/games-library/internal/user/repository"
+	"github.com/Alexander272/games-library/pkg/notifier"
+)
+
+// fakeLoans keeps the loans in memory, the other methods of the repo aren't used by the reminders.
+type fakeLoans struct {
+	repository.ILoan
+	loans []models.Loan
+}
+
+func (f *fakeLoans) MarkOverdue(ctx context.Context, now time.Time) (int64, error) {
+	var marked int64
+	for i, loan := range f.loans {
+		if loan.ReturnedAt.IsZero() && loan.DueAt.Before(now) && !loan.Overdue {
+			f.loans[i].Overdue = true
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+func (f *fakeLoans) GetOverdue(ctx context.Context, now, remindedBefore time.Time) ([]models.Loan, error) {
+	var loans []models.Loan
+	for _, loan := range f.loans {
+		if !loan.ReturnedAt.IsZero() || !loan.DueAt.Before(now) {
+			continue
+		}
+		if loan.OwnerRemindedAt.Before(remindedBefore) || (loan.BorrowerId != "" && loan.BorrowerRemindedAt.Before(remindedBefore)) {
+			loans = append(loans, loan)
+		}
+	}
+	return loans, nil
+}
+
+func (f *fakeLoans) SetReminded(ctx context.Context, loanId, recipient string, remindedAt time.Time) error {
+	for i := range f.loans {
+		if f.loans[i].Id != loanId {
+			continue
+		}
+		if recipient == models.RecipientOwner {
+			f.loans[i].OwnerRemindedAt = remindedAt
+		} else {
+			f.loans[i].BorrowerRemindedAt = remindedAt
+		}
+	}
+	return nil
+}
+
+type fakeUsers struct {
+	userRepo.IUser
+}
+
+func (f *fakeUsers) GetById(ctx context.Context, userId string) (userModels.User, error) {
+	return userModels.User{Id: userId, Name: userId}, nil
+}
+
+// fakeNotifier counts the sent messages by user and fails for the users in failFor.
+type fakeNotifier struct {
+	failFor map[string]bool
+	sent    map[string]int
+}
+
+func (f *fakeNotifier) Notify(ctx context.Context, msg notifier.Message) error {
+	if f.failFor[msg.UserId] {
+		return errors.New("delivery failed")
+	}
+	f.sent[msg.UserId]++
+	return nil
+}
+
+func TestCheckOverdue(t *testing.T) {
+	tests := []struct {
+		name         string
+		failFor      []string
+		wantSent     map[string]int
+		wantOverdue  bool
+		wantReminded []string
+	}{
+		{
+			name:         "both reminded",
+			wantSent:     map[string]int{"owner": 1, "borrower": 1},
+			wantOverdue:  true,
+			wantReminded: []string{models.RecipientOwner, models.RecipientBorrower},
+		},
+		{
+			name:        "notifier fails",
+			failFor:     []string{"owner", "borrower"},
+			wantSent:    map[string]int{},
+			wantOverdue: true,
+		},
+		{
+			name:         "owner notification fails",
+			failFor:      []string{"owner"},
+			wantSent:     map[string]int{"borrower": 1},
+			wantOverdue:  true,
+			wantReminded: []string{models.RecipientBorrower},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeLoans{loans: []models.Loan{{
+				Id:         "loan",
+				OwnerId:    "owner",
+				BorrowerId: "borrower",
+				DueAt:      time.Now().Add(-time.Hour),
+			}}}
+			n := &fakeNotifier{failFor: map[string]bool{}, sent: map[string]int{}}
+			for _, id := range tt.failFor {
+				n.failFor[id] = true
+			}
+			s := NewReminderService(repo, &fakeUsers{}, n)
+
+			// the second check must not repeat the sent reminders
+			for i := 0; i < 2; i++ {
+				if err := s.CheckOverdue(context.Background()); err != nil {
+					t.Fatalf("CheckOverdue() error = %v", err)
+				}
+			}
+
+			for _, id := range []string{"owner", "borrower"} {
+				if n.sent[id] != tt.wantSent[id] {
+					t.Errorf("sent to %s = %d, want %d", id, n.sent[id], tt.wantSent[id])
+				}
+			}
+			loan := repo.loans[0]
+			if loan.Overdue != tt.wantOverdue {
+				t.Errorf("overdue = %v, want %v", loan.Overdue, tt.wantOverdue)
+			}
+			reminded := map[string]bool{
+				models.RecipientOwner:    !loan.OwnerRemindedAt.IsZero(),
+				models.RecipientBorrower: !loan.BorrowerRemindedAt.IsZero(),
+			}
+			for _, r := range tt.wantReminded {
+				if !reminded[r] {
+					t.Errorf("%s isn't marked as reminded", r)
+				}
+				delete(reminded, r)
+			}
+			for r, ok := range reminded {
+				if ok {
+					t.Errorf("%s is marked as reminded", r)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckOverdueNotDue(t *testing.T) {
+	repo := &fakeLoans{loans: []models.Loan{{Id: "loan", OwnerId: "owner", DueAt: time.Now().Add(time.Hour)}}}
+	n := &fakeNotifier{sent: map[string]int{}}
+	if err := NewReminderService(repo, &fakeUsers{}, n).CheckOverdue(context.Background()); err != nil {
+		t.Fatalf("CheckOverdue() error = %v", err)
+	}
+	if repo.loans[0].Overdue || len(n.sent) != 0 {
+		t.Errorf("loan before the due date is overdue = %v, sent = %v", repo.loans[0].Overdue, n.sent)
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/lending/models"
+)
+
+type ILoan interface {
+	Lend(ctx context.Context, dto models.CreateLoanDTO) (string, error)
+	GetAll(ctx context.Context, ownerId string, filter models.LoanFilter) ([]models.Loan, int64, error)
+	GetLent(ctx context.Context, ownerId string) ([]models.Loan, error)
+	GetBorrowed(ctx context.Context, borrowerId string) ([]models.Loan, error)
+	GetById(ctx context.Context, ownerId, loanId string) (models.Loan, error)
+	Update(ctx context.Context, dto models.UpdateLoanDTO) error
+	Return(ctx context.Context, dto models.ReturnLoanDTO) error
+	Remove(ctx context.Context, ownerId, loanId string) error
+}
+
+type IReminder interface {
+	CheckOverdue(ctx context.Context) error
+	Run(ctx context.Context, interval time.Duration)
+}
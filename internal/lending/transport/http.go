@@ -0,0 +1,289 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/Alexander272/games-library/internal/lending/models"
+	"github.com/Alexander272/games-library/internal/service"
+	"github.com/Alexander272/games-library/internal/transport/middleware"
+	"github.com/Alexander272/games-library/internal/transport/validation"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services   *service.Services
+	middleware *middleware.Middleware
+}
+
+func NewHandler(services *service.Services, middleware *middleware.Middleware) *Handler {
+	return &Handler{
+		services:   services,
+		middleware: middleware,
+	}
+}
+
+func (h *Handler) Init(api *gin.RouterGroup) {
+	loans := api.Group("/users/me/loans", h.middleware.UserIdentity, h.middleware.ObjectIds("id"))
+	{
+		loans.GET("/", h.getAll)
+		loans.POST("/", h.lend)
+		loans.GET("/lent", h.getLent)
+		loans.GET("/borrowed", h.getBorrowed)
+		loans.GET("/:id", h.getById)
+		loans.PATCH("/:id", h.update)
+		loans.POST("/:id/return", h.returnLoan)
+		loans.DELETE("/:id", h.remove)
+	}
+}
+
+// @Summary Get All
+// @Security ApiKeyAuth
+// @Tags lending
+// @Description получение истории выдачи копий пользователя
+// @ID getLoans
+// @Accept json
+// @Produce json
+// @Param filter query models.LoanFilter false "filter"
+// @Success 200 {object} dataResponse{data=[]models.Loan}
+// @Failure 400,401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/loans [get]
+func (h *Handler) getAll(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var filter models.LoanFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(validation.Query(err))
+		return
+	}
+
+	loans, count, err := h.services.Loan.GetAll(c, userId, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: loans, Count: count})
+}
+
+// @Summary Lend
+// @Security ApiKeyAuth
+// @Tags lending
+// @Description выдача физической копии игры из библиотеки. Нужно указать пользователя или имя того, кто взял игру
+// @ID lendGame
+// @Accept json
+// @Produce json
+// @Param loan body models.CreateLoanDTO true "loan info"
+// @Success 201 {object} idResponse
+// @Failure 400,401,404,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/loans [post]
+func (h *Handler) lend(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto models.CreateLoanDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.OwnerId = userId
+
+	id, err := h.services.Loan.Lend(c, dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, idResponse{Id: id})
+}
+
+// @Summary Lent
+// @Security ApiKeyAuth
+// @Tags lending
+// @Description копии пользователя, которые сейчас выданы
+// @ID getLentCopies
+// @Accept json
+// @Produce json
+// @Success 200 {object} dataResponse{data=[]models.Loan}
+// @Failure 401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/loans/lent [get]
+func (h *Handler) getLent(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	loans, err := h.services.Loan.GetLent(c, userId)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: loans, Count: int64(len(loans))})
+}
+
+// @Summary Borrowed
+// @Security ApiKeyAuth
+// @Tags lending
+// @Description копии других пользователей, которые сейчас на руках у пользователя
+// @ID getBorrowedCopies
+// @Accept json
+// @Produce json
+// @Success 200 {object} dataResponse{data=[]models.Loan}
+// @Failure 401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/loans/borrowed [get]
+func (h *Handler) getBorrowed(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	loans, err := h.services.Loan.GetBorrowed(c, userId)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: loans, Count: int64(len(loans))})
+}
+
+// @Summary Get By Id
+// @Security ApiKeyAuth
+// @Tags lending
+// @Description получение выдачи копии
+// @ID getLoanById
+// @Accept json
+// @Produce json
+// @Param id path string true "loan id"
+// @Success 200 {object} dataResponse{data=models.Loan}
+// @Failure 401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/loans/{id} [get]
+func (h *Handler) getById(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	loan, err := h.services.Loan.GetById(c, userId, c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: loan})
+}
+
+// @Summary Update
+// @Security ApiKeyAuth
+// @Tags lending
+// @Description изменение срока возврата или заметки
+// @ID updateLoan
+// @Accept json
+// @Produce json
+// @Param id path string true "loan id"
+// @Param loan body models.UpdateLoanDTO true "loan info"
+// @Success 200 {object} response
+// @Failure 400,401,404,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/loans/{id} [patch]
+func (h *Handler) update(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto models.UpdateLoanDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.Id = c.Param("id")
+	dto.OwnerId = userId
+
+	if err := h.services.Loan.Update(c, dto); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response{Message: "Loan updated"})
+}
+
+// @Summary Return
+// @Security ApiKeyAuth
+// @Tags lending
+// @Description отметка о возврате копии. Если дата не указана, используется текущая
+// @ID returnLoan
+// @Accept json
+// @Produce json
+// @Param id path string true "loan id"
+// @Param loan body models.ReturnLoanDTO false "return info"
+// @Success 200 {object} response
+// @Failure 400,401,404,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/loans/{id}/return [post]
+func (h *Handler) returnLoan(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto models.ReturnLoanDTO
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&dto); err != nil {
+			c.Error(validation.Body(err))
+			return
+		}
+	}
+	dto.Id = c.Param("id")
+	dto.OwnerId = userId
+
+	if err := h.services.Loan.Return(c, dto); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response{Message: "Loan returned"})
+}
+
+// @Summary Remove
+// @Security ApiKeyAuth
+// @Tags lending
+// @Description удаление записи о выдаче
+// @ID removeLoan
+// @Accept json
+// @Produce json
+// @Param id path string true "loan id"
+// @Success 204 {object} response
+// @Failure 401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/loans/{id} [delete]
+func (h *Handler) remove(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.services.Loan.Remove(c, userId, c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusNoContent, response{Message: "Loan removed"})
+}
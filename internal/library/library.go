@@ -0,0 +1,24 @@
+package library
+
+import (
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/internal/library/repository"
+	"github.com/Alexander272/games-library/internal/library/service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ILibraryRepo interface {
+	repository.ILibrary
+}
+
+type ILibraryService interface {
+	service.ILibrary
+}
+
+func NewLibraryRepo(db *mongo.Database, collection, gamesCollection string) ILibraryRepo {
+	return repository.NewLibraryRepo(db, collection, gamesCollection)
+}
+
+func NewLibraryService(repo repository.ILibrary, games gameRepo.IGame) ILibraryService {
+	return service.NewLibraryService(repo, games)
+}
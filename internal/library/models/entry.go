@@ -0,0 +1,88 @@
+package models
+
+import (
+	"time"
+
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+)
+
+const (
+	StatusWishlist  = "wishlist"
+	StatusBacklog   = "backlog"
+	StatusPlaying   = "playing"
+	StatusCompleted = "completed"
+	StatusDropped   = "dropped"
+)
+
+const (
+	OwnershipNone     = "none"
+	OwnershipDigital  = "digital"
+	OwnershipPhysical = "physical"
+)
+
+// Entry is a game in the user's library.
+type Entry struct {
+	Id        string           `json:"id" bson:"_id,omitempty"`
+	UserId    string           `json:"userId" bson:"userId"`
+	GameId    string           `json:"gameId" bson:"gameId"`
+	Game      *gameModels.Game `json:"game,omitempty" bson:"game,omitempty"`
+	Status    string           `json:"status" bson:"status"`
+	Platform  string           `json:"platform" bson:"platform,omitempty"`
+	Ownership string           `json:"ownership" bson:"ownership,omitempty"`
+	Rating    int              `json:"rating" bson:"rating,omitempty"`
+	Note      string           `json:"note" bson:"note,omitempty"`
+	AddedAt   time.Time        `json:"addedAt" bson:"addedAt"`
+	UpdatedAt time.Time        `json:"updatedAt" bson:"updatedAt"`
+}
+
+type EntryFilter struct {
+	Status    string `form:"status" binding:"omitempty,oneof=wishlist backlog playing completed dropped"`
+	Platform  string `form:"platform"`
+	Ownership string `form:"ownership" binding:"omitempty,oneof=none digital physical"`
+	Limit     int64  `form:"limit" binding:"omitempty,min=1,max=100"`
+	Skip      int64  `form:"skip" binding:"omitempty,min=0"`
+}
+
+func NewEntry(dto CreateEntryDTO) Entry {
+	return Entry{
+		UserId:    dto.UserId,
+		GameId:    dto.GameId,
+		Status:    dto.Status,
+		Platform:  dto.Platform,
+		Ownership: dto.Ownership,
+		Rating:    dto.Rating,
+		Note:      dto.Note,
+	}
+}
+
+type CreateEntryDTO struct {
+	UserId    string `json:"-"`
+	GameId    string `json:"gameId" binding:"required,objectid"`
+	Status    string `json:"status" binding:"required,oneof=wishlist backlog playing completed dropped"`
+	Platform  string `json:"platform"`
+	Ownership string `json:"ownership" binding:"omitempty,oneof=none digital physical"`
+	Rating    int    `json:"rating" binding:"omitempty,min=1,max=10"`
+	Note      string `json:"note" binding:"max=1024"`
+}
+
+func UpdateEntry(dto UpdateEntryDTO) Entry {
+	return Entry{
+		Id:        dto.Id,
+		UserId:    dto.UserId,
+		Status:    dto.Status,
+		Platform:  dto.Platform,
+		Ownership: dto.Ownership,
+		Rating:    dto.Rating,
+		Note:      dto.Note,
+	}
+}
+
+type UpdateEntryDTO struct {
+	Id        string `json:"-"`
+	UserId    string `json:"-"`
+	Status    string `json:"status" binding:"omitempty,oneof=wishlist backlog playing completed dropped"`
+	Platform  string `json:"platform"`
+	Ownership string `json:"ownership" binding:"omitempty,oneof=none digital physical"`
+	Rating    int    `json:"rating" binding:"omitempty,min=1,max=10"`
+	Note      string `json:"note" binding:"max=1024"`
+}
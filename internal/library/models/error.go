@@ -0,0 +1,8 @@
+package models
+
+import "github.com/Alexander272/games-library/pkg/apperror"
+
+var (
+	ErrEntryNotFound = apperror.NotFound("LIBRARY_ENTRY_NOT_FOUND", "library entry doesn't exists")
+	ErrEntryExists   = apperror.Conflict("LIBRARY_ENTRY_EXISTS", "game is already in the library")
+)
@@ -0,0 +1,288 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Alexander272/games-library/internal/library/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LibraryRepo struct {
+	db    *mongo.Collection
+	games string
+}
+
+// NewLibraryRepo creates the repository. Entries are joined with the games collection
+// so that listings contain the game data.
+func NewLibraryRepo(db *mongo.Database, collection, gamesCollection string) *LibraryRepo {
+	return &LibraryRepo{
+		db:    db.Collection(collection),
+		games: gamesCollection,
+	}
+}
+
+// EnsureIndexes creates the unique index of the user and the game, so concurrent requests
+// can't add the same game to the library twice.
+func (r *LibraryRepo) EnsureIndexes(ctx context.Context) error {
+	_, err := r.db.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "userId", Value: 1}, {Key: "gameId", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create indexes. error: %w", err)
+	}
+	return nil
+}
+
+func (r *LibraryRepo) Create(ctx context.Context, entry models.Entry) (id string, err error) {
+	userId, gameId, err := toObjectIds(entry.UserId, entry.GameId)
+	if err != nil {
+		return id, err
+	}
+
+	doc := bson.M{
+		"userId":    userId,
+		"gameId":    gameId,
+		"status":    entry.Status,
+		"platform":  entry.Platform,
+		"ownership": entry.Ownership,
+		"rating":    entry.Rating,
+		"note":      entry.Note,
+		"addedAt":   entry.AddedAt,
+		"updatedAt": entry.UpdatedAt,
+	}
+
+	res, err := r.db.InsertOne(ctx, doc)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return id, models.ErrEntryExists
+		}
+		return id, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	oid, ok := res.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return id, fmt.Errorf("failed to convert objectid")
+	}
+	logger.Tracef("Created document with oid %s.\n", oid)
+	return oid.Hex(), nil
+}
+
+func (r *LibraryRepo) GetAll(ctx context.Context, userId string, filter models.EntryFilter) (entries []models.Entry, count int64, err error) {
+	match, err := buildFilter(userId, filter)
+	if err != nil {
+		return entries, count, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "addedAt", Value: -1}}}},
+	}
+	if filter.Skip > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: filter.Skip}})
+	}
+	if filter.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: filter.Limit}})
+	}
+	pipeline = append(pipeline, r.lookupGame()...)
+
+	cur, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return entries, count, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &entries); err != nil {
+		return entries, count, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	count, err = r.db.CountDocuments(ctx, match)
+	if err != nil {
+		return entries, count, fmt.Errorf("failed to count documents. error: %w", err)
+	}
+
+	return entries, count, nil
+}
+
+// Export iterates over the user's entries matching the filter without loading them into memory.
+func (r *LibraryRepo) Export(ctx context.Context, userId string, filter models.EntryFilter, fn func(models.Entry) error) error {
+	match, err := buildFilter(userId, filter)
+	if err != nil {
+		return err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "addedAt", Value: -1}}}},
+	}
+	pipeline = append(pipeline, r.lookupGame()...)
+
+	cur, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var entry models.Entry
+		if err := cur.Decode(&entry); err != nil {
+			return fmt.Errorf("failed to decode document. error: %w", err)
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	if err := cur.Err(); err != nil {
+		return fmt.Errorf("failed to iterate cursor. error: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LibraryRepo) GetById(ctx context.Context, userId, entryId string) (entry models.Entry, err error) {
+	uid, oid, err := toObjectIds(userId, entryId)
+	if err != nil {
+		return entry, err
+	}
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: bson.M{"_id": oid, "userId": uid}}}}
+	pipeline = append(pipeline, r.lookupGame()...)
+
+	cur, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return entry, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	if !cur.Next(ctx) {
+		if cur.Err() != nil {
+			return entry, fmt.Errorf("failed to execute query. error: %w", cur.Err())
+		}
+		return entry, models.ErrEntryNotFound
+	}
+	if err := cur.Decode(&entry); err != nil {
+		return entry, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (r *LibraryRepo) GetByGame(ctx context.Context, userId, gameId string) (entry models.Entry, err error) {
+	uid, gid, err := toObjectIds(userId, gameId)
+	if err != nil {
+		return entry, err
+	}
+
+	res := r.db.FindOne(ctx, bson.M{"userId": uid, "gameId": gid})
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return entry, models.ErrEntryNotFound
+		}
+		return entry, fmt.Errorf("failed to execute query. error: %w", res.Err())
+	}
+	if err := res.Decode(&entry); err != nil {
+		return entry, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (r *LibraryRepo) Update(ctx context.Context, entry models.Entry) error {
+	uid, oid, err := toObjectIds(entry.UserId, entry.Id)
+	if err != nil {
+		return err
+	}
+
+	updateObj := bson.M{"updatedAt": entry.UpdatedAt}
+	if entry.Status != "" {
+		updateObj["status"] = entry.Status
+	}
+	if entry.Platform != "" {
+		updateObj["platform"] = entry.Platform
+	}
+	if entry.Ownership != "" {
+		updateObj["ownership"] = entry.Ownership
+	}
+	if entry.Rating != 0 {
+		updateObj["rating"] = entry.Rating
+	}
+	if entry.Note != "" {
+		updateObj["note"] = entry.Note
+	}
+
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": oid, "userId": uid}, bson.M{"$set": updateObj})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrEntryNotFound
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+func (r *LibraryRepo) Remove(ctx context.Context, userId, entryId string) error {
+	uid, oid, err := toObjectIds(userId, entryId)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.db.DeleteOne(ctx, bson.M{"_id": oid, "userId": uid})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return models.ErrEntryNotFound
+	}
+
+	logger.Tracef("Delete %v documents.\n", res.DeletedCount)
+	return nil
+}
+
+func (r *LibraryRepo) lookupGame() mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.games,
+			"localField":   "gameId",
+			"foreignField": "_id",
+			"as":           "game",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$game", "preserveNullAndEmptyArrays": true}}},
+	}
+}
+
+func buildFilter(userId string, filter models.EntryFilter) (bson.M, error) {
+	uid, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	query := bson.M{"userId": uid}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.Platform != "" {
+		query["platform"] = filter.Platform
+	}
+	if filter.Ownership != "" {
+		query["ownership"] = filter.Ownership
+	}
+	return query, nil
+}
+
+func toObjectIds(first, second string) (primitive.ObjectID, primitive.ObjectID, error) {
+	firstOid, err := primitive.ObjectIDFromHex(first)
+	if err != nil {
+		return firstOid, primitive.NilObjectID, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	secondOid, err := primitive.ObjectIDFromHex(second)
+	if err != nil {
+		return firstOid, secondOid, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	return firstOid, secondOid, nil
+}
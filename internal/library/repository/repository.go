@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Alexander272/games-library/internal/library/models"
+	library "github.com/Alexander272/games-library/internal/library/repository/mongo"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ILibrary interface {
+	EnsureIndexes(ctx context.Context) error
+	Create(ctx context.Context, entry models.Entry) (string, error)
+	GetAll(ctx context.Context, userId string, filter models.EntryFilter) ([]models.Entry, int64, error)
+	Export(ctx context.Context, userId string, filter models.EntryFilter, fn func(models.Entry) error) error
+	GetById(ctx context.Context, userId, entryId string) (models.Entry, error)
+	GetByGame(ctx context.Context, userId, gameId string) (models.Entry, error)
+	Update(ctx context.Context, entry models.Entry) error
+	Remove(ctx context.Context, userId, entryId string) error
+}
+
+func NewLibraryRepo(db *mongo.Database, collection, gamesCollection string) ILibrary {
+	return library.NewLibraryRepo(db, collection, gamesCollection)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strconv"
+
+	"github.com/Alexander272/games-library/internal/library/models"
+	"github.com/Alexander272/games-library/pkg/export"
+)
+
+type entryRecord struct {
+	GameSlug  string `json:"gameSlug"`
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	Platform  string `json:"platform"`
+	Ownership string `json:"ownership"`
+	Rating    int    `json:"rating"`
+	Note      string `json:"note"`
+	AddedAt   string `json:"addedAt"`
+}
+
+func newEntryRecord(entry models.Entry) entryRecord {
+	r := entryRecord{
+		Status:    entry.Status,
+		Platform:  entry.Platform,
+		Ownership: entry.Ownership,
+		Rating:    entry.Rating,
+		Note:      entry.Note,
+		AddedAt:   entry.AddedAt.Format("2006-01-02"),
+	}
+	if entry.Game != nil {
+		r.GameSlug = entry.Game.Slug
+		r.Title = entry.Game.Title
+	}
+	return r
+}
+
+func entryHeader() []string {
+	return []string{"gameSlug", "title", "status", "platform", "ownership", "rating", "note", "addedAt"}
+}
+
+func (r entryRecord) Values() []string {
+	rating := ""
+	if r.Rating != 0 {
+		rating = strconv.Itoa(r.Rating)
+	}
+	return []string{r.GameSlug, r.Title, r.Status, r.Platform, r.Ownership, rating, r.Note, r.AddedAt}
+}
+
+// Export writes the user's library entries matching the filter to w. Entries are read from the cursor one by one.
+func (s *LibraryService) Export(ctx context.Context, userId, format string, filter models.EntryFilter, w io.Writer) error {
+	writer, err := export.NewWriter(format, w, entryHeader())
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.Export(ctx, userId, filter, func(entry models.Entry) error {
+		return writer.Write(newEntryRecord(entry))
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
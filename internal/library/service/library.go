@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/internal/library/models"
+	"github.com/Alexander272/games-library/internal/library/repository"
+)
+
+type LibraryService struct {
+	repo  repository.ILibrary
+	games gameRepo.IGame
+}
+
+func NewLibraryService(repo repository.ILibrary, games gameRepo.IGame) *LibraryService {
+	return &LibraryService{
+		repo:  repo,
+		games: games,
+	}
+}
+
+func (s *LibraryService) Add(ctx context.Context, dto models.CreateEntryDTO) (id string, err error) {
+	if _, err := s.games.GetById(ctx, dto.GameId); err != nil {
+		if errors.Is(err, gameModels.ErrGameNotFound) {
+			return id, err
+		}
+		return id, fmt.Errorf("failed to get game by id. error: %w", err)
+	}
+
+	_, err = s.repo.GetByGame(ctx, dto.UserId, dto.GameId)
+	if err == nil {
+		return id, models.ErrEntryExists
+	}
+	if !errors.Is(err, models.ErrEntryNotFound) {
+		return id, fmt.Errorf("failed to get library entry. error: %w", err)
+	}
+
+	entry := models.NewEntry(dto)
+	if entry.Ownership == "" {
+		entry.Ownership = models.OwnershipNone
+	}
+	entry.AddedAt = time.Now()
+	entry.UpdatedAt = entry.AddedAt
+
+	id, err = s.repo.Create(ctx, entry)
+	if err != nil {
+		if errors.Is(err, models.ErrEntryExists) {
+			return id, err
+		}
+		return id, fmt.Errorf("failed to add game to library. error: %w", err)
+	}
+
+	return id, nil
+}
+
+func (s *LibraryService) GetAll(ctx context.Context, userId string, filter models.EntryFilter) (entries []models.Entry, count int64, err error) {
+	entries, count, err = s.repo.GetAll(ctx, userId, filter)
+	if err != nil {
+		return entries, count, fmt.Errorf("failed to get library. error: %w", err)
+	}
+	if len(entries) == 0 {
+		return entries, count, models.ErrEntryNotFound
+	}
+
+	return entries, count, nil
+}
+
+func (s *LibraryService) GetById(ctx context.Context, userId, entryId string) (entry models.Entry, err error) {
+	entry, err = s.repo.GetById(ctx, userId, entryId)
+	if err != nil {
+		if errors.Is(err, models.ErrEntryNotFound) {
+			return entry, err
+		}
+		return entry, fmt.Errorf("failed to get library entry. error: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (s *LibraryService) Update(ctx context.Context, dto models.UpdateEntryDTO) error {
+	entry := models.UpdateEntry(dto)
+	entry.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, entry); err != nil {
+		if errors.Is(err, models.ErrEntryNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to update library entry. error: %w", err)
+	}
+	return nil
+}
+
+func (s *LibraryService) Remove(ctx context.Context, userId, entryId string) error {
+	if err := s.repo.Remove(ctx, userId, entryId); err != nil {
+		if errors.Is(err, models.ErrEntryNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to remove library entry. error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/Alexander272/games-library/internal/library/models"
+)
+
+type ILibrary interface {
+	Add(ctx context.Context, dto models.CreateEntryDTO) (string, error)
+	GetAll(ctx context.Context, userId string, filter models.EntryFilter) ([]models.Entry, int64, error)
+	Export(ctx context.Context, userId, format string, filter models.EntryFilter, w io.Writer) error
+	GetById(ctx context.Context, userId, entryId string) (models.Entry, error)
+	Update(ctx context.Context, dto models.UpdateEntryDTO) error
+	Remove(ctx context.Context, userId, entryId string) error
+}
@@ -0,0 +1,253 @@
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/library/models"
+	"github.com/Alexander272/games-library/internal/service"
+	"github.com/Alexander272/games-library/internal/transport/middleware"
+	"github.com/Alexander272/games-library/internal/transport/validation"
+	"github.com/Alexander272/games-library/pkg/export"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services   *service.Services
+	middleware *middleware.Middleware
+}
+
+func NewHandler(services *service.Services, middleware *middleware.Middleware) *Handler {
+	return &Handler{
+		services:   services,
+		middleware: middleware,
+	}
+}
+
+func (h *Handler) Init(api *gin.RouterGroup) {
+	library := api.Group("/users/me/library", h.middleware.UserIdentity, h.middleware.ObjectIds("id"))
+	{
+		library.GET("/", h.getAll)
+		library.POST("/", h.add)
+		library.GET("/export", h.export)
+		library.GET("/:id", h.getById)
+		library.PATCH("/:id", h.update)
+		library.DELETE("/:id", h.remove)
+	}
+}
+
+// @Summary Get All
+// @Security ApiKeyAuth
+// @Tags library
+// @Description получение библиотеки текущего пользователя
+// @ID getLibrary
+// @Accept json
+// @Produce json
+// @Param filter query models.EntryFilter false "filter"
+// @Success 200 {object} dataResponse{data=[]models.Entry}
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/library [get]
+func (h *Handler) getAll(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var filter models.EntryFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(validation.Query(err))
+		return
+	}
+
+	entries, count, err := h.services.Library.GetAll(c, userId, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: entries, Count: count})
+}
+
+// @Summary Export
+// @Security ApiKeyAuth
+// @Tags library
+// @Description выгрузка библиотеки текущего пользователя в csv или jsonl файл
+// @ID exportLibrary
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "file format (csv, jsonl)" default(csv)
+// @Param filter query models.EntryFilter false "filter"
+// @Success 200 {file} file
+// @Failure 400 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/library/export [get]
+func (h *Handler) export(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var filter models.EntryFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(validation.Query(err))
+		return
+	}
+
+	format := c.DefaultQuery("format", export.CSV)
+	if format != export.CSV && format != export.JSONL {
+		c.Error(export.ErrUnknownFormat)
+		return
+	}
+
+	filename := fmt.Sprintf("library-%s.%s", time.Now().Format("2006-01-02"), format)
+	res := export.NewResponse(c.Writer, format, filename)
+
+	if err := h.services.Library.Export(c, userId, format, filter, res); err != nil {
+		if !res.Started() {
+			c.Error(err)
+			return
+		}
+		// the response is already partially sent, so the error can only be logged
+		logger.Errorf("failed to export library. error: %s", err.Error())
+		c.Abort()
+		return
+	}
+	res.Start()
+}
+
+// @Summary Add
+// @Security ApiKeyAuth
+// @Tags library
+// @Description добавление игры в библиотеку
+// @ID addToLibrary
+// @Accept json
+// @Produce json
+// @Param entry body models.CreateEntryDTO true "entry info"
+// @Success 201 {object} idResponse
+// @Failure 400,404,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/library [post]
+func (h *Handler) add(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto models.CreateEntryDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.UserId = userId
+
+	id, err := h.services.Library.Add(c, dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/api/users/me/library/%s", id))
+	c.JSON(http.StatusCreated, idResponse{Id: id})
+}
+
+// @Summary Get By Id
+// @Security ApiKeyAuth
+// @Tags library
+// @Description получение игры из библиотеки
+// @ID getLibraryEntry
+// @Accept json
+// @Produce json
+// @Param id path string true "entry id"
+// @Success 200 {object} dataResponse{data=models.Entry}
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/library/{id} [get]
+func (h *Handler) getById(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	entry, err := h.services.Library.GetById(c, userId, c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dataResponse{Data: entry})
+}
+
+// @Summary Update
+// @Security ApiKeyAuth
+// @Tags library
+// @Description обновление игры в библиотеке
+// @ID updateLibraryEntry
+// @Accept json
+// @Produce json
+// @Param id path string true "entry id"
+// @Param entry body models.UpdateEntryDTO true "entry info"
+// @Success 200 {object} response
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/library/{id} [patch]
+func (h *Handler) update(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto models.UpdateEntryDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.Id = c.Param("id")
+	dto.UserId = userId
+
+	if err := h.services.Library.Update(c, dto); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response{Message: "Library entry updated"})
+}
+
+// @Summary Remove
+// @Security ApiKeyAuth
+// @Tags library
+// @Description удаление игры из библиотеки
+// @ID removeLibraryEntry
+// @Accept json
+// @Produce json
+// @Param id path string true "entry id"
+// @Success 204 {object} response
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/library/{id} [delete]
+func (h *Handler) remove(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.services.Library.Remove(c, userId, c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, response{Message: "Library entry removed"})
+}
@@ -0,0 +1,30 @@
+package list
+
+import (
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/internal/list/repository"
+	"github.com/Alexander272/games-library/internal/list/service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IListRepo interface {
+	repository.IList
+}
+
+type IListService interface {
+	service.IList
+}
+type IItemService interface {
+	service.IItem
+}
+
+func NewListRepo(db *mongo.Database, collection, gamesCollection string) IListRepo {
+	return repository.NewListRepo(db, collection, gamesCollection)
+}
+
+func NewListService(repo repository.IList) IListService {
+	return service.NewListService(repo)
+}
+func NewItemService(repo repository.IList, games gameRepo.IGame) IItemService {
+	return service.NewItemService(repo, games)
+}
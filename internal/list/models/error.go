@@ -0,0 +1,11 @@
+package models
+
+import "github.com/Alexander272/games-library/pkg/apperror"
+
+var (
+	ErrListNotFound = apperror.NotFound("LIST_NOT_FOUND", "list doesn't exists")
+	ErrItemNotFound = apperror.NotFound("LIST_ITEM_NOT_FOUND", "game isn't in the list")
+	ErrItemExists   = apperror.Conflict("LIST_ITEM_EXISTS", "game is already in the list")
+	ErrInvalidOrder = apperror.Validation("INVALID_LIST_ORDER", "order must contain every game of the list exactly once")
+	ErrListChanged  = apperror.Conflict("LIST_CHANGED", "list was changed by another request, try again")
+)
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+)
+
+const (
+	VisibilityPrivate = "private"
+	// VisibilityUnlisted lists are available to anyone who has the link with the token.
+	VisibilityUnlisted = "unlisted"
+	VisibilityPublic   = "public"
+)
+
+// List is a user-curated ordered list of games. The order of the items is the order of the list.
+type List struct {
+	Id          string    `json:"id" bson:"_id,omitempty"`
+	UserId      string    `json:"userId" bson:"userId"`
+	Title       string    `json:"title" bson:"title"`
+	Description string    `json:"description" bson:"description,omitempty"`
+	Visibility  string    `json:"visibility" bson:"visibility"`
+	Token       string    `json:"token,omitempty" bson:"token,omitempty"`
+	ClonedFrom  string    `json:"clonedFrom,omitempty" bson:"clonedFrom,omitempty"`
+	Items       []Item    `json:"items" bson:"items"`
+	CreatedAt   time.Time `json:"createdAt" bson:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt" bson:"updatedAt"`
+}
+
+type Item struct {
+	GameId  string           `json:"gameId" bson:"gameId"`
+	Game    *gameModels.Game `json:"game,omitempty" bson:"-"`
+	Note    string           `json:"note" bson:"note,omitempty"`
+	AddedAt time.Time        `json:"addedAt" bson:"addedAt"`
+}
+
+type ListFilter struct {
+	Limit int64 `form:"limit" binding:"omitempty,min=1,max=100"`
+	Skip  int64 `form:"skip" binding:"omitempty,min=0"`
+}
+
+func NewList(dto CreateListDTO) List {
+	return List{
+		UserId:      dto.UserId,
+		Title:       dto.Title,
+		Description: dto.Description,
+		Visibility:  dto.Visibility,
+		Items:       []Item{},
+	}
+}
+
+type CreateListDTO struct {
+	UserId      string `json:"-"`
+	Title       string `json:"title" binding:"required,min=1,max=128"`
+	Description string `json:"description" binding:"max=2048"`
+	Visibility  string `json:"visibility" binding:"omitempty,oneof=private unlisted public"`
+}
+
+func UpdateList(dto UpdateListDTO) List {
+	return List{
+		Id:          dto.Id,
+		UserId:      dto.UserId,
+		Title:       dto.Title,
+		Description: dto.Description,
+		Visibility:  dto.Visibility,
+	}
+}
+
+type UpdateListDTO struct {
+	Id          string `json:"-"`
+	UserId      string `json:"-"`
+	Title       string `json:"title" binding:"max=128"`
+	Description string `json:"description" binding:"max=2048"`
+	Visibility  string `json:"visibility" binding:"omitempty,oneof=private unlisted public"`
+}
+
+// ItemDTO adds a game to the list. Without the position the game is added to the end.
+type ItemDTO struct {
+	ListId   string `json:"-"`
+	UserId   string `json:"-"`
+	GameId   string `json:"gameId" binding:"required,objectid"`
+	Note     string `json:"note" binding:"max=1024"`
+	Position *int   `json:"position" binding:"omitempty,min=0"`
+}
+
+type UpdateItemDTO struct {
+	ListId string `json:"-"`
+	UserId string `json:"-"`
+	GameId string `json:"-"`
+	Note   string `json:"note" binding:"max=1024"`
+}
+
+// OrderDTO sets the new order of the whole list.
+type OrderDTO struct {
+	GameIds []string `json:"gameIds" binding:"required"`
+}
+
+// MoveDTO moves a single game to the position, other games are shifted.
+type MoveDTO struct {
+	Position int `json:"position" binding:"min=0"`
+}
@@ -0,0 +1,326 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/internal/list/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ListRepo struct {
+	db    *mongo.Collection
+	games string
+}
+
+func NewListRepo(db *mongo.Database, collection, gamesCollection string) *ListRepo {
+	return &ListRepo{
+		db:    db.Collection(collection),
+		games: gamesCollection,
+	}
+}
+
+// listDoc is the list joined with the games of its items.
+type listDoc struct {
+	models.List `bson:",inline"`
+	Games       []gameModels.Game `bson:"games"`
+}
+
+func (r *ListRepo) Create(ctx context.Context, list models.List) (id string, err error) {
+	uid, err := primitive.ObjectIDFromHex(list.UserId)
+	if err != nil {
+		return id, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	items, err := toItems(list.Items)
+	if err != nil {
+		return id, err
+	}
+
+	doc := bson.M{
+		"userId":      uid,
+		"title":       list.Title,
+		"description": list.Description,
+		"visibility":  list.Visibility,
+		"items":       items,
+		"createdAt":   list.CreatedAt,
+		"updatedAt":   list.UpdatedAt,
+	}
+	if list.Token != "" {
+		doc["token"] = list.Token
+	}
+	if list.ClonedFrom != "" {
+		clonedFrom, err := primitive.ObjectIDFromHex(list.ClonedFrom)
+		if err != nil {
+			return id, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+		}
+		doc["clonedFrom"] = clonedFrom
+	}
+
+	res, err := r.db.InsertOne(ctx, doc)
+	if err != nil {
+		return id, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	oid, ok := res.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return id, fmt.Errorf("failed to convert objectid")
+	}
+	logger.Tracef("Created document with oid %s.\n", oid)
+	return oid.Hex(), nil
+}
+
+// GetByUser returns all lists of the user without the games data.
+func (r *ListRepo) GetByUser(ctx context.Context, userId string, filter models.ListFilter) (lists []models.List, count int64, err error) {
+	uid, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return lists, count, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	return r.find(ctx, bson.M{"userId": uid}, filter)
+}
+
+// GetPublic returns lists available to everyone without the games data.
+func (r *ListRepo) GetPublic(ctx context.Context, filter models.ListFilter) (lists []models.List, count int64, err error) {
+	return r.find(ctx, bson.M{"visibility": models.VisibilityPublic}, filter)
+}
+
+func (r *ListRepo) GetById(ctx context.Context, listId string) (list models.List, err error) {
+	oid, err := primitive.ObjectIDFromHex(listId)
+	if err != nil {
+		return list, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	return r.get(ctx, bson.M{"_id": oid})
+}
+
+func (r *ListRepo) GetByToken(ctx context.Context, token string) (list models.List, err error) {
+	return r.get(ctx, bson.M{"token": token})
+}
+
+func (r *ListRepo) Update(ctx context.Context, list models.List) error {
+	uid, oid, err := toObjectIds(list.UserId, list.Id)
+	if err != nil {
+		return err
+	}
+
+	updateObj := bson.M{"updatedAt": list.UpdatedAt}
+	if list.Title != "" {
+		updateObj["title"] = list.Title
+	}
+	if list.Description != "" {
+		updateObj["description"] = list.Description
+	}
+	if list.Visibility != "" {
+		updateObj["visibility"] = list.Visibility
+	}
+	if list.Token != "" {
+		updateObj["token"] = list.Token
+	}
+
+	return r.update(ctx, bson.M{"_id": oid, "userId": uid}, bson.M{"$set": updateObj}, models.ErrListNotFound)
+}
+
+func (r *ListRepo) Remove(ctx context.Context, userId, listId string) error {
+	uid, oid, err := toObjectIds(userId, listId)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.db.DeleteOne(ctx, bson.M{"_id": oid, "userId": uid})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return models.ErrListNotFound
+	}
+
+	logger.Tracef("Delete %v documents.\n", res.DeletedCount)
+	return nil
+}
+
+// AddItem inserts the game at the position. Negative position means the end of the list.
+func (r *ListRepo) AddItem(ctx context.Context, userId, listId string, item models.Item, position int) error {
+	uid, oid, err := toObjectIds(userId, listId)
+	if err != nil {
+		return err
+	}
+	items, err := toItems([]models.Item{item})
+	if err != nil {
+		return err
+	}
+
+	push := bson.M{"$each": items}
+	if position >= 0 {
+		push["$position"] = position
+	}
+
+	filter := bson.M{"_id": oid, "userId": uid, "items.gameId": bson.M{"$ne": items[0]["gameId"]}}
+	update := bson.M{
+		"$push": bson.M{"items": push},
+		"$set":  bson.M{"updatedAt": item.AddedAt},
+	}
+	return r.update(ctx, filter, update, models.ErrItemExists)
+}
+
+func (r *ListRepo) UpdateItem(ctx context.Context, userId, listId, gameId, note string, updatedAt time.Time) error {
+	uid, oid, err := toObjectIds(userId, listId)
+	if err != nil {
+		return err
+	}
+	gid, err := primitive.ObjectIDFromHex(gameId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	filter := bson.M{"_id": oid, "userId": uid, "items.gameId": gid}
+	update := bson.M{"$set": bson.M{"items.$.note": note, "updatedAt": updatedAt}}
+	return r.update(ctx, filter, update, models.ErrItemNotFound)
+}
+
+func (r *ListRepo) RemoveItem(ctx context.Context, userId, listId, gameId string, updatedAt time.Time) error {
+	uid, oid, err := toObjectIds(userId, listId)
+	if err != nil {
+		return err
+	}
+	gid, err := primitive.ObjectIDFromHex(gameId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	filter := bson.M{"_id": oid, "userId": uid, "items.gameId": gid}
+	update := bson.M{
+		"$pull": bson.M{"items": bson.M{"gameId": gid}},
+		"$set":  bson.M{"updatedAt": updatedAt},
+	}
+	return r.update(ctx, filter, update, models.ErrItemNotFound)
+}
+
+// SetItems replaces the items of the list. It is used to change the order. The update is applied
+// only if the list wasn't changed since it was read, so concurrent changes aren't lost.
+func (r *ListRepo) SetItems(ctx context.Context, list models.List, updatedAt time.Time) error {
+	uid, oid, err := toObjectIds(list.UserId, list.Id)
+	if err != nil {
+		return err
+	}
+	items, err := toItems(list.Items)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"_id": oid, "userId": uid, "updatedAt": list.UpdatedAt}
+	update := bson.M{"$set": bson.M{"items": items, "updatedAt": updatedAt}}
+	return r.update(ctx, filter, update, models.ErrListChanged)
+}
+
+func (r *ListRepo) find(ctx context.Context, filter bson.M, listFilter models.ListFilter) (lists []models.List, count int64, err error) {
+	opts := options.Find().SetSort(bson.D{{Key: "updatedAt", Value: -1}})
+	if listFilter.Limit > 0 {
+		opts.SetLimit(listFilter.Limit)
+	}
+	if listFilter.Skip > 0 {
+		opts.SetSkip(listFilter.Skip)
+	}
+
+	cur, err := r.db.Find(ctx, filter, opts)
+	if err != nil {
+		return lists, count, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &lists); err != nil {
+		return lists, count, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	count, err = r.db.CountDocuments(ctx, filter)
+	if err != nil {
+		return lists, count, fmt.Errorf("failed to count documents. error: %w", err)
+	}
+
+	return lists, count, nil
+}
+
+func (r *ListRepo) get(ctx context.Context, match bson.M) (list models.List, err error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         r.games,
+			"localField":   "items.gameId",
+			"foreignField": "_id",
+			"as":           "games",
+		}}},
+	}
+
+	cur, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return list, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	if !cur.Next(ctx) {
+		if cur.Err() != nil {
+			return list, fmt.Errorf("failed to execute query. error: %w", cur.Err())
+		}
+		return list, models.ErrListNotFound
+	}
+
+	var doc listDoc
+	if err := cur.Decode(&doc); err != nil {
+		return list, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	games := make(map[string]gameModels.Game, len(doc.Games))
+	for _, g := range doc.Games {
+		games[g.Id] = g
+	}
+	list = doc.List
+	for i := range list.Items {
+		if g, ok := games[list.Items[i].GameId]; ok {
+			list.Items[i].Game = &g
+		}
+	}
+
+	return list, nil
+}
+
+func (r *ListRepo) update(ctx context.Context, filter, update bson.M, notMatched error) error {
+	res, err := r.db.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return notMatched
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+func toItems(items []models.Item) ([]bson.M, error) {
+	docs := make([]bson.M, 0, len(items))
+	for _, item := range items {
+		gid, err := primitive.ObjectIDFromHex(item.GameId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+		}
+		doc := bson.M{"gameId": gid, "addedAt": item.AddedAt}
+		if item.Note != "" {
+			doc["note"] = item.Note
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+func toObjectIds(first, second string) (primitive.ObjectID, primitive.ObjectID, error) {
+	firstOid, err := primitive.ObjectIDFromHex(first)
+	if err != nil {
+		return firstOid, primitive.NilObjectID, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	secondOid, err := primitive.ObjectIDFromHex(second)
+	if err != nil {
+		return firstOid, secondOid, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	return firstOid, secondOid, nil
+}
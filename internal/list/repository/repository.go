@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/list/models"
+	list "github.com/Alexander272/games-library/internal/list/repository/mongo"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IList interface {
+	Create(ctx context.Context, list models.List) (string, error)
+	GetByUser(ctx context.Context, userId string, filter models.ListFilter) ([]models.List, int64, error)
+	GetPublic(ctx context.Context, filter models.ListFilter) ([]models.List, int64, error)
+	GetById(ctx context.Context, listId string) (models.List, error)
+	GetByToken(ctx context.Context, token string) (models.List, error)
+	Update(ctx context.Context, list models.List) error
+	Remove(ctx context.Context, userId, listId string) error
+	AddItem(ctx context.Context, userId, listId string, item models.Item, position int) error
+	UpdateItem(ctx context.Context, userId, listId, gameId, note string, updatedAt time.Time) error
+	RemoveItem(ctx context.Context, userId, listId, gameId string, updatedAt time.Time) error
+	SetItems(ctx context.Context, list models.List, updatedAt time.Time) error
+}
+
+func NewListRepo(db *mongo.Database, collection, gamesCollection string) IList {
+	return list.NewListRepo(db, collection, gamesCollection)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/internal/list/models"
+	"github.com/Alexander272/games-library/internal/list/repository"
+)
+
+type ItemService struct {
+	repo  repository.IList
+	games gameRepo.IGame
+}
+
+func NewItemService(repo repository.IList, games gameRepo.IGame) *ItemService {
+	return &ItemService{
+		repo:  repo,
+		games: games,
+	}
+}
+
+func (s *ItemService) Add(ctx context.Context, dto models.ItemDTO) error {
+	if _, err := getOwn(ctx, s.repo, dto.UserId, dto.ListId); err != nil {
+		return err
+	}
+	if _, err := s.games.GetById(ctx, dto.GameId); err != nil {
+		if errors.Is(err, gameModels.ErrGameNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to get game by id. error: %w", err)
+	}
+
+	position := -1
+	if dto.Position != nil {
+		position = *dto.Position
+	}
+	item := models.Item{GameId: dto.GameId, Note: dto.Note, AddedAt: time.Now()}
+
+	if err := s.repo.AddItem(ctx, dto.UserId, dto.ListId, item, position); err != nil {
+		if errors.Is(err, models.ErrItemExists) {
+			return err
+		}
+		return fmt.Errorf("failed to add game to list. error: %w", err)
+	}
+	return nil
+}
+
+func (s *ItemService) Update(ctx context.Context, dto models.UpdateItemDTO) error {
+	if err := s.repo.UpdateItem(ctx, dto.UserId, dto.ListId, dto.GameId, dto.Note, time.Now()); err != nil {
+		if errors.Is(err, models.ErrItemNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to update list item. error: %w", err)
+	}
+	return nil
+}
+
+func (s *ItemService) Remove(ctx context.Context, userId, listId, gameId string) error {
+	if err := s.repo.RemoveItem(ctx, userId, listId, gameId, time.Now()); err != nil {
+		if errors.Is(err, models.ErrItemNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to remove game from list. error: %w", err)
+	}
+	return nil
+}
+
+// Reorder sets the order of the whole list. The new order must contain every game of the list.
+func (s *ItemService) Reorder(ctx context.Context, userId, listId string, dto models.OrderDTO) error {
+	list, err := getOwn(ctx, s.repo, userId, listId)
+	if err != nil {
+		return err
+	}
+	if len(dto.GameIds) != len(list.Items) {
+		return models.ErrInvalidOrder
+	}
+
+	items := make(map[string]models.Item, len(list.Items))
+	for _, item := range list.Items {
+		items[item.GameId] = item
+	}
+
+	ordered := make([]models.Item, 0, len(dto.GameIds))
+	for _, id := range dto.GameIds {
+		item, ok := items[id]
+		if !ok {
+			return models.ErrInvalidOrder
+		}
+		delete(items, id)
+		ordered = append(ordered, item)
+	}
+	list.Items = ordered
+
+	return s.setItems(ctx, list)
+}
+
+// Move moves one game to the position. Position after the end of the list moves the game to the end.
+func (s *ItemService) Move(ctx context.Context, userId, listId, gameId string, dto models.MoveDTO) error {
+	list, err := getOwn(ctx, s.repo, userId, listId)
+	if err != nil {
+		return err
+	}
+
+	from := -1
+	for i, item := range list.Items {
+		if item.GameId == gameId {
+			from = i
+			break
+		}
+	}
+	if from == -1 {
+		return models.ErrItemNotFound
+	}
+
+	item := list.Items[from]
+	items := append(list.Items[:from:from], list.Items[from+1:]...)
+	to := dto.Position
+	if to > len(items) {
+		to = len(items)
+	}
+	items = append(items[:to], append([]models.Item{item}, items[to:]...)...)
+	list.Items = items
+
+	return s.setItems(ctx, list)
+}
+
+func (s *ItemService) setItems(ctx context.Context, list models.List) error {
+	if err := s.repo.SetItems(ctx, list, time.Now()); err != nil {
+		if errors.Is(err, models.ErrListChanged) {
+			return err
+		}
+		return fmt.Errorf("failed to reorder list. error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/list/models"
+	"github.com/Alexander272/games-library/internal/list/repository"
+)
+
+// tokenLength is the number of random bytes in the link of an unlisted list.
+const tokenLength = 16
+
+type ListService struct {
+	repo repository.IList
+}
+
+func NewListService(repo repository.IList) *ListService {
+	return &ListService{
+		repo: repo,
+	}
+}
+
+func (s *ListService) Create(ctx context.Context, dto models.CreateListDTO) (id string, err error) {
+	list := models.NewList(dto)
+	if list.Visibility == "" {
+		list.Visibility = models.VisibilityPrivate
+	}
+	if list.Visibility == models.VisibilityUnlisted {
+		if list.Token, err = newToken(); err != nil {
+			return id, err
+		}
+	}
+	list.CreatedAt = time.Now()
+	list.UpdatedAt = list.CreatedAt
+
+	id, err = s.repo.Create(ctx, list)
+	if err != nil {
+		return id, fmt.Errorf("failed to create list. error: %w", err)
+	}
+	return id, nil
+}
+
+func (s *ListService) GetByUser(ctx context.Context, userId string, filter models.ListFilter) (lists []models.List, count int64, err error) {
+	lists, count, err = s.repo.GetByUser(ctx, userId, filter)
+	if err != nil {
+		return lists, count, fmt.Errorf("failed to get lists. error: %w", err)
+	}
+	if len(lists) == 0 {
+		return lists, count, models.ErrListNotFound
+	}
+	return lists, count, nil
+}
+
+func (s *ListService) GetPublic(ctx context.Context, filter models.ListFilter) (lists []models.List, count int64, err error) {
+	lists, count, err = s.repo.GetPublic(ctx, filter)
+	if err != nil {
+		return lists, count, fmt.Errorf("failed to get public lists. error: %w", err)
+	}
+	if len(lists) == 0 {
+		return lists, count, models.ErrListNotFound
+	}
+
+	for i := range lists {
+		lists[i].Token = ""
+	}
+	return lists, count, nil
+}
+
+// GetById returns the list if it is public or belongs to the user. Unlisted lists of other users
+// are available only by the token.
+func (s *ListService) GetById(ctx context.Context, userId, listId string) (list models.List, err error) {
+	list, err = s.repo.GetById(ctx, listId)
+	if err != nil {
+		if errors.Is(err, models.ErrListNotFound) {
+			return list, err
+		}
+		return list, fmt.Errorf("failed to get list. error: %w", err)
+	}
+
+	if list.UserId == userId {
+		return list, nil
+	}
+	if list.Visibility != models.VisibilityPublic {
+		return models.List{}, models.ErrListNotFound
+	}
+	list.Token = ""
+	return list, nil
+}
+
+func (s *ListService) GetByToken(ctx context.Context, token string) (list models.List, err error) {
+	list, err = s.repo.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, models.ErrListNotFound) {
+			return list, err
+		}
+		return list, fmt.Errorf("failed to get list. error: %w", err)
+	}
+
+	// the token stays after the list is made private, but the link doesn't work anymore
+	if list.Visibility == models.VisibilityPrivate {
+		return models.List{}, models.ErrListNotFound
+	}
+	list.Token = ""
+	return list, nil
+}
+
+func (s *ListService) Update(ctx context.Context, dto models.UpdateListDTO) error {
+	list, err := getOwn(ctx, s.repo, dto.UserId, dto.Id)
+	if err != nil {
+		return err
+	}
+
+	update := models.UpdateList(dto)
+	if update.Visibility == models.VisibilityUnlisted && list.Token == "" {
+		if update.Token, err = newToken(); err != nil {
+			return err
+		}
+	}
+	update.UpdatedAt = time.Now()
+
+	if err := s.repo.Update(ctx, update); err != nil {
+		if errors.Is(err, models.ErrListNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to update list. error: %w", err)
+	}
+	return nil
+}
+
+func (s *ListService) Remove(ctx context.Context, userId, listId string) error {
+	if err := s.repo.Remove(ctx, userId, listId); err != nil {
+		if errors.Is(err, models.ErrListNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to remove list. error: %w", err)
+	}
+	return nil
+}
+
+// Clone copies a public list (or the user's own list) with all items and notes to the user's private list.
+func (s *ListService) Clone(ctx context.Context, userId, listId string) (id string, err error) {
+	source, err := s.GetById(ctx, userId, listId)
+	if err != nil {
+		return id, err
+	}
+
+	now := time.Now()
+	items := make([]models.Item, 0, len(source.Items))
+	for _, item := range source.Items {
+		items = append(items, models.Item{GameId: item.GameId, Note: item.Note, AddedAt: now})
+	}
+
+	list := models.List{
+		UserId:      userId,
+		Title:       source.Title,
+		Description: source.Description,
+		Visibility:  models.VisibilityPrivate,
+		ClonedFrom:  source.Id,
+		Items:       items,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	id, err = s.repo.Create(ctx, list)
+	if err != nil {
+		return id, fmt.Errorf("failed to clone list. error: %w", err)
+	}
+	return id, nil
+}
+
+// getOwn returns the list only if it belongs to the user.
+func getOwn(ctx context.Context, repo repository.IList, userId, listId string) (list models.List, err error) {
+	list, err = repo.GetById(ctx, listId)
+	if err != nil {
+		if errors.Is(err, models.ErrListNotFound) {
+			return list, err
+		}
+		return list, fmt.Errorf("failed to get list. error: %w", err)
+	}
+	if list.UserId != userId {
+		return models.List{}, models.ErrListNotFound
+	}
+	return list, nil
+}
+
+func newToken() (string, error) {
+	b := make([]byte, tokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate list token. error: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
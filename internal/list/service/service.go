@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Alexander272/games-library/internal/list/models"
+)
+
+type IList interface {
+	Create(ctx context.Context, dto models.CreateListDTO) (string, error)
+	GetByUser(ctx context.Context, userId string, filter models.ListFilter) ([]models.List, int64, error)
+	GetPublic(ctx context.Context, filter models.ListFilter) ([]models.List, int64, error)
+	GetById(ctx context.Context, userId, listId string) (models.List, error)
+	GetByToken(ctx context.Context, token string) (models.List, error)
+	Update(ctx context.Context, dto models.UpdateListDTO) error
+	Remove(ctx context.Context, userId, listId string) error
+	Clone(ctx context.Context, userId, listId string) (string, error)
+}
+
+type IItem interface {
+	Add(ctx context.Context, dto models.ItemDTO) error
+	Update(ctx context.Context, dto models.UpdateItemDTO) error
+	Remove(ctx context.Context, userId, listId, gameId string) error
+	Reorder(ctx context.Context, userId, listId string, dto models.OrderDTO) error
+	Move(ctx context.Context, userId, listId, gameId string, dto models.MoveDTO) error
+}
@@ -0,0 +1,456 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/Alexander272/games-library/internal/list/models"
+	"github.com/Alexander272/games-library/internal/service"
+	"github.com/Alexander272/games-library/internal/transport/middleware"
+	"github.com/Alexander272/games-library/internal/transport/validation"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services   *service.Services
+	middleware *middleware.Middleware
+}
+
+func NewHandler(services *service.Services, middleware *middleware.Middleware) *Handler {
+	return &Handler{
+		services:   services,
+		middleware: middleware,
+	}
+}
+
+func (h *Handler) Init(api *gin.RouterGroup) {
+	lists := api.Group("/lists", h.middleware.ObjectIds("id"))
+	{
+		lists.GET("/", h.getPublic)
+		lists.GET("/shared/:token", h.getByToken)
+		lists.GET("/:id", h.middleware.OptionalIdentity, h.getById)
+		lists.POST("/:id/clone", h.middleware.UserIdentity, h.clone)
+	}
+
+	my := api.Group("/users/me/lists", h.middleware.UserIdentity, h.middleware.ObjectIds("id", "gameId"))
+	{
+		my.GET("/", h.getByUser)
+		my.POST("/", h.create)
+		my.PATCH("/:id", h.update)
+		my.DELETE("/:id", h.remove)
+
+		items := my.Group("/:id/items")
+		{
+			items.POST("/", h.addItem)
+			items.PUT("/order", h.reorder)
+			items.PATCH("/:gameId", h.updateItem)
+			items.POST("/:gameId/move", h.moveItem)
+			items.DELETE("/:gameId", h.removeItem)
+		}
+	}
+}
+
+// @Summary Get Public
+// @Tags lists
+// @Description получение публичных списков игр
+// @ID getPublicLists
+// @Accept json
+// @Produce json
+// @Param filter query models.ListFilter false "filter"
+// @Success 200 {object} dataResponse{data=[]models.List}
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /lists [get]
+func (h *Handler) getPublic(c *gin.Context) {
+	var filter models.ListFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(validation.Query(err))
+		return
+	}
+
+	lists, count, err := h.services.List.GetPublic(c, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: lists, Count: count})
+}
+
+// @Summary Get By Token
+// @Tags lists
+// @Description получение списка по ссылке. Работает для списков, доступных по ссылке, и публичных списков
+// @ID getListByToken
+// @Accept json
+// @Produce json
+// @Param token path string true "list token"
+// @Success 200 {object} dataResponse{data=models.List}
+// @Failure 404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /lists/shared/{token} [get]
+func (h *Handler) getByToken(c *gin.Context) {
+	list, err := h.services.List.GetByToken(c, c.Param("token"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: list})
+}
+
+// @Summary Get By Id
+// @Tags lists
+// @Description получение списка. Приватные списки и списки по ссылке доступны только владельцу
+// @ID getListById
+// @Accept json
+// @Produce json
+// @Param id path string true "list id"
+// @Success 200 {object} dataResponse{data=models.List}
+// @Failure 404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /lists/{id} [get]
+func (h *Handler) getById(c *gin.Context) {
+	userId, _ := middleware.GetUserId(c)
+
+	list, err := h.services.List.GetById(c, userId, c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: list})
+}
+
+// @Summary Clone
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description копирование публичного списка в приватный список пользователя
+// @ID cloneList
+// @Accept json
+// @Produce json
+// @Param id path string true "list id"
+// @Success 201 {object} idResponse
+// @Failure 401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /lists/{id}/clone [post]
+func (h *Handler) clone(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	id, err := h.services.List.Clone(c, userId, c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, idResponse{Id: id})
+}
+
+// @Summary Get My
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description получение списков пользователя
+// @ID getMyLists
+// @Accept json
+// @Produce json
+// @Param filter query models.ListFilter false "filter"
+// @Success 200 {object} dataResponse{data=[]models.List}
+// @Failure 400,401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/lists [get]
+func (h *Handler) getByUser(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var filter models.ListFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(validation.Query(err))
+		return
+	}
+
+	lists, count, err := h.services.List.GetByUser(c, userId, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: lists, Count: count})
+}
+
+// @Summary Create
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description создание списка игр. По умолчанию список приватный
+// @ID createList
+// @Accept json
+// @Produce json
+// @Param list body models.CreateListDTO true "list info"
+// @Success 201 {object} idResponse
+// @Failure 400,401 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/lists [post]
+func (h *Handler) create(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto models.CreateListDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.UserId = userId
+
+	id, err := h.services.List.Create(c, dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, idResponse{Id: id})
+}
+
+// @Summary Update
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description обновление названия, описания или видимости списка
+// @ID updateList
+// @Accept json
+// @Produce json
+// @Param id path string true "list id"
+// @Param list body models.UpdateListDTO true "list info"
+// @Success 200 {object} response
+// @Failure 400,401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/lists/{id} [patch]
+func (h *Handler) update(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto models.UpdateListDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.Id = c.Param("id")
+	dto.UserId = userId
+
+	if err := h.services.List.Update(c, dto); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response{Message: "List updated"})
+}
+
+// @Summary Remove
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description удаление списка
+// @ID removeList
+// @Accept json
+// @Produce json
+// @Param id path string true "list id"
+// @Success 204 {object} response
+// @Failure 401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/lists/{id} [delete]
+func (h *Handler) remove(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.services.List.Remove(c, userId, c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusNoContent, response{Message: "List removed"})
+}
+
+// @Summary Add Item
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description добавление игры в список. Без позиции игра добавляется в конец списка
+// @ID addListItem
+// @Accept json
+// @Produce json
+// @Param id path string true "list id"
+// @Param item body models.ItemDTO true "item info"
+// @Success 201 {object} response
+// @Failure 400,401,404,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/lists/{id}/items [post]
+func (h *Handler) addItem(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto models.ItemDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.ListId = c.Param("id")
+	dto.UserId = userId
+
+	if err := h.services.ListItem.Add(c, dto); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, response{Message: "Game added to list"})
+}
+
+// @Summary Reorder
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description изменение порядка игр в списке. Нужно передать все игры списка в новом порядке
+// @ID reorderList
+// @Accept json
+// @Produce json
+// @Param id path string true "list id"
+// @Param order body models.OrderDTO true "new order"
+// @Success 200 {object} response
+// @Failure 400,401,404,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/lists/{id}/items/order [put]
+func (h *Handler) reorder(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto models.OrderDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+
+	if err := h.services.ListItem.Reorder(c, userId, c.Param("id"), dto); err != nil {
+		h.orderError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response{Message: "List reordered"})
+}
+
+// @Summary Update Item
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description изменение заметки к игре в списке
+// @ID updateListItem
+// @Accept json
+// @Produce json
+// @Param id path string true "list id"
+// @Param gameId path string true "game id"
+// @Param item body models.UpdateItemDTO true "item info"
+// @Success 200 {object} response
+// @Failure 400,401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/lists/{id}/items/{gameId} [patch]
+func (h *Handler) updateItem(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto models.UpdateItemDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.ListId = c.Param("id")
+	dto.UserId = userId
+	dto.GameId = c.Param("gameId")
+
+	if err := h.services.ListItem.Update(c, dto); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response{Message: "List item updated"})
+}
+
+// @Summary Move Item
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description перемещение игры на позицию в списке. Остальные игры сдвигаются
+// @ID moveListItem
+// @Accept json
+// @Produce json
+// @Param id path string true "list id"
+// @Param gameId path string true "game id"
+// @Param move body models.MoveDTO true "new position"
+// @Success 200 {object} response
+// @Failure 400,401,404,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/lists/{id}/items/{gameId}/move [post]
+func (h *Handler) moveItem(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto models.MoveDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+
+	if err := h.services.ListItem.Move(c, userId, c.Param("id"), c.Param("gameId"), dto); err != nil {
+		h.orderError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response{Message: "List item moved"})
+}
+
+// @Summary Remove Item
+// @Security ApiKeyAuth
+// @Tags lists
+// @Description удаление игры из списка
+// @ID removeListItem
+// @Accept json
+// @Produce json
+// @Param id path string true "list id"
+// @Param gameId path string true "game id"
+// @Success 204 {object} response
+// @Failure 401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/lists/{id}/items/{gameId} [delete]
+func (h *Handler) removeItem(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.services.ListItem.Remove(c, userId, c.Param("id"), c.Param("gameId")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusNoContent, response{Message: "Game removed from list"})
+}
+
+func (h *Handler) orderError(c *gin.Context, err error) {
+	c.Error(err)
+}
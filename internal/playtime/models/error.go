@@ -0,0 +1,8 @@
+package models
+
+import "github.com/Alexander272/games-library/pkg/apperror"
+
+var (
+	ErrSessionNotFound = apperror.NotFound("PLAY_SESSION_NOT_FOUND", "play session doesn't exists")
+	ErrInvalidPeriod   = apperror.Validation("INVALID_SESSION_PERIOD", "end of the session must be after the start")
+)
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// maxSession is the longest play session that can be logged.
+const maxSession = 24 * time.Hour
+
+type PlaySession struct {
+	Id       string    `json:"id" bson:"_id,omitempty"`
+	UserId   string    `json:"userId" bson:"userId"`
+	GameId   string    `json:"gameId" bson:"gameId"`
+	Start    time.Time `json:"start" bson:"start"`
+	End      time.Time `json:"end" bson:"end"`
+	Minutes  int       `json:"minutes" bson:"minutes"`
+	Platform string    `json:"platform" bson:"platform,omitempty"`
+	Note     string    `json:"note" bson:"note,omitempty"`
+}
+
+type SessionFilter struct {
+	GameId string    `form:"gameId"`
+	From   time.Time `form:"from" time_format:"2006-01-02"`
+	To     time.Time `form:"to" time_format:"2006-01-02"`
+	Limit  int64     `form:"limit" binding:"omitempty,min=1,max=100"`
+	Skip   int64     `form:"skip" binding:"omitempty,min=0"`
+}
+
+// SessionDTO describes the session either by start and end or by start and duration in minutes.
+type SessionDTO struct {
+	Id       string    `json:"-"`
+	UserId   string    `json:"-"`
+	GameId   string    `json:"gameId" binding:"required,objectid"`
+	Start    time.Time `json:"start" binding:"required"`
+	End      time.Time `json:"end"`
+	Minutes  int       `json:"minutes" binding:"omitempty,min=1,max=1440"`
+	Platform string    `json:"platform" binding:"max=64"`
+	Note     string    `json:"note" binding:"max=1024"`
+}
+
+// NewSession calculates the missing end or duration of the session.
+func NewSession(dto SessionDTO) (PlaySession, error) {
+	session := PlaySession{
+		Id:       dto.Id,
+		UserId:   dto.UserId,
+		GameId:   dto.GameId,
+		Start:    dto.Start,
+		End:      dto.End,
+		Minutes:  dto.Minutes,
+		Platform: dto.Platform,
+		Note:     dto.Note,
+	}
+
+	if session.End.IsZero() {
+		if session.Minutes == 0 {
+			return session, ErrInvalidPeriod
+		}
+		session.End = session.Start.Add(time.Duration(session.Minutes) * time.Minute)
+		return session, nil
+	}
+
+	duration := session.End.Sub(session.Start)
+	if duration <= 0 || duration > maxSession {
+		return session, ErrInvalidPeriod
+	}
+	session.Minutes = int(duration.Minutes())
+	return session, nil
+}
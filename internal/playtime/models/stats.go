@@ -0,0 +1,38 @@
+package models
+
+type Playtime struct {
+	Minutes  int `json:"minutes" bson:"minutes"`
+	Sessions int `json:"sessions" bson:"sessions"`
+}
+
+type GamePlaytime struct {
+	GameId   string `json:"gameId" bson:"_id"`
+	Title    string `json:"title" bson:"title"`
+	Minutes  int    `json:"minutes" bson:"minutes"`
+	Sessions int    `json:"sessions" bson:"sessions"`
+}
+
+type DayPlaytime struct {
+	Day     string `json:"day" bson:"_id"`
+	Minutes int    `json:"minutes" bson:"minutes"`
+}
+
+// UserStats is the statistics of the user's play sessions.
+type UserStats struct {
+	Total         Playtime       `json:"total"`
+	Week          Playtime       `json:"week"`
+	Month         Playtime       `json:"month"`
+	CurrentStreak int            `json:"currentStreak"`
+	LongestStreak int            `json:"longestStreak"`
+	MostPlayed    []GamePlaytime `json:"mostPlayed"`
+}
+
+// Dashboard is the statistics of all users for the admin panel.
+type Dashboard struct {
+	Total       Playtime       `json:"total"`
+	Week        Playtime       `json:"week"`
+	Month       Playtime       `json:"month"`
+	ActiveUsers int            `json:"activeUsers"`
+	MostPlayed  []GamePlaytime `json:"mostPlayed"`
+	Daily       []DayPlaytime  `json:"daily"`
+}
@@ -0,0 +1,30 @@
+package playtime
+
+import (
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/internal/playtime/repository"
+	"github.com/Alexander272/games-library/internal/playtime/service"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ISessionRepo interface {
+	repository.ISession
+}
+
+type ISessionService interface {
+	service.ISession
+}
+type IStatsService interface {
+	service.IStats
+}
+
+func NewSessionRepo(db *mongo.Database, collection, gamesCollection string) ISessionRepo {
+	return repository.NewSessionRepo(db, collection, gamesCollection)
+}
+
+func NewSessionService(repo repository.ISession, games gameRepo.IGame) ISessionService {
+	return service.NewSessionService(repo, games)
+}
+func NewStatsService(repo repository.ISession) IStatsService {
+	return service.NewStatsService(repo)
+}
@@ -0,0 +1,316 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/playtime/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	mostPlayedLimit = 5
+	dayFormat       = "%Y-%m-%d"
+)
+
+type SessionRepo struct {
+	db    *mongo.Collection
+	games string
+}
+
+func NewSessionRepo(db *mongo.Database, collection, gamesCollection string) *SessionRepo {
+	return &SessionRepo{
+		db:    db.Collection(collection),
+		games: gamesCollection,
+	}
+}
+
+func (r *SessionRepo) Create(ctx context.Context, session models.PlaySession) (id string, err error) {
+	doc, err := toDocument(session)
+	if err != nil {
+		return id, err
+	}
+
+	res, err := r.db.InsertOne(ctx, doc)
+	if err != nil {
+		return id, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	oid, ok := res.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return id, fmt.Errorf("failed to convert objectid")
+	}
+	logger.Tracef("Created document with oid %s.\n", oid)
+	return oid.Hex(), nil
+}
+
+func (r *SessionRepo) GetAll(ctx context.Context, userId string, filter models.SessionFilter) (sessions []models.PlaySession, count int64, err error) {
+	uid, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return sessions, count, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	query := bson.M{"userId": uid}
+	if filter.GameId != "" {
+		gid, err := primitive.ObjectIDFromHex(filter.GameId)
+		if err != nil {
+			return sessions, count, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+		}
+		query["gameId"] = gid
+	}
+	period := bson.M{}
+	if !filter.From.IsZero() {
+		period["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		period["$lt"] = filter.To.AddDate(0, 0, 1)
+	}
+	if len(period) > 0 {
+		query["start"] = period
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "start", Value: -1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(filter.Limit)
+	}
+	if filter.Skip > 0 {
+		opts.SetSkip(filter.Skip)
+	}
+
+	cur, err := r.db.Find(ctx, query, opts)
+	if err != nil {
+		return sessions, count, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &sessions); err != nil {
+		return sessions, count, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	count, err = r.db.CountDocuments(ctx, query)
+	if err != nil {
+		return sessions, count, fmt.Errorf("failed to count documents. error: %w", err)
+	}
+
+	return sessions, count, nil
+}
+
+func (r *SessionRepo) Update(ctx context.Context, session models.PlaySession) error {
+	oid, err := primitive.ObjectIDFromHex(session.Id)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	doc, err := toDocument(session)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": oid, "userId": doc["userId"]}, bson.M{"$set": doc})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return models.ErrSessionNotFound
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+func (r *SessionRepo) Remove(ctx context.Context, userId, sessionId string) error {
+	uid, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	oid, err := primitive.ObjectIDFromHex(sessionId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	res, err := r.db.DeleteOne(ctx, bson.M{"_id": oid, "userId": uid})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return models.ErrSessionNotFound
+	}
+
+	logger.Tracef("Delete %v documents.\n", res.DeletedCount)
+	return nil
+}
+
+type statsResult struct {
+	Total      []models.Playtime     `bson:"total"`
+	Week       []models.Playtime     `bson:"week"`
+	Month      []models.Playtime     `bson:"month"`
+	MostPlayed []models.GamePlaytime `bson:"mostPlayed"`
+	Days       []models.DayPlaytime  `bson:"days"`
+	Users      []struct {
+		Count int `bson:"count"`
+	} `bson:"users"`
+}
+
+// GetUserStats calculates the user's playtime in one aggregation. Days with sessions are
+// returned in descending order to calculate streaks.
+func (r *SessionRepo) GetUserStats(ctx context.Context, userId string, week, month time.Time) (stats models.UserStats, days []string, err error) {
+	uid, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return stats, days, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	facet := bson.M{
+		"total":      playtimeStage(time.Time{}),
+		"week":       playtimeStage(week),
+		"month":      playtimeStage(month),
+		"mostPlayed": r.mostPlayedStage(),
+		"days": bson.A{
+			bson.M{"$group": bson.M{
+				"_id":     bson.M{"$dateToString": bson.M{"format": dayFormat, "date": "$start"}},
+				"minutes": bson.M{"$sum": "$minutes"},
+			}},
+			bson.M{"$sort": bson.M{"_id": -1}},
+		},
+	}
+
+	res, err := r.aggregate(ctx, bson.M{"userId": uid}, facet)
+	if err != nil {
+		return stats, days, err
+	}
+
+	stats.Total = first(res.Total)
+	stats.Week = first(res.Week)
+	stats.Month = first(res.Month)
+	stats.MostPlayed = res.MostPlayed
+	for _, d := range res.Days {
+		days = append(days, d.Day)
+	}
+
+	return stats, days, nil
+}
+
+// GetDashboard calculates the playtime of all users. Daily playtime is returned since the daily time.
+func (r *SessionRepo) GetDashboard(ctx context.Context, week, month, daily time.Time) (dashboard models.Dashboard, err error) {
+	facet := bson.M{
+		"total":      playtimeStage(time.Time{}),
+		"week":       playtimeStage(week),
+		"month":      playtimeStage(month),
+		"mostPlayed": r.mostPlayedStage(),
+		"days": bson.A{
+			bson.M{"$match": bson.M{"start": bson.M{"$gte": daily}}},
+			bson.M{"$group": bson.M{
+				"_id":     bson.M{"$dateToString": bson.M{"format": dayFormat, "date": "$start"}},
+				"minutes": bson.M{"$sum": "$minutes"},
+			}},
+			bson.M{"$sort": bson.M{"_id": 1}},
+		},
+		"users": bson.A{
+			bson.M{"$match": bson.M{"start": bson.M{"$gte": month}}},
+			bson.M{"$group": bson.M{"_id": "$userId"}},
+			bson.M{"$count": "count"},
+		},
+	}
+
+	res, err := r.aggregate(ctx, bson.M{}, facet)
+	if err != nil {
+		return dashboard, err
+	}
+
+	dashboard.Total = first(res.Total)
+	dashboard.Week = first(res.Week)
+	dashboard.Month = first(res.Month)
+	dashboard.MostPlayed = res.MostPlayed
+	dashboard.Daily = res.Days
+	if len(res.Users) > 0 {
+		dashboard.ActiveUsers = res.Users[0].Count
+	}
+
+	return dashboard, nil
+}
+
+func (r *SessionRepo) aggregate(ctx context.Context, match, facet bson.M) (res statsResult, err error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$facet", Value: facet}},
+	}
+
+	cur, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return res, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	if cur.Next(ctx) {
+		if err := cur.Decode(&res); err != nil {
+			return res, fmt.Errorf("failed to decode document. error: %w", err)
+		}
+	}
+	if err := cur.Err(); err != nil {
+		return res, fmt.Errorf("failed to iterate cursor. error: %w", err)
+	}
+
+	return res, nil
+}
+
+func (r *SessionRepo) mostPlayedStage() bson.A {
+	return bson.A{
+		bson.M{"$group": bson.M{
+			"_id":      "$gameId",
+			"minutes":  bson.M{"$sum": "$minutes"},
+			"sessions": bson.M{"$sum": 1},
+		}},
+		bson.M{"$sort": bson.D{{Key: "minutes", Value: -1}}},
+		bson.M{"$limit": mostPlayedLimit},
+		bson.M{"$lookup": bson.M{
+			"from":         r.games,
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "game",
+		}},
+		bson.M{"$addFields": bson.M{"title": bson.M{"$arrayElemAt": bson.A{"$game.title", 0}}}},
+		bson.M{"$project": bson.M{"game": 0}},
+	}
+}
+
+// playtimeStage sums minutes and sessions started since the time. Zero time means all sessions.
+func playtimeStage(since time.Time) bson.A {
+	stage := bson.A{}
+	if !since.IsZero() {
+		stage = append(stage, bson.M{"$match": bson.M{"start": bson.M{"$gte": since}}})
+	}
+	return append(stage, bson.M{"$group": bson.M{
+		"_id":      nil,
+		"minutes":  bson.M{"$sum": "$minutes"},
+		"sessions": bson.M{"$sum": 1},
+	}})
+}
+
+func first(p []models.Playtime) models.Playtime {
+	if len(p) == 0 {
+		return models.Playtime{}
+	}
+	return p[0]
+}
+
+func toDocument(session models.PlaySession) (bson.M, error) {
+	uid, err := primitive.ObjectIDFromHex(session.UserId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	gid, err := primitive.ObjectIDFromHex(session.GameId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	return bson.M{
+		"userId":   uid,
+		"gameId":   gid,
+		"start":    session.Start,
+		"end":      session.End,
+		"minutes":  session.Minutes,
+		"platform": session.Platform,
+		"note":     session.Note,
+	}, nil
+}
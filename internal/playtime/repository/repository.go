@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/playtime/models"
+	session "github.com/Alexander272/games-library/internal/playtime/repository/mongo"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ISession interface {
+	Create(ctx context.Context, session models.PlaySession) (string, error)
+	GetAll(ctx context.Context, userId string, filter models.SessionFilter) ([]models.PlaySession, int64, error)
+	Update(ctx context.Context, session models.PlaySession) error
+	Remove(ctx context.Context, userId, sessionId string) error
+	GetUserStats(ctx context.Context, userId string, week, month time.Time) (models.UserStats, []string, error)
+	GetDashboard(ctx context.Context, week, month, daily time.Time) (models.Dashboard, error)
+}
+
+func NewSessionRepo(db *mongo.Database, collection, gamesCollection string) ISession {
+	return session.NewSessionRepo(db, collection, gamesCollection)
+}
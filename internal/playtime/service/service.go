@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Alexander272/games-library/internal/playtime/models"
+)
+
+type ISession interface {
+	Create(ctx context.Context, dto models.SessionDTO) (string, error)
+	GetAll(ctx context.Context, userId string, filter models.SessionFilter) ([]models.PlaySession, int64, error)
+	Update(ctx context.Context, dto models.SessionDTO) error
+	Remove(ctx context.Context, userId, sessionId string) error
+}
+
+type IStats interface {
+	GetUserStats(ctx context.Context, userId string) (models.UserStats, error)
+	GetDashboard(ctx context.Context) (models.Dashboard, error)
+}
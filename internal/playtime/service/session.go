@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/internal/playtime/models"
+	"github.com/Alexander272/games-library/internal/playtime/repository"
+)
+
+type SessionService struct {
+	repo  repository.ISession
+	games gameRepo.IGame
+}
+
+func NewSessionService(repo repository.ISession, games gameRepo.IGame) *SessionService {
+	return &SessionService{
+		repo:  repo,
+		games: games,
+	}
+}
+
+func (s *SessionService) Create(ctx context.Context, dto models.SessionDTO) (id string, err error) {
+	session, err := models.NewSession(dto)
+	if err != nil {
+		return id, err
+	}
+
+	if _, err := s.games.GetById(ctx, session.GameId); err != nil {
+		if errors.Is(err, gameModels.ErrGameNotFound) {
+			return id, err
+		}
+		return id, fmt.Errorf("failed to get game by id. error: %w", err)
+	}
+
+	id, err = s.repo.Create(ctx, session)
+	if err != nil {
+		return id, fmt.Errorf("failed to create play session. error: %w", err)
+	}
+	return id, nil
+}
+
+func (s *SessionService) GetAll(ctx context.Context, userId string, filter models.SessionFilter) (sessions []models.PlaySession, count int64, err error) {
+	sessions, count, err = s.repo.GetAll(ctx, userId, filter)
+	if err != nil {
+		return sessions, count, fmt.Errorf("failed to get play sessions. error: %w", err)
+	}
+	if len(sessions) == 0 {
+		return sessions, count, models.ErrSessionNotFound
+	}
+	return sessions, count, nil
+}
+
+func (s *SessionService) Update(ctx context.Context, dto models.SessionDTO) error {
+	session, err := models.NewSession(dto)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Update(ctx, session); err != nil {
+		if errors.Is(err, models.ErrSessionNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to update play session. error: %w", err)
+	}
+	return nil
+}
+
+func (s *SessionService) Remove(ctx context.Context, userId, sessionId string) error {
+	if err := s.repo.Remove(ctx, userId, sessionId); err != nil {
+		if errors.Is(err, models.ErrSessionNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to remove play session. error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/playtime/models"
+	"github.com/Alexander272/games-library/internal/playtime/repository"
+)
+
+// dashboardDays is the number of days in the daily playtime chart of the dashboard.
+const dashboardDays = 30
+
+type StatsService struct {
+	repo repository.ISession
+}
+
+func NewStatsService(repo repository.ISession) *StatsService {
+	return &StatsService{
+		repo: repo,
+	}
+}
+
+func (s *StatsService) GetUserStats(ctx context.Context, userId string) (stats models.UserStats, err error) {
+	now := time.Now().UTC()
+	stats, days, err := s.repo.GetUserStats(ctx, userId, now.AddDate(0, 0, -7), now.AddDate(0, -1, 0))
+	if err != nil {
+		return stats, fmt.Errorf("failed to get user stats. error: %w", err)
+	}
+
+	stats.CurrentStreak, stats.LongestStreak = streaks(days, now)
+	return stats, nil
+}
+
+func (s *StatsService) GetDashboard(ctx context.Context) (dashboard models.Dashboard, err error) {
+	now := time.Now().UTC()
+	daily := truncateDay(now).AddDate(0, 0, -dashboardDays+1)
+
+	dashboard, err = s.repo.GetDashboard(ctx, now.AddDate(0, 0, -7), now.AddDate(0, -1, 0), daily)
+	if err != nil {
+		return dashboard, fmt.Errorf("failed to get dashboard stats. error: %w", err)
+	}
+	return dashboard, nil
+}
+
+// streaks calculates the current and the longest runs of consecutive days with sessions.
+// Days are formatted as YYYY-MM-DD and sorted in descending order. The current streak
+// counts only if the last session was today or yesterday.
+func streaks(days []string, now time.Time) (current, longest int) {
+	dates := make([]time.Time, 0, len(days))
+	for _, d := range days {
+		date, err := time.Parse("2006-01-02", d)
+		if err != nil {
+			continue
+		}
+		dates = append(dates, date)
+	}
+
+	streak := 0
+	for i, date := range dates {
+		if i > 0 && dates[i-1].AddDate(0, 0, -1).Equal(date) {
+			streak++
+		} else {
+			streak = 1
+		}
+		if streak > longest {
+			longest = streak
+		}
+		if streak == i+1 {
+			current = streak
+		}
+	}
+
+	if len(dates) > 0 && dates[0].Before(truncateDay(now).AddDate(0, 0, -1)) {
+		current = 0
+	}
+	return current, longest
+}
+
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
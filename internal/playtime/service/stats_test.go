@@ -0,0 +1,32 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStreaks(t *testing.T) {
+	now := time.Date(2022, 3, 10, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		days          []string
+		current, best int
+	}{
+		{"no sessions", nil, 0, 0},
+		{"today", []string{"2022-03-10"}, 1, 1},
+		{"until yesterday", []string{"2022-03-09", "2022-03-08", "2022-03-07"}, 3, 3},
+		{"broken current streak", []string{"2022-03-10", "2022-03-09", "2022-03-05", "2022-03-04", "2022-03-03"}, 2, 3},
+		{"last session two days ago", []string{"2022-03-08", "2022-03-07"}, 0, 2},
+		{"across the month", []string{"2022-03-01", "2022-02-28", "2022-02-27"}, 0, 3},
+		{"invalid days are skipped", []string{"2022-03-10", "garbage", "2022-03-09"}, 2, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, best := streaks(tt.days, now)
+			if current != tt.current || best != tt.best {
+				t.Errorf("streaks() = %d, %d, want %d, %d", current, best, tt.current, tt.best)
+			}
+		})
+	}
+}
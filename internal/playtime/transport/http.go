@@ -0,0 +1,217 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/Alexander272/games-library/internal/playtime/models"
+	"github.com/Alexander272/games-library/internal/service"
+	"github.com/Alexander272/games-library/internal/transport/middleware"
+	"github.com/Alexander272/games-library/internal/transport/validation"
+	userModels "github.com/Alexander272/games-library/internal/user/models"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services   *service.Services
+	middleware *middleware.Middleware
+}
+
+func NewHandler(services *service.Services, middleware *middleware.Middleware) *Handler {
+	return &Handler{
+		services:   services,
+		middleware: middleware,
+	}
+}
+
+func (h *Handler) Init(api *gin.RouterGroup) {
+	sessions := api.Group("/users/me/sessions", h.middleware.UserIdentity, h.middleware.ObjectIds("id"))
+	{
+		sessions.GET("/", h.getAll)
+		sessions.POST("/", h.create)
+		sessions.PATCH("/:id", h.update)
+		sessions.DELETE("/:id", h.remove)
+	}
+
+	api.GET("/users/me/stats", h.middleware.UserIdentity, h.getUserStats)
+	api.GET("/stats", h.middleware.UserIdentity, h.middleware.AccessForRoles(userModels.RoleAdmin), h.getDashboard)
+}
+
+// @Summary Get All
+// @Security ApiKeyAuth
+// @Tags playtime
+// @Description получение игровых сессий пользователя
+// @ID getPlaySessions
+// @Accept json
+// @Produce json
+// @Param filter query models.SessionFilter false "filter"
+// @Success 200 {object} dataResponse{data=[]models.PlaySession}
+// @Failure 400,401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/sessions [get]
+func (h *Handler) getAll(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var filter models.SessionFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(validation.Query(err))
+		return
+	}
+
+	sessions, count, err := h.services.Session.GetAll(c, userId, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: sessions, Count: count})
+}
+
+// @Summary Create
+// @Security ApiKeyAuth
+// @Tags playtime
+// @Description добавление игровой сессии. Нужно указать конец сессии или её продолжительность в минутах
+// @ID createPlaySession
+// @Accept json
+// @Produce json
+// @Param session body models.SessionDTO true "session info"
+// @Success 201 {object} idResponse
+// @Failure 400,401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/sessions [post]
+func (h *Handler) create(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto models.SessionDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.UserId = userId
+
+	id, err := h.services.Session.Create(c, dto)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, idResponse{Id: id})
+}
+
+// @Summary Update
+// @Security ApiKeyAuth
+// @Tags playtime
+// @Description обновление игровой сессии
+// @ID updatePlaySession
+// @Accept json
+// @Produce json
+// @Param id path string true "session id"
+// @Param session body models.SessionDTO true "session info"
+// @Success 200 {object} response
+// @Failure 400,401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/sessions/{id} [patch]
+func (h *Handler) update(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto models.SessionDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.Id = c.Param("id")
+	dto.UserId = userId
+
+	if err := h.services.Session.Update(c, dto); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response{Message: "Session updated"})
+}
+
+// @Summary Remove
+// @Security ApiKeyAuth
+// @Tags playtime
+// @Description удаление игровой сессии
+// @ID removePlaySession
+// @Accept json
+// @Produce json
+// @Param id path string true "session id"
+// @Success 204 {object} response
+// @Failure 401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/sessions/{id} [delete]
+func (h *Handler) remove(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.services.Session.Remove(c, userId, c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusNoContent, response{Message: "Session removed"})
+}
+
+// @Summary User Stats
+// @Security ApiKeyAuth
+// @Tags playtime
+// @Description статистика игрового времени пользователя: всего, за неделю, за месяц, серии дней и самые популярные игры
+// @ID getUserPlaytimeStats
+// @Accept json
+// @Produce json
+// @Success 200 {object} dataResponse{data=models.UserStats}
+// @Failure 401 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/stats [get]
+func (h *Handler) getUserStats(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	stats, err := h.services.Stats.GetUserStats(c, userId)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: stats})
+}
+
+// @Summary Dashboard
+// @Security ApiKeyAuth
+// @Tags playtime
+// @Description статистика игрового времени всех пользователей для панели администратора
+// @ID getDashboardStats
+// @Accept json
+// @Produce json
+// @Success 200 {object} dataResponse{data=models.Dashboard}
+// @Failure 401,403 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /stats [get]
+func (h *Handler) getDashboard(c *gin.Context) {
+	dashboard, err := h.services.Stats.GetDashboard(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: dashboard})
+}
@@ -0,0 +1,21 @@
+package transport
+
+import (
+	"github.com/Alexander272/games-library/internal/transport/errcode"
+	"github.com/Alexander272/games-library/pkg/apperror"
+)
+
+type dataResponse struct {
+	Data  interface{} `json:"data"`
+	Count int64       `json:"count"`
+}
+
+type idResponse struct {
+	Id string `json:"id"`
+}
+
+type response struct {
+	Code    errcode.Code          `json:"code,omitempty"`
+	Message string                `json:"message"`
+	Fields  []apperror.FieldError `json:"fields,omitempty"`
+}
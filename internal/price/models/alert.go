@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Alert notifies the user when the price of the wishlist game drops below the threshold.
+// The user is notified again only if the price drops lower than the last notified price.
+type Alert struct {
+	Id            string    `json:"id" bson:"_id,omitempty"`
+	UserId        string    `json:"userId" bson:"userId"`
+	GameId        string    `json:"gameId" bson:"gameId"`
+	Currency      string    `json:"currency" bson:"currency"`
+	Threshold     float64   `json:"threshold" bson:"threshold"`
+	NotifiedPrice float64   `json:"notifiedPrice,omitempty" bson:"notifiedPrice,omitempty"`
+	NotifiedAt    time.Time `json:"notifiedAt" bson:"notifiedAt,omitempty"`
+	CreatedAt     time.Time `json:"createdAt" bson:"createdAt"`
+}
+
+type AlertDTO struct {
+	UserId    string  `json:"-"`
+	GameId    string  `json:"-"`
+	Currency  string  `json:"currency" binding:"required,len=3"`
+	Threshold float64 `json:"threshold" binding:"required,gt=0"`
+}
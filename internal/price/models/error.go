@@ -0,0 +1,11 @@
+package models
+
+import "github.com/Alexander272/games-library/pkg/apperror"
+
+var (
+	ErrPriceNotFound   = apperror.NotFound("PRICE_NOT_FOUND", "prices of the game aren't found")
+	ErrAlertNotFound   = apperror.NotFound("PRICE_ALERT_NOT_FOUND", "price alert doesn't exists")
+	ErrNotInWishlist   = apperror.Validation("NOT_IN_WISHLIST", "price alerts can be set only for games in the wishlist")
+	ErrIngestRunning   = apperror.Conflict("PRICE_INGEST_RUNNING", "price feed is already being ingested")
+	ErrUnknownFeedFile = apperror.Validation("UNKNOWN_FEED_FILE", "unknown feed file format, supported are json, jsonl and csv")
+)
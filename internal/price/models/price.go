@@ -0,0 +1,58 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Observation is the price of the game in the store at the date.
+type Observation struct {
+	Id       string    `json:"id" bson:"_id,omitempty"`
+	GameId   string    `json:"gameId" bson:"gameId"`
+	Store    string    `json:"store" bson:"store"`
+	Currency string    `json:"currency" bson:"currency"`
+	Price    float64   `json:"price" bson:"price"`
+	Date     time.Time `json:"date" bson:"date"`
+	Source   string    `json:"source,omitempty" bson:"source,omitempty"`
+}
+
+// Low is the historical low of the game price in the currency.
+type Low struct {
+	Currency string    `json:"currency" bson:"_id"`
+	Price    float64   `json:"price" bson:"price"`
+	Store    string    `json:"store" bson:"store"`
+	Date     time.Time `json:"date" bson:"date"`
+}
+
+type HistoryFilter struct {
+	Store    string    `form:"store"`
+	Currency string    `form:"currency"`
+	From     time.Time `form:"from" time_format:"2006-01-02"`
+	To       time.Time `form:"to" time_format:"2006-01-02"`
+	Limit    int64     `form:"limit" binding:"omitempty,min=1,max=1000"`
+	Skip     int64     `form:"skip" binding:"omitempty,min=0"`
+}
+
+// FeedRow is a record of the price feed file. The game is found by id or by slug.
+type FeedRow struct {
+	GameId   string  `json:"gameId"`
+	Slug     string  `json:"slug"`
+	Store    string  `json:"store"`
+	Currency string  `json:"currency"`
+	Price    float64 `json:"price"`
+	Date     string  `json:"date"`
+}
+
+// NormalizeCurrency makes currency codes comparable, e.g. "usd " and "USD".
+func NormalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+// FeedReport is the result of ingesting the feed files.
+type FeedReport struct {
+	Files    int `json:"files"`
+	Rows     int `json:"rows"`
+	Saved    int `json:"saved"`
+	Failed   int `json:"failed"`
+	Notified int `json:"notified"`
+}
@@ -0,0 +1,48 @@
+package price
+
+import (
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	libraryRepo "github.com/Alexander272/games-library/internal/library/repository"
+	"github.com/Alexander272/games-library/internal/price/repository"
+	"github.com/Alexander272/games-library/internal/price/service"
+	userRepo "github.com/Alexander272/games-library/internal/user/repository"
+	"github.com/Alexander272/games-library/pkg/lifecycle"
+	"github.com/Alexander272/games-library/pkg/notifier"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IObservationRepo interface {
+	repository.IObservation
+}
+type IAlertRepo interface {
+	repository.IAlert
+}
+
+type IPriceService interface {
+	service.IPrice
+}
+type IAlertService interface {
+	service.IAlert
+}
+type IFeedService interface {
+	service.IFeed
+}
+
+func NewObservationRepo(db *mongo.Database, collection string) IObservationRepo {
+	return repository.NewObservationRepo(db, collection)
+}
+func NewAlertRepo(db *mongo.Database, collection string) IAlertRepo {
+	return repository.NewAlertRepo(db, collection)
+}
+
+func NewPriceService(repo repository.IObservation) IPriceService {
+	return service.NewPriceService(repo)
+}
+func NewAlertService(repo repository.IAlert, library libraryRepo.ILibrary) IAlertService {
+	return service.NewAlertService(repo, library)
+}
+func NewFeedService(observations repository.IObservation, alerts repository.IAlert, games gameRepo.IGame,
+	library libraryRepo.ILibrary, users userRepo.IUser, notifier notifier.Notifier, dir string, background *lifecycle.Background,
+) IFeedService {
+	return service.NewFeedService(observations, alerts, games, library, users, notifier, dir, background)
+}
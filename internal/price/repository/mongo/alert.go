@@ -0,0 +1,142 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/price/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AlertRepo struct {
+	db *mongo.Collection
+}
+
+func NewAlertRepo(db *mongo.Database, collection string) *AlertRepo {
+	return &AlertRepo{
+		db: db.Collection(collection),
+	}
+}
+
+// Set creates or replaces the user's alert for the game. Changing the alert resets the last notification.
+func (r *AlertRepo) Set(ctx context.Context, alert models.Alert) error {
+	uid, gid, err := toObjectIds(alert.UserId, alert.GameId)
+	if err != nil {
+		return err
+	}
+
+	filter := bson.M{"userId": uid, "gameId": gid}
+	update := bson.M{
+		"$set": bson.M{
+			"currency":  alert.Currency,
+			"threshold": alert.Threshold,
+		},
+		"$unset":       bson.M{"notifiedPrice": "", "notifiedAt": ""},
+		"$setOnInsert": bson.M{"createdAt": alert.CreatedAt},
+	}
+
+	res, err := r.db.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Matched %v documents and upserted %v documents.\n", res.MatchedCount, res.UpsertedCount)
+	return nil
+}
+
+func (r *AlertRepo) GetByUser(ctx context.Context, userId string) (alerts []models.Alert, err error) {
+	uid, err := primitive.ObjectIDFromHex(userId)
+	if err != nil {
+		return alerts, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	cur, err := r.db.Find(ctx, bson.M{"userId": uid}, options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}))
+	if err != nil {
+		return alerts, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &alerts); err != nil {
+		return alerts, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// GetTriggered returns alerts of the game in the currency with the threshold not lower than the price,
+// which weren't notified about the same or a lower price.
+func (r *AlertRepo) GetTriggered(ctx context.Context, gameId, currency string, price float64) (alerts []models.Alert, err error) {
+	gid, err := primitive.ObjectIDFromHex(gameId)
+	if err != nil {
+		return alerts, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	filter := bson.M{
+		"gameId":    gid,
+		"currency":  currency,
+		"threshold": bson.M{"$gte": price},
+		"$or": bson.A{
+			bson.M{"notifiedPrice": bson.M{"$exists": false}},
+			bson.M{"notifiedPrice": bson.M{"$gt": price}},
+		},
+	}
+
+	cur, err := r.db.Find(ctx, filter)
+	if err != nil {
+		return alerts, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &alerts); err != nil {
+		return alerts, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return alerts, nil
+}
+
+func (r *AlertRepo) SetNotified(ctx context.Context, alertId string, price float64, notifiedAt time.Time) error {
+	oid, err := primitive.ObjectIDFromHex(alertId)
+	if err != nil {
+		return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	update := bson.M{"$set": bson.M{"notifiedPrice": price, "notifiedAt": notifiedAt}}
+	res, err := r.db.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Matched %v documents and updated %v documents.\n", res.MatchedCount, res.ModifiedCount)
+	return nil
+}
+
+func (r *AlertRepo) Remove(ctx context.Context, userId, gameId string) error {
+	uid, gid, err := toObjectIds(userId, gameId)
+	if err != nil {
+		return err
+	}
+
+	res, err := r.db.DeleteOne(ctx, bson.M{"userId": uid, "gameId": gid})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if res.DeletedCount == 0 {
+		return models.ErrAlertNotFound
+	}
+
+	logger.Tracef("Delete %v documents.\n", res.DeletedCount)
+	return nil
+}
+
+func toObjectIds(first, second string) (primitive.ObjectID, primitive.ObjectID, error) {
+	firstOid, err := primitive.ObjectIDFromHex(first)
+	if err != nil {
+		return firstOid, primitive.NilObjectID, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	secondOid, err := primitive.ObjectIDFromHex(second)
+	if err != nil {
+		return firstOid, secondOid, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+	return firstOid, secondOid, nil
+}
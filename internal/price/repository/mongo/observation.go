@@ -0,0 +1,130 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Alexander272/games-library/internal/price/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ObservationRepo struct {
+	db *mongo.Collection
+}
+
+func NewObservationRepo(db *mongo.Database, collection string) *ObservationRepo {
+	return &ObservationRepo{
+		db: db.Collection(collection),
+	}
+}
+
+// Save writes the observations. The same game, store, currency and date is saved once,
+// so ingesting the same feed file again doesn't duplicate the history.
+func (r *ObservationRepo) Save(ctx context.Context, observations []models.Observation) error {
+	if len(observations) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(observations))
+	for _, o := range observations {
+		gid, err := primitive.ObjectIDFromHex(o.GameId)
+		if err != nil {
+			return fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+		}
+
+		filter := bson.M{"gameId": gid, "store": o.Store, "currency": o.Currency, "date": o.Date}
+		update := bson.M{"$set": bson.M{"price": o.Price, "source": o.Source}}
+		writes = append(writes, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	}
+
+	res, err := r.db.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Matched %v documents and upserted %v documents.\n", res.MatchedCount, res.UpsertedCount)
+	return nil
+}
+
+func (r *ObservationRepo) GetHistory(ctx context.Context, gameId string, filter models.HistoryFilter) (observations []models.Observation, count int64, err error) {
+	gid, err := primitive.ObjectIDFromHex(gameId)
+	if err != nil {
+		return observations, count, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	query := bson.M{"gameId": gid}
+	if filter.Store != "" {
+		query["store"] = filter.Store
+	}
+	if filter.Currency != "" {
+		query["currency"] = models.NormalizeCurrency(filter.Currency)
+	}
+	period := bson.M{}
+	if !filter.From.IsZero() {
+		period["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		period["$lt"] = filter.To.AddDate(0, 0, 1)
+	}
+	if len(period) > 0 {
+		query["date"] = period
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: -1}})
+	if filter.Limit > 0 {
+		opts.SetLimit(filter.Limit)
+	}
+	if filter.Skip > 0 {
+		opts.SetSkip(filter.Skip)
+	}
+
+	cur, err := r.db.Find(ctx, query, opts)
+	if err != nil {
+		return observations, count, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &observations); err != nil {
+		return observations, count, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	count, err = r.db.CountDocuments(ctx, query)
+	if err != nil {
+		return observations, count, fmt.Errorf("failed to count documents. error: %w", err)
+	}
+
+	return observations, count, nil
+}
+
+// GetLows returns the lowest price of the game in every currency. If the price was the same
+// several times the earliest date is returned.
+func (r *ObservationRepo) GetLows(ctx context.Context, gameId string) (lows []models.Low, err error) {
+	gid, err := primitive.ObjectIDFromHex(gameId)
+	if err != nil {
+		return lows, fmt.Errorf("failed to convert hex to objectid. error: %w", err)
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"gameId": gid}}},
+		{{Key: "$sort", Value: bson.D{{Key: "price", Value: 1}, {Key: "date", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$currency",
+			"price": bson.M{"$first": "$price"},
+			"store": bson.M{"$first": "$store"},
+			"date":  bson.M{"$first": "$date"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+
+	cur, err := r.db.Aggregate(ctx, pipeline)
+	if err != nil {
+		return lows, fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if err := cur.All(ctx, &lows); err != nil {
+		return lows, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return lows, nil
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/price/models"
+	price "github.com/Alexander272/games-library/internal/price/repository/mongo"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IObservation interface {
+	Save(ctx context.Context, observations []models.Observation) error
+	GetHistory(ctx context.Context, gameId string, filter models.HistoryFilter) ([]models.Observation, int64, error)
+	GetLows(ctx context.Context, gameId string) ([]models.Low, error)
+}
+
+type IAlert interface {
+	Set(ctx context.Context, alert models.Alert) error
+	GetByUser(ctx context.Context, userId string) ([]models.Alert, error)
+	GetTriggered(ctx context.Context, gameId, currency string, price float64) ([]models.Alert, error)
+	SetNotified(ctx context.Context, alertId string, price float64, notifiedAt time.Time) error
+	Remove(ctx context.Context, userId, gameId string) error
+}
+
+func NewObservationRepo(db *mongo.Database, collection string) IObservation {
+	return price.NewObservationRepo(db, collection)
+}
+
+func NewAlertRepo(db *mongo.Database, collection string) IAlert {
+	return price.NewAlertRepo(db, collection)
+}
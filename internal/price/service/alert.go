@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	libraryModels "github.com/Alexander272/games-library/internal/library/models"
+	libraryRepo "github.com/Alexander272/games-library/internal/library/repository"
+	"github.com/Alexander272/games-library/internal/price/models"
+	"github.com/Alexander272/games-library/internal/price/repository"
+)
+
+type AlertService struct {
+	repo    repository.IAlert
+	library libraryRepo.ILibrary
+}
+
+func NewAlertService(repo repository.IAlert, library libraryRepo.ILibrary) *AlertService {
+	return &AlertService{
+		repo:    repo,
+		library: library,
+	}
+}
+
+func (s *AlertService) Set(ctx context.Context, dto models.AlertDTO) error {
+	if err := inWishlist(ctx, s.library, dto.UserId, dto.GameId); err != nil {
+		return err
+	}
+
+	alert := models.Alert{
+		UserId:    dto.UserId,
+		GameId:    dto.GameId,
+		Currency:  models.NormalizeCurrency(dto.Currency),
+		Threshold: dto.Threshold,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.Set(ctx, alert); err != nil {
+		return fmt.Errorf("failed to set price alert. error: %w", err)
+	}
+	return nil
+}
+
+func (s *AlertService) GetByUser(ctx context.Context, userId string) (alerts []models.Alert, err error) {
+	alerts, err = s.repo.GetByUser(ctx, userId)
+	if err != nil {
+		return alerts, fmt.Errorf("failed to get price alerts. error: %w", err)
+	}
+	if len(alerts) == 0 {
+		return alerts, models.ErrAlertNotFound
+	}
+	return alerts, nil
+}
+
+func (s *AlertService) Remove(ctx context.Context, userId, gameId string) error {
+	if err := s.repo.Remove(ctx, userId, gameId); err != nil {
+		if errors.Is(err, models.ErrAlertNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to remove price alert. error: %w", err)
+	}
+	return nil
+}
+
+// inWishlist checks that the game is in the user's library with the wishlist status.
+func inWishlist(ctx context.Context, library libraryRepo.ILibrary, userId, gameId string) error {
+	entry, err := library.GetByGame(ctx, userId, gameId)
+	if err != nil {
+		if errors.Is(err, libraryModels.ErrEntryNotFound) {
+			return models.ErrNotInWishlist
+		}
+		return fmt.Errorf("failed to get library entry. error: %w", err)
+	}
+	if entry.Status != libraryModels.StatusWishlist {
+		return models.ErrNotInWishlist
+	}
+	return nil
+}
@@ -0,0 +1,361 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	libraryRepo "github.com/Alexander272/games-library/internal/library/repository"
+	"github.com/Alexander272/games-library/internal/price/models"
+	"github.com/Alexander272/games-library/internal/price/repository"
+	userRepo "github.com/Alexander272/games-library/internal/user/repository"
+	"github.com/Alexander272/games-library/pkg/lifecycle"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/Alexander272/games-library/pkg/notifier"
+)
+
+const (
+	// saveBatch is the number of observations saved at once.
+	saveBatch = 500
+	// processedDir and failedDir are subdirectories of the feed directory for ingested files.
+	processedDir    = "processed"
+	failedDir       = "failed"
+	defaultInterval = time.Hour
+)
+
+// FeedService ingests price observations from the files in the feed directory. Ingested files
+// are moved to the "processed" subdirectory, files that can't be read or saved to the "failed" one.
+type FeedService struct {
+	observations repository.IObservation
+	alerts       repository.IAlert
+	games        gameRepo.IGame
+	library      libraryRepo.ILibrary
+	users        userRepo.IUser
+	notifier     notifier.Notifier
+	dir          string
+	background   *lifecycle.Background
+
+	running int32
+}
+
+func NewFeedService(observations repository.IObservation, alerts repository.IAlert, games gameRepo.IGame,
+	library libraryRepo.ILibrary, users userRepo.IUser, notifier notifier.Notifier, dir string, background *lifecycle.Background,
+) *FeedService {
+	return &FeedService{
+		observations: observations,
+		alerts:       alerts,
+		games:        games,
+		library:      library,
+		users:        users,
+		notifier:     notifier,
+		dir:          dir,
+		background:   background,
+	}
+}
+
+// lowKey is the game and the currency the lowest ingested price is kept for.
+type lowKey struct {
+	gameId   string
+	currency string
+}
+
+type lowPrice struct {
+	price float64
+	store string
+}
+
+// Ingest reads all feed files and notifies users whose alerts are triggered by the new prices.
+func (s *FeedService) Ingest(ctx context.Context) (report models.FeedReport, err error) {
+	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
+		return report, models.ErrIngestRunning
+	}
+	defer atomic.StoreInt32(&s.running, 0)
+
+	return s.ingest(ctx)
+}
+
+// Start ingests the feed in the background.
+func (s *FeedService) Start() error {
+	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
+		return models.ErrIngestRunning
+	}
+
+	s.background.Go(func(ctx context.Context) {
+		defer atomic.StoreInt32(&s.running, 0)
+		if _, err := s.ingest(ctx); err != nil {
+			logger.Errorf("failed to ingest price feed. error: %s", err.Error())
+		}
+	})
+	return nil
+}
+
+// Run ingests the feed every interval until the context is cancelled.
+func (s *FeedService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Ingest(ctx); err != nil {
+			logger.Errorf("failed to ingest price feed. error: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *FeedService) ingest(ctx context.Context) (report models.FeedReport, err error) {
+	if s.dir == "" {
+		return report, nil
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return report, nil
+		}
+		return report, fmt.Errorf("failed to read feed directory. error: %w", err)
+	}
+
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && supportedFeed(e.Name()) {
+			files = append(files, e.Name())
+		}
+	}
+	sort.Strings(files)
+
+	lows := make(map[lowKey]lowPrice)
+	resolver := newGameResolver(s.games)
+	for _, name := range files {
+		if err := s.ingestFile(ctx, name, resolver, lows, &report); err != nil {
+			logger.Errorf("failed to ingest price feed file %s. error: %s", name, err.Error())
+			s.move(name, failedDir)
+			continue
+		}
+		s.move(name, processedDir)
+		report.Files++
+	}
+
+	for key, low := range lows {
+		notified, err := s.notify(ctx, key, low)
+		if err != nil {
+			return report, err
+		}
+		report.Notified += notified
+	}
+
+	if len(files) > 0 {
+		logger.Infof("Ingested price feed: %d files, %d rows, %d saved, %d failed, %d users notified.",
+			report.Files, report.Rows, report.Saved, report.Failed, report.Notified)
+	}
+	return report, nil
+}
+
+// ingestFile reads the whole file before saving it, so nothing is saved from a file that can't be read.
+// The lows of the file are added only after all its observations are saved, so a file failed
+// on saving doesn't trigger the alerts.
+func (s *FeedService) ingestFile(ctx context.Context, name string, resolver *gameResolver, lows map[lowKey]lowPrice, report *models.FeedReport) error {
+	file, err := os.Open(filepath.Join(s.dir, name))
+	if err != nil {
+		return fmt.Errorf("failed to open file. error: %w", err)
+	}
+	defer file.Close()
+
+	var observations []models.Observation
+	err = readFeed(name, file, func(line int, row models.FeedRow, rowErr error) error {
+		report.Rows++
+		if rowErr == nil {
+			var observation models.Observation
+			observation, rowErr = s.toObservation(ctx, resolver, row)
+			if rowErr == nil {
+				observation.Source = name
+				observations = append(observations, observation)
+			}
+		}
+		if rowErr != nil {
+			report.Failed++
+			logger.Debugf("price feed %s, row %d: %s", name, line, rowErr.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(observations); i += saveBatch {
+		end := i + saveBatch
+		if end > len(observations) {
+			end = len(observations)
+		}
+		batch := observations[i:end]
+		if err := s.observations.Save(ctx, batch); err != nil {
+			return fmt.Errorf("failed to save prices. error: %w", err)
+		}
+		report.Saved += len(batch)
+	}
+
+	for _, o := range observations {
+		key := lowKey{gameId: o.GameId, currency: o.Currency}
+		if low, ok := lows[key]; !ok || o.Price < low.price {
+			lows[key] = lowPrice{price: o.Price, store: o.Store}
+		}
+	}
+	return nil
+}
+
+func (s *FeedService) toObservation(ctx context.Context, resolver *gameResolver, row models.FeedRow) (o models.Observation, err error) {
+	gameId, err := resolver.resolve(ctx, row)
+	if err != nil {
+		return o, err
+	}
+
+	store := strings.TrimSpace(row.Store)
+	if store == "" {
+		return o, errors.New("store is required")
+	}
+	currency := models.NormalizeCurrency(row.Currency)
+	if len(currency) != 3 {
+		return o, fmt.Errorf("invalid currency %q", row.Currency)
+	}
+	if row.Price <= 0 {
+		return o, fmt.Errorf("invalid price %v", row.Price)
+	}
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(row.Date))
+	if err != nil {
+		return o, fmt.Errorf("invalid date %q, expected format is YYYY-MM-DD", row.Date)
+	}
+
+	return models.Observation{
+		GameId:   gameId,
+		Store:    store,
+		Currency: currency,
+		Price:    row.Price,
+		Date:     date,
+	}, nil
+}
+
+// notify sends the notification to every user whose alert threshold is reached by the price.
+func (s *FeedService) notify(ctx context.Context, key lowKey, low lowPrice) (notified int, err error) {
+	alerts, err := s.alerts.GetTriggered(ctx, key.gameId, key.currency, low.price)
+	if err != nil {
+		return notified, fmt.Errorf("failed to get price alerts. error: %w", err)
+	}
+	if len(alerts) == 0 {
+		return notified, nil
+	}
+
+	game, err := s.games.GetById(ctx, key.gameId)
+	if err != nil {
+		return notified, fmt.Errorf("failed to get game by id. error: %w", err)
+	}
+
+	for _, alert := range alerts {
+		// the game could be bought or removed from the wishlist after the alert was set
+		if err := inWishlist(ctx, s.library, alert.UserId, alert.GameId); err != nil {
+			if !errors.Is(err, models.ErrNotInWishlist) {
+				logger.Errorf("failed to check wishlist. error: %s", err.Error())
+			}
+			continue
+		}
+
+		user, err := s.users.GetById(ctx, alert.UserId)
+		if err != nil {
+			logger.Errorf("failed to get user %s. error: %s", alert.UserId, err.Error())
+			continue
+		}
+
+		err = s.notifier.Notify(ctx, notifier.Message{
+			UserId:  user.Id,
+			Email:   user.Email,
+			Subject: fmt.Sprintf("%s is on sale", game.Title),
+			Text: fmt.Sprintf("%s costs %.2f %s in %s, below your threshold of %.2f %s.",
+				game.Title, low.price, key.currency, low.store, alert.Threshold, alert.Currency),
+		})
+		if err != nil {
+			logger.Errorf("failed to notify user %s. error: %s", user.Id, err.Error())
+			continue
+		}
+
+		if err := s.alerts.SetNotified(ctx, alert.Id, low.price, time.Now()); err != nil {
+			return notified, fmt.Errorf("failed to update price alert. error: %w", err)
+		}
+		notified++
+	}
+
+	return notified, nil
+}
+
+func (s *FeedService) move(name, subdir string) {
+	dir := filepath.Join(s.dir, subdir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		logger.Errorf("failed to create directory %s. error: %s", dir, err.Error())
+		return
+	}
+	if err := os.Rename(filepath.Join(s.dir, name), filepath.Join(dir, name)); err != nil {
+		logger.Errorf("failed to move price feed file %s. error: %s", name, err.Error())
+	}
+}
+
+// gameResolver finds games of the feed rows by id or slug and remembers the results,
+// because the feed usually has many rows for the same game.
+type gameResolver struct {
+	games gameRepo.IGame
+	ids   map[string]string
+}
+
+func newGameResolver(games gameRepo.IGame) *gameResolver {
+	return &gameResolver{
+		games: games,
+		ids:   make(map[string]string),
+	}
+}
+
+func (r *gameResolver) resolve(ctx context.Context, row models.FeedRow) (string, error) {
+	key := "id:" + strings.TrimSpace(row.GameId)
+	if row.GameId == "" {
+		key = "slug:" + strings.TrimSpace(row.Slug)
+	}
+	if row.GameId == "" && row.Slug == "" {
+		return "", errors.New("gameId or slug is required")
+	}
+
+	if id, ok := r.ids[key]; ok {
+		if id == "" {
+			return "", gameModels.ErrGameNotFound
+		}
+		return id, nil
+	}
+
+	var game gameModels.Game
+	var err error
+	if row.GameId != "" {
+		game, err = r.games.GetById(ctx, strings.TrimSpace(row.GameId))
+	} else {
+		game, err = r.games.GetBySlug(ctx, strings.TrimSpace(row.Slug))
+	}
+	if err != nil {
+		if errors.Is(err, gameModels.ErrGameNotFound) {
+			r.ids[key] = ""
+			return "", err
+		}
+		return "", fmt.Errorf("failed to get game. error: %w", err)
+	}
+
+	r.ids[key] = game.Id
+	return game.Id, nil
+}
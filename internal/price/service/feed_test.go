@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/internal/price/models"
+	"github.com/Alexander272/games-library/internal/price/repository"
+)
+
+// fakeGames finds the games by id or slug and counts the lookups.
+type fakeGames struct {
+	gameRepo.IGame
+	lookups int
+}
+
+func (f *fakeGames) GetById(ctx context.Context, id string) (gameModels.Game, error) {
+	f.lookups++
+	if id == "hades" {
+		return gameModels.Game{Id: id, Title: "Hades"}, nil
+	}
+	return gameModels.Game{}, gameModels.ErrGameNotFound
+}
+
+func (f *fakeGames) GetBySlug(ctx context.Context, slug string) (gameModels.Game, error) {
+	f.lookups++
+	if slug == "witcher-3" {
+		return gameModels.Game{Id: "witcher", Title: "The Witcher 3"}, nil
+	}
+	return gameModels.Game{}, gameModels.ErrGameNotFound
+}
+
+type fakeObservations struct {
+	repository.IObservation
+	saved []models.Observation
+}
+
+func (f *fakeObservations) Save(ctx context.Context, observations []models.Observation) error {
+	f.saved = append(f.saved, observations...)
+	return nil
+}
+
+// fakeAlerts records the prices the alerts are checked for and has no triggered alerts.
+type fakeAlerts struct {
+	repository.IAlert
+	checked map[string]float64
+}
+
+func (f *fakeAlerts) GetTriggered(ctx context.Context, gameId, currency string, price float64) ([]models.Alert, error) {
+	f.checked[gameId+" "+currency] = price
+	return nil, nil
+}
+
+func TestToObservation(t *testing.T) {
+	date := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	valid := models.FeedRow{Slug: "witcher-3", Store: " Steam ", Currency: "usd ", Price: 9.99, Date: "2023-01-02"}
+	with := func(change func(r *models.FeedRow)) models.FeedRow {
+		row := valid
+		change(&row)
+		return row
+	}
+
+	tests := []struct {
+		name    string
+		row     models.FeedRow
+		want    models.Observation
+		wantErr bool
+	}{
+		{"by slug", valid, models.Observation{GameId: "witcher", Store: "Steam", Currency: "USD", Price: 9.99, Date: date}, false},
+		{"by id", with(func(r *models.FeedRow) { r.GameId, r.Slug = "hades", "" }), models.Observation{GameId: "hades", Store: "Steam", Currency: "USD", Price: 9.99, Date: date}, false},
+		{"no game", with(func(r *models.FeedRow) { r.Slug = "" }), models.Observation{}, true},
+		{"unknown game", with(func(r *models.FeedRow) { r.Slug = "unknown" }), models.Observation{}, true},
+		{"no store", with(func(r *models.FeedRow) { r.Store = " " }), models.Observation{}, true},
+		{"invalid currency", with(func(r *models.FeedRow) { r.Currency = "dollar" }), models.Observation{}, true},
+		{"zero price", with(func(r *models.FeedRow) { r.Price = 0 }), models.Observation{}, true},
+		{"invalid date", with(func(r *models.FeedRow) { r.Date = "02.01.2023" }), models.Observation{}, true},
+	}
+	s := &FeedService{}
+	resolver := newGameResolver(&fakeGames{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.toObservation(context.Background(), resolver, tt.row)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("toObservation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("toObservation() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGameResolverCache(t *testing.T) {
+	games := &fakeGames{}
+	resolver := newGameResolver(games)
+	for i := 0; i < 3; i++ {
+		if _, err := resolver.resolve(context.Background(), models.FeedRow{Slug: "witcher-3"}); err != nil {
+			t.Fatalf("resolve() error = %v", err)
+		}
+		if _, err := resolver.resolve(context.Background(), models.FeedRow{Slug: "unknown"}); !errors.Is(err, gameModels.ErrGameNotFound) {
+			t.Fatalf("resolve() of the unknown game error = %v", err)
+		}
+	}
+	if games.lookups != 2 {
+		t.Errorf("lookups = %d, want 2", games.lookups)
+	}
+}
+
+// TestIngestFailedFile checks that nothing of a file failed in the middle is saved or used for the alerts.
+func TestIngestFailedFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"1-broken.json": `[{"gameId": "hades", "store": "GOG", "currency": "EUR", "price": 1, "date": "2023-01-02"}, {"gameId": `,
+		"2-valid.csv":   "slug,store,currency,price,date\nwitcher-3,Steam,USD,9.99,2023-01-02\nunknown,Steam,USD,1,2023-01-02\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	observations := &fakeObservations{}
+	alerts := &fakeAlerts{checked: map[string]float64{}}
+	s := NewFeedService(observations, alerts, &fakeGames{}, nil, nil, nil, dir, nil)
+
+	report, err := s.Ingest(context.Background())
+	if err != nil {
+		t.Fatalf("Ingest() error = %v", err)
+	}
+
+	if want := (models.FeedReport{Files: 1, Rows: 3, Saved: 1, Failed: 1}); report != want {
+		t.Errorf("report = %+v, want %+v", report, want)
+	}
+	if len(observations.saved) != 1 || observations.saved[0].Source != "2-valid.csv" {
+		t.Errorf("saved = %+v, want the row of the valid file", observations.saved)
+	}
+	if len(alerts.checked) != 1 || alerts.checked["witcher USD"] != 9.99 {
+		t.Errorf("checked alerts = %v, want only the valid file", alerts.checked)
+	}
+	for name, subdir := range map[string]string{"1-broken.json": failedDir, "2-valid.csv": processedDir} {
+		if _, err := os.Stat(filepath.Join(dir, subdir, name)); err != nil {
+			t.Errorf("%s isn't moved to %s. error: %v", name, subdir, err)
+		}
+	}
+}
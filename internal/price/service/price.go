@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Alexander272/games-library/internal/price/models"
+	"github.com/Alexander272/games-library/internal/price/repository"
+)
+
+type PriceService struct {
+	repo repository.IObservation
+}
+
+func NewPriceService(repo repository.IObservation) *PriceService {
+	return &PriceService{
+		repo: repo,
+	}
+}
+
+func (s *PriceService) GetHistory(ctx context.Context, gameId string, filter models.HistoryFilter) (observations []models.Observation, count int64, err error) {
+	observations, count, err = s.repo.GetHistory(ctx, gameId, filter)
+	if err != nil {
+		return observations, count, fmt.Errorf("failed to get price history. error: %w", err)
+	}
+	if len(observations) == 0 {
+		return observations, count, models.ErrPriceNotFound
+	}
+	return observations, count, nil
+}
+
+func (s *PriceService) GetLows(ctx context.Context, gameId string) (lows []models.Low, err error) {
+	lows, err = s.repo.GetLows(ctx, gameId)
+	if err != nil {
+		return lows, fmt.Errorf("failed to get historical lows. error: %w", err)
+	}
+	if len(lows) == 0 {
+		return lows, models.ErrPriceNotFound
+	}
+	return lows, nil
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Alexander272/games-library/internal/price/models"
+)
+
+const maxLineSize = 1 << 20
+
+// rowFunc is called for every row of the feed file. A malformed row is passed with the error,
+// returning an error stops reading the file.
+type rowFunc func(line int, row models.FeedRow, err error) error
+
+// supportedFeed reports whether the file can be read as a price feed.
+func supportedFeed(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv", ".json", ".jsonl":
+		return true
+	}
+	return false
+}
+
+func readFeed(name string, r io.Reader, fn rowFunc) error {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return readCsv(r, fn)
+	case ".json":
+		return readJson(r, fn)
+	case ".jsonl":
+		return readJsonl(r, fn)
+	default:
+		return models.ErrUnknownFeedFile
+	}
+}
+
+// readCsv reads the file with the header row. Columns are gameId or slug, store, currency, price and date.
+func readCsv(r io.Reader, fn rowFunc) error {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read header. error: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, column := range []string{"store", "currency", "price", "date"} {
+		if _, ok := columns[column]; !ok {
+			return fmt.Errorf("required column '%s' is missing", column)
+		}
+	}
+	get := func(record []string, column string) string {
+		i, ok := columns[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return err
+			}
+			if err := fn(parseErr.Line, models.FeedRow{}, parseErr.Err); err != nil {
+				return err
+			}
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+
+		row := models.FeedRow{
+			GameId:   get(record, "gameid"),
+			Slug:     get(record, "slug"),
+			Store:    get(record, "store"),
+			Currency: get(record, "currency"),
+			Date:     get(record, "date"),
+		}
+		price, err := strconv.ParseFloat(strings.Replace(get(record, "price"), ",", ".", 1), 64)
+		if err != nil {
+			err = fmt.Errorf("invalid price %q", get(record, "price"))
+		}
+		row.Price = price
+
+		if err := fn(line, row, err); err != nil {
+			return err
+		}
+	}
+}
+
+// readJson reads the file with an array of rows without loading the whole array into memory.
+func readJson(r io.Reader, fn rowFunc) error {
+	dec := json.NewDecoder(r)
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("failed to read json array. error: %w", err)
+	}
+
+	for i := 1; dec.More(); i++ {
+		var row models.FeedRow
+		if err := dec.Decode(&row); err != nil {
+			// the rest of the array can't be read after a syntax error
+			return fmt.Errorf("failed to decode row %d. error: %w", i, err)
+		}
+		if err := fn(i, row, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readJsonl(r io.Reader, fn rowFunc) error {
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" {
+			continue
+		}
+
+		var row models.FeedRow
+		err := json.Unmarshal([]byte(text), &row)
+		if err := fn(line, row, err); err != nil {
+			return err
+		}
+	}
+	return s.Err()
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Alexander272/games-library/internal/price/models"
+)
+
+// readRow is a row passed to the rowFunc.
+type readRow struct {
+	line  int
+	row   models.FeedRow
+	isErr bool
+}
+
+func TestReadFeed(t *testing.T) {
+	witcher := models.FeedRow{Slug: "witcher-3", Store: "Steam", Currency: "usd", Price: 9.99, Date: "2023-01-02"}
+	hades := models.FeedRow{GameId: "hades", Store: "GOG", Currency: "EUR", Price: 12.5, Date: "2023-01-03"}
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []readRow
+		wantErr bool
+	}{
+		{
+			name: "csv",
+			file: "prices.csv",
+			content: "Slug, GameId, Store, Currency, Price, Date\n" +
+				"witcher-3,, Steam, usd, \"9,99\", 2023-01-02\n" +
+				",hades,GOG,EUR,12.5,2023-01-03\n" +
+				"hades,,GOG,EUR,free,2023-01-03\n",
+			want: []readRow{
+				{line: 2, row: witcher},
+				{line: 3, row: hades},
+				{line: 4, row: models.FeedRow{Slug: "hades", Store: "GOG", Currency: "EUR", Date: "2023-01-03"}, isErr: true},
+			},
+		},
+		{
+			name:    "csv without the price column",
+			file:    "prices.csv",
+			content: "slug,store,currency,date\n",
+			wantErr: true,
+		},
+		{
+			name: "json",
+			file: "prices.JSON",
+			content: `[{"slug": "witcher-3", "store": "Steam", "currency": "usd", "price": 9.99, "date": "2023-01-02"},
+				{"gameId": "hades", "store": "GOG", "currency": "EUR", "price": 12.5, "date": "2023-01-03"}]`,
+			want: []readRow{{line: 1, row: witcher}, {line: 2, row: hades}},
+		},
+		{
+			name:    "broken json",
+			file:    "prices.json",
+			content: `[{"slug": "witcher-3", "store": "Steam", "currency": "usd", "price": 9.99, "date": "2023-01-02"}, {"slug": `,
+			want:    []readRow{{line: 1, row: witcher}},
+			wantErr: true,
+		},
+		{
+			name: "jsonl",
+			file: "prices.jsonl",
+			content: `{"slug": "witcher-3", "store": "Steam", "currency": "usd", "price": 9.99, "date": "2023-01-02"}
+
+not json
+{"gameId": "hades", "store": "GOG", "currency": "EUR", "price": 12.5, "date": "2023-01-03"}
+`,
+			want: []readRow{{line: 1, row: witcher}, {line: 3, isErr: true}, {line: 4, row: hades}},
+		},
+		{
+			name:    "unknown file",
+			file:    "prices.xml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []readRow
+			err := readFeed(tt.file, strings.NewReader(tt.content), func(line int, row models.FeedRow, err error) error {
+				got = append(got, readRow{line: line, row: row, isErr: err != nil})
+				return nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readFeed() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("rows = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadFeedStops(t *testing.T) {
+	stop := errors.New("stop")
+	calls := 0
+	err := readFeed("prices.jsonl", strings.NewReader("{}\n{}\n"), func(line int, row models.FeedRow, err error) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("readFeed() error = %v after %d calls, want the error of the first call", err, calls)
+	}
+}
+
+func TestSupportedFeed(t *testing.T) {
+	for name, want := range map[string]bool{"a.csv": true, "a.JSON": true, "a.jsonl": true, "a.txt": false, "csv": false} {
+		if got := supportedFeed(name); got != want {
+			t.Errorf("supportedFeed(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/price/models"
+)
+
+type IPrice interface {
+	GetHistory(ctx context.Context, gameId string, filter models.HistoryFilter) ([]models.Observation, int64, error)
+	GetLows(ctx context.Context, gameId string) ([]models.Low, error)
+}
+
+type IAlert interface {
+	Set(ctx context.Context, dto models.AlertDTO) error
+	GetByUser(ctx context.Context, userId string) ([]models.Alert, error)
+	Remove(ctx context.Context, userId, gameId string) error
+}
+
+type IFeed interface {
+	Ingest(ctx context.Context) (models.FeedReport, error)
+	Start() error
+	Run(ctx context.Context, interval time.Duration)
+}
@@ -0,0 +1,196 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/Alexander272/games-library/internal/price/models"
+	"github.com/Alexander272/games-library/internal/service"
+	"github.com/Alexander272/games-library/internal/transport/middleware"
+	"github.com/Alexander272/games-library/internal/transport/validation"
+	userModels "github.com/Alexander272/games-library/internal/user/models"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services   *service.Services
+	middleware *middleware.Middleware
+}
+
+func NewHandler(services *service.Services, middleware *middleware.Middleware) *Handler {
+	return &Handler{
+		services:   services,
+		middleware: middleware,
+	}
+}
+
+func (h *Handler) Init(api *gin.RouterGroup) {
+	api.GET("/games/:id/prices", h.middleware.ObjectIds("id"), h.getHistory)
+	api.GET("/games/:id/prices/lows", h.middleware.ObjectIds("id"), h.getLows)
+	api.POST("/prices/ingest", h.middleware.UserIdentity, h.middleware.AccessForRoles(userModels.RoleAdmin), h.ingest)
+
+	alerts := api.Group("/users/me/price-alerts", h.middleware.UserIdentity, h.middleware.ObjectIds("gameId"))
+	{
+		alerts.GET("/", h.getAlerts)
+		alerts.PUT("/:gameId", h.setAlert)
+		alerts.DELETE("/:gameId", h.removeAlert)
+	}
+}
+
+// @Summary History
+// @Tags prices
+// @Description история цен игры по магазинам и валютам
+// @ID getPriceHistory
+// @Accept json
+// @Produce json
+// @Param id path string true "game id"
+// @Param filter query models.HistoryFilter false "filter"
+// @Success 200 {object} dataResponse{data=[]models.Observation}
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id}/prices [get]
+func (h *Handler) getHistory(c *gin.Context) {
+	var filter models.HistoryFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(validation.Query(err))
+		return
+	}
+
+	prices, count, err := h.services.Price.GetHistory(c, c.Param("id"), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: prices, Count: count})
+}
+
+// @Summary Lows
+// @Tags prices
+// @Description исторический минимум цены игры в каждой валюте
+// @ID getPriceLows
+// @Accept json
+// @Produce json
+// @Param id path string true "game id"
+// @Success 200 {object} dataResponse{data=[]models.Low}
+// @Failure 404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id}/prices/lows [get]
+func (h *Handler) getLows(c *gin.Context) {
+	lows, err := h.services.Price.GetLows(c, c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: lows, Count: int64(len(lows))})
+}
+
+// @Summary Ingest
+// @Security ApiKeyAuth
+// @Tags prices
+// @Description запуск загрузки файлов с ценами из каталога вне расписания
+// @ID ingestPrices
+// @Accept json
+// @Produce json
+// @Success 202 {object} response
+// @Failure 401,403,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /prices/ingest [post]
+func (h *Handler) ingest(c *gin.Context) {
+	if err := h.services.PriceFeed.Start(); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusAccepted, response{Message: "Ingestion started"})
+}
+
+// @Summary Get Alerts
+// @Security ApiKeyAuth
+// @Tags prices
+// @Description получение уведомлений о снижении цены пользователя
+// @ID getPriceAlerts
+// @Accept json
+// @Produce json
+// @Success 200 {object} dataResponse{data=[]models.Alert}
+// @Failure 401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/price-alerts [get]
+func (h *Handler) getAlerts(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	alerts, err := h.services.PriceAlert.GetByUser(c, userId)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: alerts, Count: int64(len(alerts))})
+}
+
+// @Summary Set Alert
+// @Security ApiKeyAuth
+// @Tags prices
+// @Description установка порога цены для игры из списка желаемого. при снижении цены ниже порога пользователь получит уведомление
+// @ID setPriceAlert
+// @Accept json
+// @Produce json
+// @Param gameId path string true "game id"
+// @Param alert body models.AlertDTO true "alert info"
+// @Success 200 {object} response
+// @Failure 400,401 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/price-alerts/{gameId} [put]
+func (h *Handler) setAlert(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var dto models.AlertDTO
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
+		return
+	}
+	dto.UserId = userId
+	dto.GameId = c.Param("gameId")
+
+	if err := h.services.PriceAlert.Set(c, dto); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response{Message: "Alert set successfully"})
+}
+
+// @Summary Remove Alert
+// @Security ApiKeyAuth
+// @Tags prices
+// @Description удаление уведомления о снижении цены
+// @ID removePriceAlert
+// @Accept json
+// @Produce json
+// @Param gameId path string true "game id"
+// @Success 204 {object} response
+// @Failure 401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/price-alerts/{gameId} [delete]
+func (h *Handler) removeAlert(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.services.PriceAlert.Remove(c, userId, c.Param("gameId")); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusNoContent, response{Message: "Deleted successfully"})
+}
@@ -0,0 +1,8 @@
+package models
+
+import "github.com/Alexander272/games-library/pkg/apperror"
+
+var (
+	ErrNoSuggestions      = apperror.NotFound("NO_SUGGESTIONS", "suggestions aren't calculated yet")
+	ErrCalculationRunning = apperror.Conflict("SUGGESTIONS_CALCULATION_RUNNING", "suggestions are already being calculated")
+)
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Suggestion is a game offered to the user with the reasons why it was chosen.
+type Suggestion struct {
+	GameId  string   `json:"gameId" bson:"gameId"`
+	Slug    string   `json:"slug" bson:"slug,omitempty"`
+	Title   string   `json:"title" bson:"title"`
+	Score   float64  `json:"score" bson:"score"`
+	Reasons []string `json:"reasons" bson:"reasons"`
+}
+
+// Suggestions are calculated by the background job for a game ("similar games")
+// or for a user (personal recommendations).
+type Suggestions struct {
+	Id        string       `json:"id" bson:"_id"`
+	Games     []Suggestion `json:"games" bson:"games"`
+	UpdatedAt time.Time    `json:"updatedAt" bson:"updatedAt"`
+}
+
+func (s Suggestions) MarshalBinary() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+func (s *Suggestions) UnmarshalBinary(b []byte) error {
+	return json.Unmarshal(b, s)
+}
+
+type SuggestionFilter struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// Rating is a library entry used by collaborative filtering. Unrated entries have zero rating.
+type Rating struct {
+	UserId string `bson:"userId"`
+	GameId string `bson:"gameId"`
+	Rating int    `bson:"rating"`
+}
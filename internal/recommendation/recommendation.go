@@ -0,0 +1,51 @@
+package recommendation
+
+import (
+	"time"
+
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/internal/recommendation/repository"
+	"github.com/Alexander272/games-library/internal/recommendation/service"
+	"github.com/Alexander272/games-library/pkg/lifecycle"
+	"github.com/go-redis/redis/v8"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ISuggestionRepo interface {
+	repository.ISuggestion
+}
+type IRatingRepo interface {
+	repository.IRating
+}
+type ICacheRepo interface {
+	repository.ICache
+}
+
+type IRecommendationService interface {
+	service.IRecommendation
+}
+type ICalculationService interface {
+	service.ICalculation
+}
+
+func NewSuggestionRepo(db *mongo.Database, collection string) ISuggestionRepo {
+	return repository.NewSuggestionRepo(db, collection)
+}
+func NewRatingRepo(db *mongo.Database, libraryCollection string) IRatingRepo {
+	return repository.NewRatingRepo(db, libraryCollection)
+}
+func NewCacheRepo(db *redis.Client, prefix string) ICacheRepo {
+	return repository.NewCacheRepo(db, prefix)
+}
+
+func NewRecommendationService(similar, recommended repository.ISuggestion, similarCache, recommendedCache repository.ICache,
+	cacheTTL time.Duration,
+) IRecommendationService {
+	return service.NewRecommendationService(similar, recommended, similarCache, recommendedCache, cacheTTL)
+}
+
+func NewCalculationService(games gameRepo.IGame, ratings repository.IRating, similar, recommended repository.ISuggestion,
+	similarCache, recommendedCache repository.ICache, background *lifecycle.Background,
+) ICalculationService {
+	return service.NewCalculationService(games, ratings, similar, recommended, similarCache, recommendedCache, background)
+}
@@ -0,0 +1,48 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Alexander272/games-library/internal/recommendation/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RatingRepo reads the library entries of all users for collaborative filtering.
+type RatingRepo struct {
+	db *mongo.Collection
+}
+
+func NewRatingRepo(db *mongo.Database, libraryCollection string) *RatingRepo {
+	return &RatingRepo{
+		db: db.Collection(libraryCollection),
+	}
+}
+
+// Iterate calls fn for every library entry without loading all entries into memory.
+func (r *RatingRepo) Iterate(ctx context.Context, fn func(models.Rating) error) error {
+	opts := options.Find().SetProjection(bson.M{"userId": 1, "gameId": 1, "rating": 1})
+
+	cur, err := r.db.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var rating models.Rating
+		if err := cur.Decode(&rating); err != nil {
+			return fmt.Errorf("failed to decode document. error: %w", err)
+		}
+		if err := fn(rating); err != nil {
+			return err
+		}
+	}
+	if err := cur.Err(); err != nil {
+		return fmt.Errorf("failed to iterate cursor. error: %w", err)
+	}
+
+	return nil
+}
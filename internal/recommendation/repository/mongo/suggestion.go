@@ -0,0 +1,81 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/recommendation/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// batchSize is the number of documents written in one bulk operation.
+const batchSize = 500
+
+// SuggestionRepo keeps the calculated suggestions. The document id is the id of the game
+// for similar games or the id of the user for personal recommendations.
+type SuggestionRepo struct {
+	db *mongo.Collection
+}
+
+func NewSuggestionRepo(db *mongo.Database, collection string) *SuggestionRepo {
+	return &SuggestionRepo{
+		db: db.Collection(collection),
+	}
+}
+
+func (r *SuggestionRepo) Get(ctx context.Context, id string) (suggestions models.Suggestions, err error) {
+	res := r.db.FindOne(ctx, bson.M{"_id": id})
+	if res.Err() != nil {
+		if errors.Is(res.Err(), mongo.ErrNoDocuments) {
+			return suggestions, models.ErrNoSuggestions
+		}
+		return suggestions, fmt.Errorf("failed to execute query. error: %w", res.Err())
+	}
+	if err := res.Decode(&suggestions); err != nil {
+		return suggestions, fmt.Errorf("failed to decode document. error: %w", err)
+	}
+
+	return suggestions, nil
+}
+
+// Save replaces the suggestions with the new ones in batches.
+func (r *SuggestionRepo) Save(ctx context.Context, suggestions []models.Suggestions) error {
+	for start := 0; start < len(suggestions); start += batchSize {
+		end := start + batchSize
+		if end > len(suggestions) {
+			end = len(suggestions)
+		}
+
+		writes := make([]mongo.WriteModel, 0, end-start)
+		for _, s := range suggestions[start:end] {
+			writes = append(writes, mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"_id": s.Id}).
+				SetReplacement(s).
+				SetUpsert(true))
+		}
+
+		res, err := r.db.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			return fmt.Errorf("failed to execute query. error: %w", err)
+		}
+		logger.Tracef("Matched %v documents and upserted %v documents.\n", res.MatchedCount, res.UpsertedCount)
+	}
+
+	return nil
+}
+
+// RemoveOlder removes suggestions that weren't recalculated since the time, e.g. for removed games.
+func (r *SuggestionRepo) RemoveOlder(ctx context.Context, before time.Time) error {
+	res, err := r.db.DeleteMany(ctx, bson.M{"updatedAt": bson.M{"$lt": before}})
+	if err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+
+	logger.Tracef("Delete %v documents.\n", res.DeletedCount)
+	return nil
+}
@@ -0,0 +1,74 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/recommendation/models"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/go-redis/redis/v8"
+)
+
+// CacheRepo caches suggestions under keys "<prefix>:<id>".
+type CacheRepo struct {
+	db     *redis.Client
+	prefix string
+}
+
+func NewCacheRepo(db *redis.Client, prefix string) *CacheRepo {
+	return &CacheRepo{
+		db:     db,
+		prefix: prefix,
+	}
+}
+
+func (r *CacheRepo) Get(ctx context.Context, id string) (suggestions models.Suggestions, err error) {
+	cmd := r.db.Get(ctx, r.key(id))
+	if cmd.Err() != nil {
+		if errors.Is(cmd.Err(), redis.Nil) {
+			return suggestions, models.ErrNoSuggestions
+		}
+		return suggestions, fmt.Errorf("failed to execute query. error: %w", cmd.Err())
+	}
+
+	if err := cmd.Scan(&suggestions); err != nil {
+		return suggestions, fmt.Errorf("failed to decode suggestions. error: %w", err)
+	}
+	return suggestions, nil
+}
+
+func (r *CacheRepo) Set(ctx context.Context, suggestions models.Suggestions, ttl time.Duration) error {
+	res := r.db.Set(ctx, r.key(suggestions.Id), suggestions, ttl)
+	if res.Err() != nil {
+		return fmt.Errorf("failed to execute query. error: %w", res.Err())
+	}
+	return nil
+}
+
+// Clear removes all cached suggestions with the prefix.
+func (r *CacheRepo) Clear(ctx context.Context) error {
+	iter := r.db.Scan(ctx, 0, r.prefix+":*", 100).Iterator()
+	keys := make([]string, 0, 100)
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("failed to execute query. error: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	res := r.db.Del(ctx, keys...)
+	if res.Err() != nil {
+		return fmt.Errorf("failed to execute query. error: %w", res.Err())
+	}
+	logger.Debugf("Removed %d cached suggestions with prefix %s.", res.Val(), r.prefix)
+	return nil
+}
+
+func (r *CacheRepo) key(id string) string {
+	return r.prefix + ":" + id
+}
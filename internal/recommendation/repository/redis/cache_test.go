@@ -0,0 +1,48 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/recommendation/models"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func TestCacheRoundTrip(t *testing.T) {
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	defer client.Close()
+	repo := NewCacheRepo(client, "similar")
+	ctx := context.Background()
+
+	if _, err := repo.Get(ctx, "witcher"); !errors.Is(err, models.ErrNoSuggestions) {
+		t.Fatalf("Get() of the missing key error = %v, want %v", err, models.ErrNoSuggestions)
+	}
+
+	want := models.Suggestions{
+		Id:        "witcher",
+		Games:     []models.Suggestion{{GameId: "1", Slug: "hades", Title: "Hades", Score: 0.8, Reasons: []string{"same genre"}}},
+		UpdatedAt: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	if err := repo.Set(ctx, want, time.Hour); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	got, err := repo.Get(ctx, "witcher")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Get() = %+v, want %+v", got, want)
+	}
+
+	if err := repo.Clear(ctx); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if _, err := repo.Get(ctx, "witcher"); !errors.Is(err, models.ErrNoSuggestions) {
+		t.Errorf("Get() after Clear() error = %v, want %v", err, models.ErrNoSuggestions)
+	}
+}
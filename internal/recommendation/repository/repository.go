@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/recommendation/models"
+	suggestion "github.com/Alexander272/games-library/internal/recommendation/repository/mongo"
+	cache "github.com/Alexander272/games-library/internal/recommendation/repository/redis"
+	"github.com/go-redis/redis/v8"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ISuggestion interface {
+	Get(ctx context.Context, id string) (models.Suggestions, error)
+	Save(ctx context.Context, suggestions []models.Suggestions) error
+	RemoveOlder(ctx context.Context, before time.Time) error
+}
+
+type IRating interface {
+	Iterate(ctx context.Context, fn func(models.Rating) error) error
+}
+
+type ICache interface {
+	Get(ctx context.Context, id string) (models.Suggestions, error)
+	Set(ctx context.Context, suggestions models.Suggestions, ttl time.Duration) error
+	Clear(ctx context.Context) error
+}
+
+func NewSuggestionRepo(db *mongo.Database, collection string) ISuggestion {
+	return suggestion.NewSuggestionRepo(db, collection)
+}
+
+func NewRatingRepo(db *mongo.Database, libraryCollection string) IRating {
+	return suggestion.NewRatingRepo(db, libraryCollection)
+}
+
+func NewCacheRepo(db *redis.Client, prefix string) ICache {
+	return cache.NewCacheRepo(db, prefix)
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+	gameRepo "github.com/Alexander272/games-library/internal/game/repository"
+	"github.com/Alexander272/games-library/internal/recommendation/models"
+	"github.com/Alexander272/games-library/internal/recommendation/repository"
+	"github.com/Alexander272/games-library/pkg/lifecycle"
+	"github.com/Alexander272/games-library/pkg/logger"
+)
+
+const (
+	// suggestionsLimit is the number of suggestions saved for a game or a user.
+	suggestionsLimit = 20
+	defaultInterval  = 6 * time.Hour
+)
+
+// CalculationService calculates similar games and personal recommendations offline
+// and saves them to the database.
+type CalculationService struct {
+	games            gameRepo.IGame
+	ratings          repository.IRating
+	similar          repository.ISuggestion
+	recommended      repository.ISuggestion
+	similarCache     repository.ICache
+	recommendedCache repository.ICache
+	background       *lifecycle.Background
+
+	running int32
+}
+
+func NewCalculationService(games gameRepo.IGame, ratings repository.IRating, similar, recommended repository.ISuggestion,
+	similarCache, recommendedCache repository.ICache, background *lifecycle.Background,
+) *CalculationService {
+	return &CalculationService{
+		games:            games,
+		ratings:          ratings,
+		similar:          similar,
+		recommended:      recommended,
+		similarCache:     similarCache,
+		recommendedCache: recommendedCache,
+		background:       background,
+	}
+}
+
+// Calculate recalculates all suggestions. Only one calculation runs at a time.
+func (s *CalculationService) Calculate(ctx context.Context) error {
+	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
+		return models.ErrCalculationRunning
+	}
+	defer atomic.StoreInt32(&s.running, 0)
+
+	return s.calculate(ctx)
+}
+
+// Start runs the calculation in the background.
+func (s *CalculationService) Start() error {
+	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
+		return models.ErrCalculationRunning
+	}
+
+	s.background.Go(func(ctx context.Context) {
+		defer atomic.StoreInt32(&s.running, 0)
+		if err := s.calculate(ctx); err != nil {
+			logger.Errorf("failed to calculate suggestions. error: %s", err.Error())
+		}
+	})
+	return nil
+}
+
+// Run recalculates suggestions every interval until the context is cancelled.
+func (s *CalculationService) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Calculate(ctx); err != nil {
+			logger.Errorf("failed to calculate suggestions. error: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *CalculationService) calculate(ctx context.Context) error {
+	start := time.Now()
+
+	var games []gameInfo
+	index := make(map[string]int)
+	err := s.games.Export(ctx, gameModels.GameFilter{}, func(game gameModels.Game) error {
+		index[game.Id] = len(games)
+		games = append(games, newGameInfo(game))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get games. error: %w", err)
+	}
+
+	users := make(map[string]map[int]int)
+	err = s.ratings.Iterate(ctx, func(r models.Rating) error {
+		g, ok := index[r.GameId]
+		if !ok {
+			return nil
+		}
+		if users[r.UserId] == nil {
+			users[r.UserId] = make(map[int]int)
+		}
+		users[r.UserId][g] = r.Rating
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get ratings. error: %w", err)
+	}
+
+	similar := contentSimilar(games, suggestionsLimit)
+	similarDocs := make([]models.Suggestions, 0, len(games))
+	for i, g := range games {
+		if len(similar[i]) == 0 {
+			continue
+		}
+		similarDocs = append(similarDocs, models.Suggestions{Id: g.id, Games: toSuggestions(games, similar[i]), UpdatedAt: start})
+	}
+
+	itemSim := itemSimilarity(users)
+	recommendedDocs := make([]models.Suggestions, 0, len(users))
+	for userId, library := range users {
+		matches := recommend(library, itemSim, similar, games, suggestionsLimit)
+		if len(matches) == 0 {
+			continue
+		}
+		recommendedDocs = append(recommendedDocs, models.Suggestions{Id: userId, Games: toSuggestions(games, matches), UpdatedAt: start})
+	}
+
+	if err := s.save(ctx, s.similar, s.similarCache, similarDocs, start); err != nil {
+		return fmt.Errorf("failed to save similar games. error: %w", err)
+	}
+	if err := s.save(ctx, s.recommended, s.recommendedCache, recommendedDocs, start); err != nil {
+		return fmt.Errorf("failed to save recommendations. error: %w", err)
+	}
+
+	logger.Infof("Calculated similar games for %d games and recommendations for %d users in %s.",
+		len(similarDocs), len(recommendedDocs), time.Since(start))
+	return nil
+}
+
+// save replaces the old suggestions and drops the cache, so the next request reads the new ones.
+func (s *CalculationService) save(ctx context.Context, repo repository.ISuggestion, cache repository.ICache,
+	docs []models.Suggestions, calculatedAt time.Time,
+) error {
+	if err := repo.Save(ctx, docs); err != nil {
+		return err
+	}
+	if err := repo.RemoveOlder(ctx, calculatedAt); err != nil {
+		return err
+	}
+	if err := cache.Clear(ctx); err != nil {
+		logger.Errorf("failed to clear suggestions cache. error: %s", err.Error())
+	}
+	return nil
+}
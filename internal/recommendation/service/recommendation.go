@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/recommendation/models"
+	"github.com/Alexander272/games-library/internal/recommendation/repository"
+	"github.com/Alexander272/games-library/pkg/logger"
+)
+
+const defaultLimit = 10
+
+type RecommendationService struct {
+	similar          repository.ISuggestion
+	recommended      repository.ISuggestion
+	similarCache     repository.ICache
+	recommendedCache repository.ICache
+	cacheTTL         time.Duration
+}
+
+func NewRecommendationService(similar, recommended repository.ISuggestion, similarCache, recommendedCache repository.ICache,
+	cacheTTL time.Duration,
+) *RecommendationService {
+	return &RecommendationService{
+		similar:          similar,
+		recommended:      recommended,
+		similarCache:     similarCache,
+		recommendedCache: recommendedCache,
+		cacheTTL:         cacheTTL,
+	}
+}
+
+// GetSimilar returns games with shared genres, tags and developers.
+func (s *RecommendationService) GetSimilar(ctx context.Context, gameId string, limit int) ([]models.Suggestion, error) {
+	return s.get(ctx, s.similar, s.similarCache, gameId, limit)
+}
+
+// GetForUser returns games liked by players with the same taste and games similar to the ones the user liked.
+func (s *RecommendationService) GetForUser(ctx context.Context, userId string, limit int) ([]models.Suggestion, error) {
+	return s.get(ctx, s.recommended, s.recommendedCache, userId, limit)
+}
+
+func (s *RecommendationService) get(ctx context.Context, repo repository.ISuggestion, cache repository.ICache, id string, limit int) ([]models.Suggestion, error) {
+	suggestions, err := cache.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, models.ErrNoSuggestions) {
+			logger.Errorf("failed to get cached suggestions. error: %s", err.Error())
+		}
+
+		suggestions, err = repo.Get(ctx, id)
+		if err != nil {
+			if errors.Is(err, models.ErrNoSuggestions) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to get suggestions. error: %w", err)
+		}
+
+		if err := cache.Set(ctx, suggestions, s.cacheTTL); err != nil {
+			logger.Errorf("failed to cache suggestions. error: %s", err.Error())
+		}
+	}
+
+	if len(suggestions.Games) == 0 {
+		return nil, models.ErrNoSuggestions
+	}
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if len(suggestions.Games) > limit {
+		return suggestions.Games[:limit], nil
+	}
+	return suggestions.Games, nil
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Alexander272/games-library/internal/recommendation/models"
+)
+
+type IRecommendation interface {
+	GetSimilar(ctx context.Context, gameId string, limit int) ([]models.Suggestion, error)
+	GetForUser(ctx context.Context, userId string, limit int) ([]models.Suggestion, error)
+}
+
+type ICalculation interface {
+	Calculate(ctx context.Context) error
+	Start() error
+	Run(ctx context.Context, interval time.Duration)
+}
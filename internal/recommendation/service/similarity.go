@@ -0,0 +1,285 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/internal/recommendation/models"
+)
+
+// Weights of the game features in content-based similarity.
+const (
+	genreWeight     = 1.0
+	tagWeight       = 1.0
+	developerWeight = 2.0
+	publisherWeight = 0.5
+)
+
+const (
+	// likedRating is the lowest rating meaning the user liked the game.
+	likedRating = 7
+	// contentWeight lowers the score of games similar by content in personal recommendations,
+	// so games liked by players with the same taste come first.
+	contentWeight = 0.5
+	// maxReasons is the number of explanations kept for a suggestion.
+	maxReasons = 3
+)
+
+type gameInfo struct {
+	id        string
+	slug      string
+	title     string
+	genres    []string
+	tags      []string
+	developer string
+	publisher string
+	// features are lowercased genres, tags, developer and publisher with their weights.
+	features map[string]float64
+}
+
+func newGameInfo(game gameModels.Game) gameInfo {
+	info := gameInfo{
+		id:        game.Id,
+		slug:      game.Slug,
+		title:     game.Title,
+		genres:    game.Genres,
+		tags:      game.Tags,
+		developer: game.Developer,
+		publisher: game.Publisher,
+		features:  make(map[string]float64),
+	}
+
+	for _, g := range game.Genres {
+		info.features["genre:"+strings.ToLower(g)] = genreWeight
+	}
+	for _, t := range game.Tags {
+		info.features["tag:"+strings.ToLower(t)] = tagWeight
+	}
+	if game.Developer != "" {
+		info.features["developer:"+strings.ToLower(game.Developer)] = developerWeight
+	}
+	if game.Publisher != "" {
+		info.features["publisher:"+strings.ToLower(game.Publisher)] = publisherWeight
+	}
+	return info
+}
+
+type match struct {
+	index   int
+	score   float64
+	reasons []string
+}
+
+// contentSimilar finds the most similar games for every game. Similarity is the weighted
+// Jaccard index of the game features, only games with at least one shared feature are compared.
+func contentSimilar(games []gameInfo, limit int) [][]match {
+	index := make(map[string][]int)
+	weights := make([]float64, len(games))
+	for i, g := range games {
+		for f, w := range g.features {
+			index[f] = append(index[f], i)
+			weights[i] += w
+		}
+	}
+
+	result := make([][]match, len(games))
+	for i, g := range games {
+		shared := make(map[int]float64)
+		for f, w := range g.features {
+			for _, j := range index[f] {
+				if j != i {
+					shared[j] += w
+				}
+			}
+		}
+
+		matches := make([]match, 0, len(shared))
+		for j, s := range shared {
+			matches = append(matches, match{index: j, score: s / (weights[i] + weights[j] - s)})
+		}
+		matches = top(matches, limit)
+		for k := range matches {
+			matches[k].reasons = contentReasons(g, games[matches[k].index])
+		}
+		result[i] = matches
+	}
+	return result
+}
+
+func contentReasons(a, b gameInfo) []string {
+	reasons := make([]string, 0, 4)
+	if a.developer != "" && strings.EqualFold(a.developer, b.developer) {
+		reasons = append(reasons, fmt.Sprintf("Same developer: %s", b.developer))
+	}
+	if genres := intersect(a.genres, b.genres); len(genres) > 0 {
+		reasons = append(reasons, fmt.Sprintf("Shared genres: %s", strings.Join(genres, ", ")))
+	}
+	if tags := intersect(a.tags, b.tags); len(tags) > 0 {
+		reasons = append(reasons, fmt.Sprintf("Shared tags: %s", strings.Join(tags, ", ")))
+	}
+	if a.publisher != "" && strings.EqualFold(a.publisher, b.publisher) {
+		reasons = append(reasons, fmt.Sprintf("Same publisher: %s", b.publisher))
+	}
+	return reasons
+}
+
+// itemSimilarity calculates the adjusted cosine similarity of games over the ratings of users.
+// Ratings are centred on the user's mean rating, so a game rated low by a user counts against
+// the games the user rated high. Only positive similarities are kept.
+func itemSimilarity(users map[string]map[int]int) map[int]map[int]float64 {
+	dots := make(map[[2]int]float64)
+	norms := make(map[int]float64)
+
+	for _, library := range users {
+		rated := make([]int, 0, len(library))
+		sum := 0
+		for g, r := range library {
+			if r > 0 {
+				rated = append(rated, g)
+				sum += r
+			}
+		}
+		if len(rated) < 2 {
+			continue
+		}
+		sort.Ints(rated)
+		mean := float64(sum) / float64(len(rated))
+
+		centred := make([]float64, len(rated))
+		for k, g := range rated {
+			centred[k] = float64(library[g]) - mean
+			norms[g] += centred[k] * centred[k]
+		}
+		for a := 0; a < len(rated); a++ {
+			for b := a + 1; b < len(rated); b++ {
+				dots[[2]int{rated[a], rated[b]}] += centred[a] * centred[b]
+			}
+		}
+	}
+
+	sim := make(map[int]map[int]float64)
+	for pair, dot := range dots {
+		norm := math.Sqrt(norms[pair[0]] * norms[pair[1]])
+		if dot <= 0 || norm == 0 {
+			continue
+		}
+		s := dot / norm
+		for _, p := range [][2]int{pair, {pair[1], pair[0]}} {
+			if sim[p[0]] == nil {
+				sim[p[0]] = make(map[int]float64)
+			}
+			sim[p[0]][p[1]] = s
+		}
+	}
+	return sim
+}
+
+type contribution struct {
+	game          int
+	rating        int
+	score         float64
+	collaborative bool
+}
+
+// recommend scores games the user doesn't have in the library by their similarity to the games the user liked.
+func recommend(library map[int]int, itemSim map[int]map[int]float64, similar [][]match, games []gameInfo, limit int) []match {
+	scores := make(map[int]float64)
+	because := make(map[int][]contribution)
+	add := func(j int, c contribution) {
+		if _, ok := library[j]; ok {
+			return
+		}
+		scores[j] += c.score
+		because[j] = append(because[j], c)
+	}
+
+	for g, r := range library {
+		if r < likedRating {
+			continue
+		}
+		w := float64(r) / 10
+		for j, s := range itemSim[g] {
+			add(j, contribution{game: g, rating: r, score: s * w, collaborative: true})
+		}
+		for _, m := range similar[g] {
+			add(m.index, contribution{game: g, rating: r, score: contentWeight * m.score * w})
+		}
+	}
+
+	matches := make([]match, 0, len(scores))
+	for j, s := range scores {
+		matches = append(matches, match{index: j, score: s})
+	}
+	matches = top(matches, limit)
+
+	for k, m := range matches {
+		contributions := because[m.index]
+		sort.Slice(contributions, func(a, b int) bool { return contributions[a].score > contributions[b].score })
+
+		seen := make(map[string]bool)
+		for _, c := range contributions {
+			var reason string
+			if c.collaborative {
+				reason = fmt.Sprintf("Players who liked %s also liked it", games[c.game].title)
+			} else {
+				reason = fmt.Sprintf("Similar to %s, which you rated %d", games[c.game].title, c.rating)
+			}
+			if seen[reason] {
+				continue
+			}
+			seen[reason] = true
+			matches[k].reasons = append(matches[k].reasons, reason)
+			if len(matches[k].reasons) == maxReasons {
+				break
+			}
+		}
+	}
+	return matches
+}
+
+func toSuggestions(games []gameInfo, matches []match) []models.Suggestion {
+	suggestions := make([]models.Suggestion, 0, len(matches))
+	for _, m := range matches {
+		g := games[m.index]
+		suggestions = append(suggestions, models.Suggestion{
+			GameId:  g.id,
+			Slug:    g.slug,
+			Title:   g.title,
+			Score:   math.Round(m.score*1000) / 1000,
+			Reasons: m.reasons,
+		})
+	}
+	return suggestions
+}
+
+// top sorts matches by score and keeps the first limit ones.
+func top(matches []match, limit int) []match {
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].score != matches[b].score {
+			return matches[a].score > matches[b].score
+		}
+		return matches[a].index < matches[b].index
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+func intersect(a, b []string) []string {
+	set := make(map[string]bool, len(b))
+	for _, v := range b {
+		set[strings.ToLower(v)] = true
+	}
+
+	res := make([]string, 0)
+	for _, v := range a {
+		if set[strings.ToLower(v)] {
+			res = append(res, v)
+		}
+	}
+	return res
+}
@@ -0,0 +1,112 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/Alexander272/games-library/internal/recommendation/models"
+	"github.com/Alexander272/games-library/internal/service"
+	"github.com/Alexander272/games-library/internal/transport/middleware"
+	"github.com/Alexander272/games-library/internal/transport/validation"
+	userModels "github.com/Alexander272/games-library/internal/user/models"
+	"github.com/gin-gonic/gin"
+)
+
+type Handler struct {
+	services   *service.Services
+	middleware *middleware.Middleware
+}
+
+func NewHandler(services *service.Services, middleware *middleware.Middleware) *Handler {
+	return &Handler{
+		services:   services,
+		middleware: middleware,
+	}
+}
+
+func (h *Handler) Init(api *gin.RouterGroup) {
+	api.GET("/games/:id/similar", h.middleware.ObjectIds("id"), h.getSimilar)
+	api.GET("/users/me/recommendations", h.middleware.UserIdentity, h.getForUser)
+	api.POST("/recommendations/calculate", h.middleware.UserIdentity, h.middleware.AccessForRoles(userModels.RoleAdmin), h.calculate)
+}
+
+// @Summary Similar
+// @Tags recommendations
+// @Description похожие игры по общим жанрам, тегам, разработчику и издателю с объяснением, почему они выбраны
+// @ID getSimilarGames
+// @Accept json
+// @Produce json
+// @Param id path string true "game id"
+// @Param filter query models.SuggestionFilter false "filter"
+// @Success 200 {object} dataResponse{data=[]models.Suggestion}
+// @Failure 400,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /games/{id}/similar [get]
+func (h *Handler) getSimilar(c *gin.Context) {
+	var filter models.SuggestionFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(validation.Query(err))
+		return
+	}
+
+	games, err := h.services.Recommendation.GetSimilar(c, c.Param("id"), filter.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: games, Count: int64(len(games))})
+}
+
+// @Summary Recommendations
+// @Security ApiKeyAuth
+// @Tags recommendations
+// @Description персональные рекомендации на основе оценок пользователя и игроков с похожими вкусами
+// @ID getRecommendations
+// @Accept json
+// @Produce json
+// @Param filter query models.SuggestionFilter false "filter"
+// @Success 200 {object} dataResponse{data=[]models.Suggestion}
+// @Failure 400,401,404 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /users/me/recommendations [get]
+func (h *Handler) getForUser(c *gin.Context) {
+	userId, err := middleware.GetUserId(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var filter models.SuggestionFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.Error(validation.Query(err))
+		return
+	}
+
+	games, err := h.services.Recommendation.GetForUser(c, userId, filter.Limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dataResponse{Data: games, Count: int64(len(games))})
+}
+
+// @Summary Calculate
+// @Security ApiKeyAuth
+// @Tags recommendations
+// @Description запуск пересчета похожих игр и рекомендаций вне расписания
+// @ID calculateRecommendations
+// @Accept json
+// @Produce json
+// @Success 202 {object} response
+// @Failure 401,403,409 {object} response
+// @Failure 500 {object} response
+// @Failure default {object} response
+// @Router /recommendations/calculate [post]
+func (h *Handler) calculate(c *gin.Context) {
+	if err := h.services.Calculation.Start(); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusAccepted, response{Message: "Calculation started"})
+}
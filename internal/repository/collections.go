@@ -1,5 +1,27 @@
 package repository
 
 const (
-	usersCollection = "users"
+	usersCollection             = "users"
+	gamesCollection             = "games"
+	importJobsCollection        = "import_jobs"
+	libraryCollection           = "library"
+	achievementsCollection      = "achievements"
+	userAchievementsCollection  = "user_achievements"
+	playSessionsCollection      = "play_sessions"
+	loansCollection             = "loans"
+	similarGamesCollection      = "similar_games"
+	recommendationsCollection   = "recommendations"
+	listsCollection             = "lists"
+	priceObservationsCollection = "price_observations"
+	priceAlertsCollection       = "price_alerts"
+	gameRedirectsCollection     = "game_redirects"
+	termsCollection             = "terms"
+	companiesCollection         = "companies"
+	duplicatesCollection        = "duplicate_candidates"
+)
+
+// Prefixes of the cache keys in redis.
+const (
+	similarCachePrefix     = "similar"
+	recommendedCachePrefix = "recommendations"
 )
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// indexer is the repo that has the indexes the queries or the consistency rely on.
+type indexer interface {
+	EnsureIndexes(ctx context.Context) error
+}
+
+// EnsureIndexes creates the indexes of the repos before the requests are served. Existing indexes
+// are kept, so it's safe to call on every start. Repos missing in the set, e.g. of the import, are skipped.
+func (r *Repo) EnsureIndexes(ctx context.Context) error {
+	repos := []struct {
+		collection string
+		repo       indexer
+	}{
+		{gamesCollection, r.Game},
+		{companiesCollection, r.Company},
+		{libraryCollection, r.Library},
+		{loansCollection, r.Loan},
+	}
+	for _, r := range repos {
+		if r.repo == nil {
+			continue
+		}
+		if err := r.repo.EnsureIndexes(ctx); err != nil {
+			return fmt.Errorf("failed to create indexes of %s. error: %w", r.collection, err)
+		}
+	}
+	return nil
+}
@@ -1,19 +1,96 @@
 package repository
 
 import (
+	"github.com/Alexander272/games-library/internal/achievement"
+	"github.com/Alexander272/games-library/internal/duplicate"
+	"github.com/Alexander272/games-library/internal/game"
+	"github.com/Alexander272/games-library/internal/lending"
+	"github.com/Alexander272/games-library/internal/library"
+	"github.com/Alexander272/games-library/internal/list"
+	"github.com/Alexander272/games-library/internal/playtime"
+	"github.com/Alexander272/games-library/internal/price"
+	"github.com/Alexander272/games-library/internal/recommendation"
 	"github.com/Alexander272/games-library/internal/user"
 	"github.com/go-redis/redis/v8"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type Repo struct {
-	Session user.ISesRepo
-	User    user.IUserRepo
+	Session     user.ISesRepo
+	User        user.IUserRepo
+	Game        game.IGameRepo
+	Import      game.IImportRepo
+	Redirect    game.IRedirectRepo
+	Company     game.ICompanyRepo
+	Term        game.ITermRepo
+	Library     library.ILibraryRepo
+	Achievement achievement.IAchievementRepo
+	Unlock      achievement.IUnlockRepo
+	PlaySession playtime.ISessionRepo
+	Loan        lending.ILoanRepo
+	List        list.IListRepo
+
+	PriceObservation price.IObservationRepo
+	PriceAlert       price.IAlertRepo
+
+	Duplicate duplicate.ICandidateRepo
+	Merge     duplicate.IMergeRepo
+
+	Similar          recommendation.ISuggestionRepo
+	Recommended      recommendation.ISuggestionRepo
+	Rating           recommendation.IRatingRepo
+	SimilarCache     recommendation.ICacheRepo
+	RecommendedCache recommendation.ICacheRepo
 }
 
 func NewRepo(db *mongo.Database, redis *redis.Client) *Repo {
 	return &Repo{
-		Session: user.NewSessionRepo(redis),
-		User:    user.NewUserRepo(db, usersCollection),
+		Session:     user.NewSessionRepo(redis),
+		User:        user.NewUserRepo(db, usersCollection),
+		Game:        game.NewGameRepo(db, gamesCollection),
+		Import:      game.NewImportRepo(db, importJobsCollection),
+		Redirect:    game.NewRedirectRepo(db, gameRedirectsCollection),
+		Company:     game.NewCompanyRepo(db, companiesCollection),
+		Term:        game.NewTermRepo(db, termsCollection),
+		Library:     library.NewLibraryRepo(db, libraryCollection, gamesCollection),
+		Achievement: achievement.NewAchievementRepo(db, achievementsCollection),
+		Unlock:      achievement.NewUnlockRepo(db, userAchievementsCollection, achievementsCollection, gamesCollection),
+		PlaySession: playtime.NewSessionRepo(db, playSessionsCollection, gamesCollection),
+		Loan:        lending.NewLoanRepo(db, loansCollection, gamesCollection),
+		List:        list.NewListRepo(db, listsCollection, gamesCollection),
+
+		PriceObservation: price.NewObservationRepo(db, priceObservationsCollection),
+		PriceAlert:       price.NewAlertRepo(db, priceAlertsCollection),
+
+		Duplicate: duplicate.NewCandidateRepo(db, duplicatesCollection, gamesCollection),
+		Merge: duplicate.NewMergeRepo(db, duplicate.Collections{
+			Games:        gamesCollection,
+			Redirects:    gameRedirectsCollection,
+			Candidates:   duplicatesCollection,
+			Library:      libraryCollection,
+			Achievements: achievementsCollection,
+			Unlocks:      userAchievementsCollection,
+			Sessions:     playSessionsCollection,
+			Loans:        loansCollection,
+			Lists:        listsCollection,
+			Prices:       priceObservationsCollection,
+			Alerts:       priceAlertsCollection,
+		}),
+
+		Similar:          recommendation.NewSuggestionRepo(db, similarGamesCollection),
+		Recommended:      recommendation.NewSuggestionRepo(db, recommendationsCollection),
+		Rating:           recommendation.NewRatingRepo(db, libraryCollection),
+		SimilarCache:     recommendation.NewCacheRepo(redis, similarCachePrefix),
+		RecommendedCache: recommendation.NewCacheRepo(redis, recommendedCachePrefix),
+	}
+}
+
+// NewImportRepo creates only the repos of the import, so the import command doesn't need redis.
+func NewImportRepo(db *mongo.Database) *Repo {
+	return &Repo{
+		Game:     game.NewGameRepo(db, gamesCollection),
+		Import:   game.NewImportRepo(db, importJobsCollection),
+		Redirect: game.NewRedirectRepo(db, gameRedirectsCollection),
+		Company:  game.NewCompanyRepo(db, companiesCollection),
 	}
 }
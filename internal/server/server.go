@@ -23,6 +23,18 @@ func NewServer(conf *config.Config, handler http.Handler) *Server {
 	}
 }
 
+// NewAdminServer creates the server of the admin port, e.g. for the metrics.
+func NewAdminServer(conf *config.Config, handler http.Handler) *Server {
+	return &Server{
+		httpServer: &http.Server{
+			Addr:         ":" + conf.Metrics.Port,
+			Handler:      handler,
+			ReadTimeout:  conf.Http.ReadTimeout,
+			WriteTimeout: conf.Http.WriteTimeout,
+		},
+	}
+}
+
 func (s *Server) Run() error {
 	return s.httpServer.ListenAndServe()
 }
@@ -3,29 +3,72 @@ package service
 import (
 	"time"
 
+	"github.com/Alexander272/games-library/internal/achievement"
+	"github.com/Alexander272/games-library/internal/duplicate"
+	"github.com/Alexander272/games-library/internal/game"
+	"github.com/Alexander272/games-library/internal/lending"
+	"github.com/Alexander272/games-library/internal/library"
+	"github.com/Alexander272/games-library/internal/list"
+	"github.com/Alexander272/games-library/internal/playtime"
+	"github.com/Alexander272/games-library/internal/price"
+	"github.com/Alexander272/games-library/internal/recommendation"
 	"github.com/Alexander272/games-library/internal/repository"
 	"github.com/Alexander272/games-library/internal/user"
 	"github.com/Alexander272/games-library/pkg/auth"
 	"github.com/Alexander272/games-library/pkg/hasher"
+	"github.com/Alexander272/games-library/pkg/lifecycle"
+	"github.com/Alexander272/games-library/pkg/notifier"
 	"github.com/Alexander272/games-library/pkg/storage"
 )
 
 type Services struct {
-	Auth user.IAuthService
-	User user.IUserService
+	Auth        user.IAuthService
+	User        user.IUserService
+	Game        game.IGameService
+	Import      game.IImportService
+	Company     game.ICompanyService
+	Term        game.ITermService
+	Library     library.ILibraryService
+	Achievement achievement.IAchievementService
+	Session     playtime.ISessionService
+	Stats       playtime.IStatsService
+	Loan        lending.ILoanService
+	Reminder    lending.IReminderService
+	List        list.IListService
+	ListItem    list.IItemService
+
+	Recommendation recommendation.IRecommendationService
+	Calculation    recommendation.ICalculationService
+
+	Price      price.IPriceService
+	PriceAlert price.IAlertService
+	PriceFeed  price.IFeedService
+
+	Duplicate duplicate.ICandidateService
+	Detection duplicate.IDetectionService
+	Merge     duplicate.IMergeService
 }
 
 type Deps struct {
 	Repos           *repository.Repo
 	StorageProvider storage.Provider
+	Notifier        notifier.Notifier
 	Hasher          hasher.IPasswordHasher
 	TokenManager    auth.ITokenManager
 	AccessTokenTTL  time.Duration
 	RefreshTokenTTL time.Duration
+	CacheTTL        time.Duration
+	PriceFeedDir    string
 	Domain          string
+	// Background runs the jobs started by the requests, they are stopped with it on shutdown
+	Background *lifecycle.Background
 }
 
 func NewServices(deps Deps) *Services {
+	if deps.Background == nil {
+		deps.Background = lifecycle.NewBackground()
+	}
+
 	return &Services{
 		Auth: user.NewAuthService(
 			deps.Repos.User,
@@ -36,6 +79,52 @@ func NewServices(deps Deps) *Services {
 			deps.RefreshTokenTTL,
 			deps.Domain,
 		),
-		User: user.NewUserService(deps.Repos.User, deps.Hasher),
+		User:        user.NewUserService(deps.Repos.User, deps.Hasher),
+		Game:        game.NewGameService(deps.Repos.Game, deps.Repos.Redirect, deps.Repos.Term, deps.Repos.Company),
+		Import:      game.NewImportService(deps.Repos.Game, deps.Repos.Redirect, deps.Repos.Company, deps.Repos.Import, deps.Background),
+		Company:     game.NewCompanyService(deps.Repos.Company, deps.Repos.Game),
+		Term:        game.NewTermService(deps.Repos.Term),
+		Library:     library.NewLibraryService(deps.Repos.Library, deps.Repos.Game),
+		Achievement: achievement.NewAchievementService(deps.Repos.Achievement, deps.Repos.Unlock, deps.Repos.Game, deps.StorageProvider),
+		Session:     playtime.NewSessionService(deps.Repos.PlaySession, deps.Repos.Game),
+		Stats:       playtime.NewStatsService(deps.Repos.PlaySession),
+		Loan:        lending.NewLoanService(deps.Repos.Loan, deps.Repos.Library, deps.Repos.User),
+		Reminder:    lending.NewReminderService(deps.Repos.Loan, deps.Repos.User, deps.Notifier),
+		List:        list.NewListService(deps.Repos.List),
+		ListItem:    list.NewItemService(deps.Repos.List, deps.Repos.Game),
+
+		Recommendation: recommendation.NewRecommendationService(
+			deps.Repos.Similar,
+			deps.Repos.Recommended,
+			deps.Repos.SimilarCache,
+			deps.Repos.RecommendedCache,
+			deps.CacheTTL,
+		),
+		Calculation: recommendation.NewCalculationService(
+			deps.Repos.Game,
+			deps.Repos.Rating,
+			deps.Repos.Similar,
+			deps.Repos.Recommended,
+			deps.Repos.SimilarCache,
+			deps.Repos.RecommendedCache,
+			deps.Background,
+		),
+
+		Price:      price.NewPriceService(deps.Repos.PriceObservation),
+		PriceAlert: price.NewAlertService(deps.Repos.PriceAlert, deps.Repos.Library),
+		PriceFeed: price.NewFeedService(
+			deps.Repos.PriceObservation,
+			deps.Repos.PriceAlert,
+			deps.Repos.Game,
+			deps.Repos.Library,
+			deps.Repos.User,
+			deps.Notifier,
+			deps.PriceFeedDir,
+			deps.Background,
+		),
+
+		Duplicate: duplicate.NewCandidateService(deps.Repos.Duplicate),
+		Detection: duplicate.NewDetectionService(deps.Repos.Game, deps.Repos.Duplicate, deps.Background),
+		Merge:     duplicate.NewMergeService(deps.Repos.Merge, deps.Repos.Duplicate, deps.Repos.Game, deps.Repos.Redirect),
 	}
 }
@@ -0,0 +1,180 @@
+// Package errcode is the catalogue of the stable error codes returned by the api.
+// The client branches on the code and shows the message translated to the locale of the request.
+package errcode
+
+import "github.com/Alexander272/games-library/pkg/locale"
+
+type Code string
+
+// general codes, used when the error has no code of its own
+const (
+	BadRequest      Code = "BAD_REQUEST"
+	Unauthorized    Code = "UNAUTHORIZED"
+	Forbidden       Code = "FORBIDDEN"
+	NotFound        Code = "NOT_FOUND"
+	Conflict        Code = "CONFLICT"
+	TooManyRequests Code = "TOO_MANY_REQUESTS"
+	Internal        Code = "INTERNAL_ERROR"
+)
+
+// request and auth codes
+const (
+	InvalidRequestBody Code = "INVALID_REQUEST_BODY"
+	InvalidQueryParams Code = "INVALID_QUERY_PARAMS"
+	InvalidPathParams  Code = "INVALID_PATH_PARAMS"
+	EmptyIdParam       Code = "EMPTY_ID_PARAM"
+	FileNotFound       Code = "FILE_NOT_FOUND"
+	FileOpenFailed     Code = "FILE_OPEN_FAILED"
+	CookieNotFound     Code = "COOKIE_NOT_FOUND"
+	EmptyAuthHeader    Code = "EMPTY_AUTH_HEADER"
+	InvalidAuthHeader  Code = "INVALID_AUTH_HEADER"
+	InvalidToken       Code = "INVALID_TOKEN"
+	InvalidSession     Code = "INVALID_SESSION"
+	AccessDenied       Code = "ACCESS_DENIED"
+	InvalidCredentials Code = "INVALID_CREDENTIALS"
+	InvalidForwarding  Code = "INVALID_FORWARDING_CHAIN"
+)
+
+// domain codes
+const (
+	UserNotFound Code = "USER_NOT_FOUND"
+	UserExists   Code = "USER_EXISTS"
+
+	GameNotFound     Code = "GAME_NOT_FOUND"
+	GameExists       Code = "GAME_EXISTS"
+	InvalidSlug      Code = "INVALID_SLUG"
+	ReleaseNotFound  Code = "RELEASE_NOT_FOUND"
+	RedirectNotFound Code = "REDIRECT_NOT_FOUND"
+	CompanyNotFound  Code = "COMPANY_NOT_FOUND"
+	CompanyExists    Code = "COMPANY_EXISTS"
+	TermNotFound     Code = "TERM_NOT_FOUND"
+	UnknownTermKind  Code = "UNKNOWN_TERM_KIND"
+	InvalidBarcode   Code = "INVALID_BARCODE"
+	ImportNotFound   Code = "IMPORT_JOB_NOT_FOUND"
+	UnknownFormat    Code = "UNKNOWN_FILE_FORMAT"
+	UnknownExport    Code = "UNKNOWN_EXPORT_FORMAT"
+
+	EntryNotFound Code = "LIBRARY_ENTRY_NOT_FOUND"
+	EntryExists   Code = "LIBRARY_ENTRY_EXISTS"
+
+	AchievementNotFound Code = "ACHIEVEMENT_NOT_FOUND"
+	NotUnlocked         Code = "ACHIEVEMENT_NOT_UNLOCKED"
+
+	SessionNotFound Code = "PLAY_SESSION_NOT_FOUND"
+	InvalidPeriod   Code = "INVALID_SESSION_PERIOD"
+
+	LoanNotFound    Code = "LOAN_NOT_FOUND"
+	NotPhysical     Code = "NOT_PHYSICAL_COPY"
+	AlreadyLent     Code = "ALREADY_LENT"
+	AlreadyReturned Code = "ALREADY_RETURNED"
+	NoBorrower      Code = "NO_BORROWER"
+	InvalidDueDate  Code = "INVALID_DUE_DATE"
+
+	NoSuggestions      Code = "NO_SUGGESTIONS"
+	CalculationRunning Code = "SUGGESTIONS_CALCULATION_RUNNING"
+	ListNotFound       Code = "LIST_NOT_FOUND"
+	ListItemNotFound   Code = "LIST_ITEM_NOT_FOUND"
+	ListItemExists     Code = "LIST_ITEM_EXISTS"
+	InvalidListOrder   Code = "INVALID_LIST_ORDER"
+	ListChanged        Code = "LIST_CHANGED"
+	PriceNotFound      Code = "PRICE_NOT_FOUND"
+	AlertNotFound      Code = "PRICE_ALERT_NOT_FOUND"
+	NotInWishlist      Code = "NOT_IN_WISHLIST"
+	IngestRunning      Code = "PRICE_INGEST_RUNNING"
+	UnknownFeedFile    Code = "UNKNOWN_FEED_FILE"
+	CandidateNotFound  Code = "DUPLICATE_CANDIDATE_NOT_FOUND"
+	DetectionRunning   Code = "DUPLICATE_DETECTION_RUNNING"
+	SameGame           Code = "SAME_GAME_MERGE"
+	MergeUnsupported   Code = "MERGE_UNSUPPORTED"
+)
+
+// messages are the texts of the codes by locale. Every code has at least the default locale.
+var messages = map[Code]map[string]string{
+	BadRequest:      {locale.En: "bad request", locale.Ru: "некорректный запрос"},
+	Unauthorized:    {locale.En: "unauthorized", locale.Ru: "требуется авторизация"},
+	Forbidden:       {locale.En: "forbidden", locale.Ru: "доступ запрещен"},
+	NotFound:        {locale.En: "not found", locale.Ru: "не найдено"},
+	Conflict:        {locale.En: "conflict", locale.Ru: "конфликт"},
+	TooManyRequests: {locale.En: "too many requests", locale.Ru: "слишком много запросов"},
+	Internal:        {locale.En: "internal server error", locale.Ru: "внутренняя ошибка сервера"},
+
+	InvalidRequestBody: {locale.En: "invalid request body", locale.Ru: "некорректное тело запроса"},
+	InvalidQueryParams: {locale.En: "invalid query params", locale.Ru: "некорректные параметры запроса"},
+	InvalidPathParams:  {locale.En: "invalid path params", locale.Ru: "некорректные параметры пути"},
+	EmptyIdParam:       {locale.En: "empty id param", locale.Ru: "не указан id"},
+	FileNotFound:       {locale.En: "file not found", locale.Ru: "файл не найден"},
+	FileOpenFailed:     {locale.En: "failed to open file", locale.Ru: "не удалось открыть файл"},
+	CookieNotFound:     {locale.En: "cookie not found", locale.Ru: "cookie не найден"},
+	EmptyAuthHeader:    {locale.En: "empty auth header", locale.Ru: "пустой заголовок авторизации"},
+	InvalidAuthHeader:  {locale.En: "invalid auth header", locale.Ru: "некорректный заголовок авторизации"},
+	InvalidToken:       {locale.En: "token is invalid", locale.Ru: "недействительный токен"},
+	InvalidSession:     {locale.En: "session is expired or doesn't exists", locale.Ru: "сессия истекла или не существует"},
+	AccessDenied:       {locale.En: "access denied", locale.Ru: "доступ запрещен"},
+	InvalidCredentials: {locale.En: "invalid credentials", locale.Ru: "неверный email или пароль"},
+	InvalidForwarding:  {locale.En: "invalid forwarding headers of the proxy", locale.Ru: "некорректные заголовки прокси"},
+
+	UserNotFound: {locale.En: "user doesn't exists", locale.Ru: "пользователь не найден"},
+	UserExists:   {locale.En: "user with the same email already exists", locale.Ru: "пользователь с таким email уже существует"},
+
+	GameNotFound:     {locale.En: "game doesn't exists", locale.Ru: "игра не найдена"},
+	GameExists:       {locale.En: "game with the same slug already exists", locale.Ru: "игра с таким slug уже существует"},
+	InvalidSlug:      {locale.En: "slug must contain only lowercase latin letters, digits and dashes", locale.Ru: "slug может содержать только строчные латинские буквы, цифры и дефисы"},
+	ReleaseNotFound:  {locale.En: "release doesn't exists", locale.Ru: "издание не найдено"},
+	RedirectNotFound: {locale.En: "redirect doesn't exists", locale.Ru: "перенаправление не найдено"},
+	CompanyNotFound:  {locale.En: "company doesn't exists", locale.Ru: "компания не найдена"},
+	CompanyExists:    {locale.En: "company with the same name or slug already exists", locale.Ru: "компания с таким названием или slug уже существует"},
+	TermNotFound:     {locale.En: "term doesn't exists", locale.Ru: "термин не найден"},
+	UnknownTermKind:  {locale.En: "unknown term kind, supported are genre, tag and platform", locale.Ru: "неизвестный вид термина, поддерживаются genre, tag и platform"},
+	InvalidBarcode:   {locale.En: "invalid EAN barcode", locale.Ru: "некорректный штрихкод EAN"},
+	ImportNotFound:   {locale.En: "import job doesn't exists", locale.Ru: "задача импорта не найдена"},
+	UnknownFormat:    {locale.En: "unknown file format", locale.Ru: "неизвестный формат файла"},
+	UnknownExport:    {locale.En: "unknown export format", locale.Ru: "неизвестный формат выгрузки"},
+
+	EntryNotFound: {locale.En: "library entry doesn't exists", locale.Ru: "игры нет в библиотеке"},
+	EntryExists:   {locale.En: "game is already in the library", locale.Ru: "игра уже есть в библиотеке"},
+
+	AchievementNotFound: {locale.En: "achievement doesn't exists", locale.Ru: "достижение не найдено"},
+	NotUnlocked:         {locale.En: "achievement isn't unlocked", locale.Ru: "достижение не получено"},
+
+	SessionNotFound: {locale.En: "play session doesn't exists", locale.Ru: "игровая сессия не найдена"},
+	InvalidPeriod:   {locale.En: "end of the session must be after the start", locale.Ru: "конец сессии должен быть позже начала"},
+
+	LoanNotFound:    {locale.En: "loan doesn't exists", locale.Ru: "запись о выдаче не найдена"},
+	NotPhysical:     {locale.En: "only physical copies can be lent", locale.Ru: "выдавать можно только физические копии"},
+	AlreadyLent:     {locale.En: "copy is already lent", locale.Ru: "копия уже выдана"},
+	AlreadyReturned: {locale.En: "copy is already returned", locale.Ru: "копия уже возвращена"},
+	NoBorrower:      {locale.En: "borrower user or name is required", locale.Ru: "нужно указать пользователя или имя заемщика"},
+	InvalidDueDate:  {locale.En: "due date must be after the lent date", locale.Ru: "срок возврата должен быть позже даты выдачи"},
+
+	NoSuggestions:      {locale.En: "suggestions aren't calculated yet", locale.Ru: "рекомендации еще не рассчитаны"},
+	CalculationRunning: {locale.En: "suggestions are already being calculated", locale.Ru: "рекомендации уже рассчитываются"},
+	ListNotFound:       {locale.En: "list doesn't exists", locale.Ru: "список не найден"},
+	ListItemNotFound:   {locale.En: "game isn't in the list", locale.Ru: "игры нет в списке"},
+	ListItemExists:     {locale.En: "game is already in the list", locale.Ru: "игра уже есть в списке"},
+	InvalidListOrder:   {locale.En: "order must contain every game of the list exactly once", locale.Ru: "порядок должен содержать каждую игру списка ровно один раз"},
+	ListChanged:        {locale.En: "list was changed by another request, try again", locale.Ru: "список был изменен другим запросом, попробуйте еще раз"},
+	PriceNotFound:      {locale.En: "prices of the game aren't found", locale.Ru: "цены игры не найдены"},
+	AlertNotFound:      {locale.En: "price alert doesn't exists", locale.Ru: "оповещение о цене не найдено"},
+	NotInWishlist:      {locale.En: "price alerts can be set only for games in the wishlist", locale.Ru: "оповещения о цене доступны только для игр из списка желаемого"},
+	IngestRunning:      {locale.En: "price feed is already being ingested", locale.Ru: "загрузка цен уже выполняется"},
+	UnknownFeedFile:    {locale.En: "unknown feed file format, supported are json, jsonl and csv", locale.Ru: "неизвестный формат файла цен, поддерживаются json, jsonl и csv"},
+	CandidateNotFound:  {locale.En: "duplicate candidate doesn't exists", locale.Ru: "возможный дубликат не найден"},
+	DetectionRunning:   {locale.En: "duplicate detection is already running", locale.Ru: "поиск дубликатов уже выполняется"},
+	SameGame:           {locale.En: "game can't be merged into itself", locale.Ru: "игру нельзя объединить саму с собой"},
+	MergeUnsupported:   {locale.En: "merge needs a replica set of the database", locale.Ru: "для объединения нужна реплика базы данных"},
+}
+
+// Message returns the text of the code in the locale or in the default locale if there is no translation.
+// It reports whether the code is in the catalogue.
+func Message(code Code, loc string) (string, bool) {
+	m, ok := messages[code]
+	if !ok {
+		return "", false
+	}
+	for _, l := range locale.Chain(loc) {
+		if text, ok := m[l]; ok {
+			return text, true
+		}
+	}
+	return "", false
+}
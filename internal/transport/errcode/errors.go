@@ -0,0 +1,20 @@
+package errcode
+
+import "github.com/Alexander272/games-library/pkg/apperror"
+
+// errors of the requests, the errors of the domain are declared in the models of the modules
+var (
+	ErrInvalidRequestBody = apperror.Validation(string(InvalidRequestBody), "invalid request body")
+	ErrInvalidQueryParams = apperror.Validation(string(InvalidQueryParams), "invalid query params")
+	ErrInvalidPathParams  = apperror.Validation(string(InvalidPathParams), "invalid path params")
+	ErrEmptyIdParam       = apperror.Validation(string(EmptyIdParam), "empty id param")
+	ErrFileNotFound       = apperror.Validation(string(FileNotFound), "file not found")
+	ErrFileOpenFailed     = apperror.Validation(string(FileOpenFailed), "failed to open file")
+	ErrCookieNotFound     = apperror.Unauthorized(string(CookieNotFound), "cookie not found")
+
+	ErrEmptyAuthHeader   = apperror.Unauthorized(string(EmptyAuthHeader), "empty auth header")
+	ErrInvalidAuthHeader = apperror.Unauthorized(string(InvalidAuthHeader), "invalid auth header")
+	ErrInvalidToken      = apperror.Unauthorized(string(InvalidToken), "token is invalid")
+	ErrUnauthorized      = apperror.Unauthorized(string(Unauthorized), "unauthorized")
+	ErrAccessDenied      = apperror.Forbidden(string(AccessDenied), "access denied")
+)
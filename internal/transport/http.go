@@ -7,37 +7,76 @@ import (
 	"github.com/Alexander272/games-library/docs"
 	"github.com/Alexander272/games-library/internal/config"
 	"github.com/Alexander272/games-library/internal/service"
+	"github.com/Alexander272/games-library/internal/transport/middleware"
+	"github.com/Alexander272/games-library/internal/transport/validation"
+	"github.com/Alexander272/games-library/pkg/clientip"
+	"github.com/Alexander272/games-library/pkg/health"
 	"github.com/Alexander272/games-library/pkg/limiter"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/Alexander272/games-library/pkg/metrics"
+	"github.com/Alexander272/games-library/pkg/tracing"
 	"github.com/gin-gonic/contrib/cors"
 	"github.com/gin-gonic/gin"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github.com/swaggo/gin-swagger/swaggerFiles"
 
+	achievementDelivery "github.com/Alexander272/games-library/internal/achievement/transport"
+	duplicateDelivery "github.com/Alexander272/games-library/internal/duplicate/transport"
+	gameDelivery "github.com/Alexander272/games-library/internal/game/transport"
+	lendingDelivery "github.com/Alexander272/games-library/internal/lending/transport"
+	libraryDelivery "github.com/Alexander272/games-library/internal/library/transport"
+	listDelivery "github.com/Alexander272/games-library/internal/list/transport"
+	playtimeDelivery "github.com/Alexander272/games-library/internal/playtime/transport"
+	priceDelivery "github.com/Alexander272/games-library/internal/price/transport"
+	recommendationDelivery "github.com/Alexander272/games-library/internal/recommendation/transport"
 	userDelivery "github.com/Alexander272/games-library/internal/user/transport"
 )
 
 type Handler struct {
 	services *service.Services
+	limiter  limiter.Backend
+	health   *health.Health
 }
 
-func NewHandler(services *service.Services) *Handler {
+func NewHandler(services *service.Services, limiter limiter.Backend, health *health.Health) *Handler {
 	return &Handler{
 		services: services,
+		limiter:  limiter,
+		health:   health,
 	}
 }
 
 func (h *Handler) Init(conf *config.Config) *gin.Engine {
-	router := gin.Default()
+	if err := validation.Register(); err != nil {
+		logger.Fatalf("failed to register validation rules. error: %s", err.Error())
+	}
+
+	resolver, err := clientip.New(conf.Http.ClientIPHeader, conf.Http.TrustedProxies)
+	if err != nil {
+		logger.Fatalf("failed to initialize client ip resolver. error: %s", err.Error())
+	}
+
+	router := gin.New()
+	// the client ip is resolved by the resolver, gin must not trust the forwarding headers itself
+	router.ForwardedByClientIP = false
 
+	mw := middleware.NewMiddleware(h.services, conf.Auth.APIKeys)
+
+	// The resolver goes after the access log and the errors, so the rejected requests are logged
+	// and answered with the error code. The ip is read by them when the request is completed.
 	router.Use(
+		tracing.Middleware,
+		middleware.RequestId,
+		metrics.Middleware,
 		gin.Recovery(),
-		gin.Logger(),
-		limiter.Limit(conf.Limiter.RPS, conf.Limiter.Burst, conf.Limiter.TTL),
+		logger.AccessLog,
+		mw.Errors,
+		resolver.Handler,
 		cors.New(cors.Config{
 			AllowedOrigins: []string{conf.Http.Host},
 			AllowedMethods: []string{"GET"},
 			AllowedHeaders: []string{"Origin"},
-			ExposedHeaders: []string{"Content-Length"},
+			ExposedHeaders: []string{"Content-Length", "X-Request-ID", "Traceparent", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After"},
 			// AllowCredentials: true,
 		}),
 	)
@@ -55,16 +94,41 @@ func (h *Handler) Init(conf *config.Config) *gin.Engine {
 	router.GET("/api/ping", func(c *gin.Context) {
 		c.String(http.StatusOK, "pong")
 	})
+	router.GET("/healthz", h.health.Liveness)
+	router.GET("/readyz", h.health.Readiness)
 
-	h.initAPI(router)
+	h.initAPI(router, mw, conf)
 
 	return router
 }
 
-func (h *Handler) initAPI(router *gin.Engine) {
-	userHandler := userDelivery.NewHandler(h.services)
-	api := router.Group("/api")
+func (h *Handler) initAPI(router *gin.Engine, middleware *middleware.Middleware, conf *config.Config) {
+	rateLimiter, err := newLimiter(h.limiter, conf.Limiter, middleware.Identity)
+	if err != nil {
+		logger.Fatalf("failed to initialize limiter policies. error: %s", err.Error())
+	}
+
+	userHandler := userDelivery.NewHandler(h.services, middleware)
+	gameHandler := gameDelivery.NewHandler(h.services, middleware)
+	libraryHandler := libraryDelivery.NewHandler(h.services, middleware)
+	achievementHandler := achievementDelivery.NewHandler(h.services, middleware)
+	playtimeHandler := playtimeDelivery.NewHandler(h.services, middleware)
+	lendingHandler := lendingDelivery.NewHandler(h.services, middleware)
+	recommendationHandler := recommendationDelivery.NewHandler(h.services, middleware)
+	listHandler := listDelivery.NewHandler(h.services, middleware)
+	priceHandler := priceDelivery.NewHandler(h.services, middleware)
+	duplicateHandler := duplicateDelivery.NewHandler(h.services, middleware)
+	api := router.Group("/api", middleware.Locale, rateLimiter.Handler)
 	{
 		userHandler.Init(api)
+		gameHandler.Init(api)
+		libraryHandler.Init(api)
+		achievementHandler.Init(api)
+		playtimeHandler.Init(api)
+		lendingHandler.Init(api)
+		recommendationHandler.Init(api)
+		listHandler.Init(api)
+		priceHandler.Init(api)
+		duplicateHandler.Init(api)
 	}
 }
@@ -0,0 +1,30 @@
+package transport
+
+import (
+	"github.com/Alexander272/games-library/internal/config"
+	"github.com/Alexander272/games-library/pkg/limiter"
+)
+
+// newLimiter creates the limiter with the policies of the config.
+func newLimiter(backend limiter.Backend, conf config.LimiterConfig, identify limiter.IdentityFunc) (*limiter.Limiter, error) {
+	policies := make([]limiter.Policy, 0, len(conf.Policies))
+	for name, p := range conf.Policies {
+		policies = append(policies, limiter.Policy{
+			Name:          name,
+			Anonymous:     toRate(p.LimitRateConfig),
+			Authenticated: toRate(p.Authenticated),
+			APIKey:        toRate(p.APIKey),
+		})
+	}
+
+	routes := make([]limiter.Route, 0, len(conf.Routes))
+	for _, r := range conf.Routes {
+		routes = append(routes, limiter.Route{Method: r.Method, Path: r.Path, Policy: r.Policy})
+	}
+
+	return limiter.New(backend, policies, routes, identify)
+}
+
+func toRate(conf config.LimitRateConfig) limiter.Rate {
+	return limiter.Rate{Count: conf.Count, Period: conf.Period, Burst: conf.Burst}
+}
@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/Alexander272/games-library/internal/transport/errcode"
+	"github.com/Alexander272/games-library/internal/transport/validation"
+	"github.com/Alexander272/games-library/pkg/apperror"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+type response struct {
+	Code    errcode.Code          `json:"code"`
+	Message string                `json:"message"`
+	Fields  []apperror.FieldError `json:"fields,omitempty"`
+}
+
+// Errors responds to the last error added by the handlers with c.Error. The status and the code
+// are taken from the kind of the error, the message is translated to the locale of the request.
+// Errors of unknown kind are logged and replaced with the general message, so their text never reaches the client.
+func (m *Middleware) Errors(c *gin.Context) {
+	c.Next()
+
+	if len(c.Errors) == 0 {
+		return
+	}
+	err := c.Errors.Last().Err
+
+	status, code, message := http.StatusInternalServerError, errcode.Internal, ""
+	var fields []apperror.FieldError
+	if e, ok := apperror.As(err); ok && e.Kind != apperror.KindInternal {
+		status, code, message = statusOf(e.Kind), errcode.Code(e.Code), e.Message
+		fields = validation.Localize(e.Fields, GetLocale(c))
+		logger.FromContext(c).Debug(err.Error(), nil)
+	} else {
+		logger.FromContext(c).Error(err.Error(), nil)
+	}
+
+	// the status may be already sent, but the body can still be written
+	if c.Writer.Size() > 0 {
+		return
+	}
+	if text, ok := errcode.Message(code, GetLocale(c)); ok {
+		message = text
+	}
+	c.AbortWithStatusJSON(status, response{Code: code, Message: message, Fields: fields})
+}
+
+// abort stops the request with the error, the response is sent by the Errors middleware.
+func abort(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
+
+func statusOf(kind apperror.Kind) int {
+	switch kind {
+	case apperror.KindNotFound:
+		return http.StatusNotFound
+	case apperror.KindConflict:
+		return http.StatusConflict
+	case apperror.KindValidation:
+		return http.StatusBadRequest
+	case apperror.KindUnauthorized:
+		return http.StatusUnauthorized
+	case apperror.KindForbidden:
+		return http.StatusForbidden
+	case apperror.KindTooManyRequests:
+		return http.StatusTooManyRequests
+	}
+	return http.StatusInternalServerError
+}
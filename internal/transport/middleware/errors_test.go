@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	gameModels "github.com/Alexander272/games-library/internal/game/models"
+	"github.com/Alexander272/games-library/internal/transport/errcode"
+	"github.com/Alexander272/games-library/pkg/apperror"
+	"github.com/Alexander272/games-library/pkg/clientip"
+	"github.com/gin-gonic/gin"
+)
+
+func TestErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	invalidBody := errcode.ErrInvalidRequestBody.WithFields(apperror.FieldError{Field: "title", Rule: "required"})
+
+	tests := []struct {
+		name       string
+		err        error
+		lang       string
+		wantStatus int
+		want       response
+	}{
+		{
+			name:       "not found",
+			err:        gameModels.ErrGameNotFound,
+			wantStatus: http.StatusNotFound,
+			want:       response{Code: errcode.GameNotFound, Message: "game doesn't exists"},
+		},
+		{
+			name:       "translated message",
+			err:        gameModels.ErrGameNotFound,
+			lang:       "ru",
+			wantStatus: http.StatusNotFound,
+			want:       response{Code: errcode.GameNotFound, Message: "игра не найдена"},
+		},
+		{
+			name:       "wrapped with the cause",
+			err:        fmt.Errorf("failed to parse token. error: %w", errcode.ErrInvalidToken.Wrap(errors.New("signature is invalid"))),
+			wantStatus: http.StatusUnauthorized,
+			want:       response{Code: errcode.InvalidToken, Message: "token is invalid"},
+		},
+		{
+			name:       "validation with fields",
+			err:        invalidBody,
+			wantStatus: http.StatusBadRequest,
+			want: response{
+				Code:    errcode.InvalidRequestBody,
+				Message: "invalid request body",
+				Fields:  []apperror.FieldError{{Field: "title", Rule: "required", Message: "is required"}},
+			},
+		},
+		{
+			name:       "invalid forwarding chain",
+			err:        clientip.ErrInvalidChain,
+			wantStatus: http.StatusBadRequest,
+			want:       response{Code: errcode.InvalidForwarding, Message: "invalid forwarding headers of the proxy"},
+		},
+		{
+			name:       "code without translation",
+			err:        apperror.Conflict("SEAT_TAKEN", "the seat is taken"),
+			wantStatus: http.StatusConflict,
+			want:       response{Code: "SEAT_TAKEN", Message: "the seat is taken"},
+		},
+		{
+			name:       "too many requests",
+			err:        apperror.New(apperror.KindTooManyRequests, string(errcode.TooManyRequests), "too many requests"),
+			wantStatus: http.StatusTooManyRequests,
+			want:       response{Code: errcode.TooManyRequests, Message: "too many requests"},
+		},
+		{
+			name:       "internal kind",
+			err:        apperror.New(apperror.KindInternal, "DB_DOWN", "database is down"),
+			wantStatus: http.StatusInternalServerError,
+			want:       response{Code: errcode.Internal, Message: "internal server error"},
+		},
+		{
+			name:       "unknown error",
+			err:        errors.New("connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			want:       response{Code: errcode.Internal, Message: "internal server error"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMiddleware(nil, nil)
+			router := gin.New()
+			router.Use(m.Locale, m.Errors)
+			router.GET("/", func(c *gin.Context) {
+				_ = c.Error(tt.err)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/?lang="+tt.lang, nil))
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var got response
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("failed to decode body %q. error: %v", w.Body.String(), err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("body = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorsAfterBody(t *testing.T) {
+	m := NewMiddleware(nil, nil)
+	router := gin.New()
+	router.Use(m.Errors)
+	router.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, "partial")
+		_ = c.Error(errors.New("connection reset"))
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "partial" {
+		t.Errorf("response = %d %q, want the written body only", w.Code, w.Body.String())
+	}
+}
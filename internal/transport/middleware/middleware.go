@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/Alexander272/games-library/internal/service"
+	"github.com/Alexander272/games-library/internal/transport/errcode"
+	"github.com/Alexander272/games-library/internal/transport/validation"
+	"github.com/Alexander272/games-library/pkg/limiter"
+	"github.com/Alexander272/games-library/pkg/locale"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	authorizationHeader = "Authorization"
+	apiKeyHeader        = "X-API-Key"
+
+	UserIdCtx = "userId"
+	RoleCtx   = "role"
+	LocaleCtx = "locale"
+)
+
+type Middleware struct {
+	services *service.Services
+	// apiKeys are the names of the api keys by the keys
+	apiKeys map[string]string
+}
+
+// NewMiddleware creates the middleware, apiKeys are the keys of the clients by their names.
+func NewMiddleware(services *service.Services, apiKeys map[string]string) *Middleware {
+	m := &Middleware{
+		services: services,
+		apiKeys:  make(map[string]string, len(apiKeys)),
+	}
+	for name, key := range apiKeys {
+		m.apiKeys[key] = name
+	}
+	return m
+}
+
+// UserIdentity checks the access token and puts the user id and role to the context.
+func (m *Middleware) UserIdentity(c *gin.Context) {
+	header := c.GetHeader(authorizationHeader)
+	if header == "" {
+		abort(c, errcode.ErrEmptyAuthHeader)
+		return
+	}
+
+	headerParts := strings.Split(header, " ")
+	if len(headerParts) != 2 || headerParts[0] != "Bearer" || headerParts[1] == "" {
+		abort(c, errcode.ErrInvalidAuthHeader)
+		return
+	}
+
+	userId, role, err := m.services.Auth.TokenParse(headerParts[1])
+	if err != nil {
+		abort(c, errcode.ErrInvalidToken.Wrap(err))
+		return
+	}
+
+	setUser(c, userId, role)
+}
+
+// OptionalIdentity works like UserIdentity for requests with a valid access token
+// and lets anonymous requests through without the user in the context.
+func (m *Middleware) OptionalIdentity(c *gin.Context) {
+	userId, role, ok := m.parseToken(c)
+	if !ok {
+		return
+	}
+
+	setUser(c, userId, role)
+}
+
+// Identity returns the identity of the request for the rate limiter, it's the name of a known api key
+// or the user of a valid access token. The context isn't changed, the routes still require UserIdentity.
+// Unknown api keys are ignored, so the clients can't get new limits by sending random keys.
+func (m *Middleware) Identity(c *gin.Context) string {
+	if key := c.GetHeader(apiKeyHeader); key != "" {
+		if name, ok := m.apiKeys[key]; ok {
+			return limiter.IdentityAPIKey + name
+		}
+	}
+
+	userId, _, ok := m.parseToken(c)
+	if !ok {
+		return ""
+	}
+	return limiter.IdentityUser + userId
+}
+
+func setUser(c *gin.Context, userId, role string) {
+	c.Set(UserIdCtx, userId)
+	c.Set(RoleCtx, role)
+	logger.With(c, map[string]interface{}{logger.FieldUserId: userId})
+}
+
+func (m *Middleware) parseToken(c *gin.Context) (userId, role string, ok bool) {
+	headerParts := strings.Split(c.GetHeader(authorizationHeader), " ")
+	if len(headerParts) != 2 || headerParts[0] != "Bearer" || headerParts[1] == "" {
+		return "", "", false
+	}
+
+	userId, role, err := m.services.Auth.TokenParse(headerParts[1])
+	if err != nil {
+		return "", "", false
+	}
+	return userId, role, true
+}
+
+// AccessForRoles allows the request only for users with one of the roles.
+// It must be used after UserIdentity.
+func (m *Middleware) AccessForRoles(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString(RoleCtx)
+		for _, r := range roles {
+			if r == role {
+				return
+			}
+		}
+		abort(c, errcode.ErrAccessDenied)
+	}
+}
+
+// ObjectIds checks that the path params with the names are ObjectIDs. Params missing in the route are skipped.
+func (m *Middleware) ObjectIds(params ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, p := range params {
+			value, ok := c.Params.Get(p)
+			if !ok {
+				continue
+			}
+			if err := validation.ObjectId(p, value); err != nil {
+				abort(c, err)
+				return
+			}
+		}
+	}
+}
+
+func GetUserId(c *gin.Context) (string, error) {
+	id := c.GetString(UserIdCtx)
+	if id == "" {
+		return "", errcode.ErrUnauthorized
+	}
+	return id, nil
+}
+
+// Locale selects the language of the response by the lang query param or the Accept-Language header.
+func (m *Middleware) Locale(c *gin.Context) {
+	l := locale.Negotiate(c.Query("lang"), c.GetHeader("Accept-Language"))
+	c.Set(LocaleCtx, l)
+	c.Header("Content-Language", l)
+}
+
+// GetLocale returns the locale selected by the Locale middleware or the default one.
+func GetLocale(c *gin.Context) string {
+	l := c.GetString(LocaleCtx)
+	if l == "" {
+		return locale.Default
+	}
+	return l
+}
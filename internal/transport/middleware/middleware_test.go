@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIdentity(t *testing.T) {
+	m := NewMiddleware(nil, map[string]string{"partner": "partner-key"})
+
+	tests := []struct {
+		name   string
+		apiKey string
+		want   string
+	}{
+		{"known api key", "partner-key", "apikey:partner"},
+		{"unknown api key", "random-key", ""},
+		{"anonymous", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest("GET", "/api/games", nil)
+			if tt.apiKey != "" {
+				c.Request.Header.Set(apiKeyHeader, tt.apiKey)
+			}
+			if got := m.Identity(c); got != tt.want {
+				t.Errorf("Identity() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
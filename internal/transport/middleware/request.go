@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	requestIdHeader = "X-Request-ID"
+	maxRequestIdLen = 128
+
+	RequestIdCtx = "requestId"
+)
+
+// RequestId takes the id of the request from the X-Request-ID header set by the proxy or generates it.
+// The id is returned in the response and added to the logs of the request.
+func RequestId(c *gin.Context) {
+	id := c.GetHeader(requestIdHeader)
+	if !validRequestId(id) {
+		id = newRequestId()
+	}
+
+	c.Set(RequestIdCtx, id)
+	c.Header(requestIdHeader, id)
+	logger.With(c, map[string]interface{}{logger.FieldRequestId: id})
+}
+
+// validRequestId allows only short printable ids, so the clients can't break the logs with the header.
+func validRequestId(id string) bool {
+	if id == "" || len(id) > maxRequestIdLen {
+		return false
+	}
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestId() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// GetRequestId returns the id of the request set by the RequestId middleware.
+func GetRequestId(c *gin.Context) string {
+	return c.GetString(RequestIdCtx)
+}
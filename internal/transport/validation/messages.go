@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Alexander272/games-library/pkg/apperror"
+	"github.com/Alexander272/games-library/pkg/locale"
+)
+
+// messages are the texts of the rules by locale, %s is replaced with the param of the rule.
+var messages = map[string]map[string]string{
+	"required": {locale.En: "is required", locale.Ru: "обязательное поле"},
+	"email":    {locale.En: "must be a valid email", locale.Ru: "должно быть корректным email"},
+	"min":      {locale.En: "must be at least %s", locale.Ru: "должно быть не меньше %s"},
+	"max":      {locale.En: "must be at most %s", locale.Ru: "должно быть не больше %s"},
+	"len":      {locale.En: "must have the length of %s", locale.Ru: "должно иметь длину %s"},
+	"gt":       {locale.En: "must be greater than %s", locale.Ru: "должно быть больше %s"},
+	"gte":      {locale.En: "must be at least %s", locale.Ru: "должно быть не меньше %s"},
+	"lt":       {locale.En: "must be less than %s", locale.Ru: "должно быть меньше %s"},
+	"lte":      {locale.En: "must be at most %s", locale.Ru: "должно быть не больше %s"},
+	"oneof":    {locale.En: "must be one of: %s", locale.Ru: "должно быть одним из: %s"},
+	"numeric":  {locale.En: "must be a number", locale.Ru: "должно быть числом"},
+	"type":     {locale.En: "must be of type %s", locale.Ru: "должно иметь тип %s"},
+
+	RulePassword: {
+		locale.En: "must contain lowercase and uppercase letters and digits",
+		locale.Ru: "должен содержать строчные и заглавные буквы и цифры",
+	},
+	RuleRole: {locale.En: "must be admin or user", locale.Ru: "должна быть admin или user"},
+	RuleSlug: {
+		locale.En: "must contain only lowercase latin letters, digits and dashes",
+		locale.Ru: "может содержать только строчные латинские буквы, цифры и дефисы",
+	},
+	RuleObjectId: {locale.En: "must be a valid id", locale.Ru: "должен быть корректным id"},
+}
+
+// Message returns the text of the field error in the locale. Unknown rules are described by their name.
+func Message(field apperror.FieldError, loc string) string {
+	m, ok := messages[field.Rule]
+	if !ok {
+		return fmt.Sprintf("failed on the %s rule", field.Rule)
+	}
+
+	text := m[locale.Default]
+	for _, l := range locale.Chain(loc) {
+		if t, ok := m[l]; ok {
+			text = t
+			break
+		}
+	}
+	if strings.Contains(text, "%s") {
+		text = fmt.Sprintf(text, strings.ReplaceAll(field.Param, " ", ", "))
+	}
+	return text
+}
+
+// Localize returns the copy of the fields with the messages in the locale.
+func Localize(fields []apperror.FieldError, loc string) []apperror.FieldError {
+	res := make([]apperror.FieldError, len(fields))
+	for i, f := range fields {
+		f.Message = Message(f, loc)
+		res[i] = f
+	}
+	return res
+}
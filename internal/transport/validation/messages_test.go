@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Alexander272/games-library/pkg/apperror"
+)
+
+func TestMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		field apperror.FieldError
+		loc   string
+		want  string
+	}{
+		{"default locale", apperror.FieldError{Rule: "required"}, "", "is required"},
+		{"english", apperror.FieldError{Rule: "required"}, "en", "is required"},
+		{"russian", apperror.FieldError{Rule: "required"}, "ru", "обязательное поле"},
+		{"unsupported locale", apperror.FieldError{Rule: "required"}, "de", "is required"},
+		{"param", apperror.FieldError{Rule: "min", Param: "8"}, "", "must be at least 8"},
+		{"translated param", apperror.FieldError{Rule: "max", Param: "64"}, "ru", "должно быть не больше 64"},
+		{"list param", apperror.FieldError{Rule: "oneof", Param: "pc ps5 switch"}, "", "must be one of: pc, ps5, switch"},
+		{"password", apperror.FieldError{Rule: RulePassword}, "", "must contain lowercase and uppercase letters and digits"},
+		{"role", apperror.FieldError{Rule: RuleRole}, "ru", "должна быть admin или user"},
+		{"slug", apperror.FieldError{Rule: RuleSlug}, "ru", "может содержать только строчные латинские буквы, цифры и дефисы"},
+		{"object id", apperror.FieldError{Rule: RuleObjectId}, "", "must be a valid id"},
+		{"unknown rule", apperror.FieldError{Rule: "uuid4"}, "ru", "failed on the uuid4 rule"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Message(tt.field, tt.loc); got != tt.want {
+				t.Errorf("Message() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	fields := []apperror.FieldError{
+		{Field: "password", Rule: "min", Param: "8", Message: "must be at least 8"},
+		{Field: "slug", Rule: RuleSlug, Message: "must contain only lowercase latin letters, digits and dashes"},
+	}
+
+	data, err := json.Marshal(Localize(fields, "ru"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"field":"password","rule":"min","param":"8","message":"должно быть не меньше 8"},` +
+		`{"field":"slug","rule":"slug","message":"может содержать только строчные латинские буквы, цифры и дефисы"}]`
+	if string(data) != want {
+		t.Errorf("payload = %s, want %s", data, want)
+	}
+	if fields[0].Message != "must be at least 8" {
+		t.Errorf("the fields are changed: %+v", fields)
+	}
+}
@@ -0,0 +1,137 @@
+// Package validation adds the custom rules to the validator of gin and converts its errors
+// to the list of invalid fields returned to the client.
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/Alexander272/games-library/internal/transport/errcode"
+	userModels "github.com/Alexander272/games-library/internal/user/models"
+	"github.com/Alexander272/games-library/pkg/apperror"
+	"github.com/Alexander272/games-library/pkg/slug"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// custom rules
+const (
+	RulePassword = "password"
+	RuleRole     = "role"
+	RuleSlug     = "slug"
+	RuleObjectId = "objectid"
+)
+
+// Register adds the custom rules to the validator used by the binding of gin
+// and makes the validator report the fields by their json or query names.
+func Register() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("unknown validator engine")
+	}
+
+	v.RegisterTagNameFunc(fieldName)
+
+	rules := map[string]validator.Func{
+		RulePassword: func(fl validator.FieldLevel) bool { return IsStrongPassword(fl.Field().String()) },
+		RuleRole:     func(fl validator.FieldLevel) bool { return IsRole(fl.Field().String()) },
+		RuleSlug:     func(fl validator.FieldLevel) bool { return slug.IsValid(fl.Field().String()) },
+		RuleObjectId: func(fl validator.FieldLevel) bool { return primitive.IsValidObjectID(fl.Field().String()) },
+	}
+	for tag, fn := range rules {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			return fmt.Errorf("failed to register %s rule. error: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+// IsStrongPassword reports whether the password has lower and upper case letters and digits.
+// The length is checked by the min and max rules.
+func IsStrongPassword(password string) bool {
+	var lower, upper, digit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+	return lower && upper && digit
+}
+
+func IsRole(role string) bool {
+	return role == userModels.RoleAdmin || role == userModels.RoleUser
+}
+
+// Body converts the error of binding the request body to ErrInvalidRequestBody with the invalid fields.
+func Body(err error) error {
+	return convert(err, errcode.ErrInvalidRequestBody)
+}
+
+// Query converts the error of binding the query params to ErrInvalidQueryParams with the invalid fields.
+func Query(err error) error {
+	return convert(err, errcode.ErrInvalidQueryParams)
+}
+
+// ObjectId returns the error of the path param that isn't an ObjectID or nil.
+func ObjectId(param, value string) error {
+	if primitive.IsValidObjectID(value) {
+		return nil
+	}
+	field := apperror.FieldError{Field: param, Rule: RuleObjectId}
+	field.Message = Message(field, "")
+	return errcode.ErrInvalidPathParams.WithFields(field)
+}
+
+func convert(err error, base *apperror.Error) error {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]apperror.FieldError, 0, len(validationErrs))
+		for _, e := range validationErrs {
+			field := apperror.FieldError{Field: path(e), Rule: e.Tag(), Param: e.Param()}
+			field.Message = Message(field, "")
+			fields = append(fields, field)
+		}
+		return base.WithFields(fields...).Wrap(err)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		field := apperror.FieldError{Field: typeErr.Field, Rule: "type", Param: typeErr.Type.String()}
+		field.Message = Message(field, "")
+		return base.WithFields(field).Wrap(err)
+	}
+
+	return base.Wrap(err)
+}
+
+// path returns the name of the field without the name of the struct, e.g. "translations[0].locale".
+func path(e validator.FieldError) string {
+	ns := e.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+	return e.Field()
+}
+
+func fieldName(f reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name := strings.SplitN(f.Tag.Get(tag), ",", 2)[0]
+		if name == "-" {
+			continue
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
@@ -0,0 +1,161 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Alexander272/games-library/internal/transport/errcode"
+	"github.com/Alexander272/games-library/pkg/apperror"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type rulesDTO struct {
+	Password string `json:"password" binding:"omitempty,password"`
+	Role     string `json:"role" binding:"omitempty,role"`
+	Slug     string `json:"slug" binding:"omitempty,slug"`
+	Id       string `json:"id" binding:"omitempty,objectid"`
+}
+
+func TestRules(t *testing.T) {
+	if err := Register(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		dto   rulesDTO
+		valid bool
+	}{
+		{"strong password", rulesDTO{Password: "Secret42"}, true},
+		{"password without digits", rulesDTO{Password: "SecretPass"}, false},
+		{"password without upper case", rulesDTO{Password: "secret42"}, false},
+		{"password without lower case", rulesDTO{Password: "SECRET42"}, false},
+		{"cyrillic password", rulesDTO{Password: "Пароль42"}, true},
+		{"admin role", rulesDTO{Role: "admin"}, true},
+		{"user role", rulesDTO{Role: "user"}, true},
+		{"unknown role", rulesDTO{Role: "owner"}, false},
+		{"role in upper case", rulesDTO{Role: "Admin"}, false},
+		{"slug", rulesDTO{Slug: "the-witcher-3"}, true},
+		{"slug with upper case", rulesDTO{Slug: "The-Witcher"}, false},
+		{"slug with spaces", rulesDTO{Slug: "the witcher"}, false},
+		{"slug with cyrillic", rulesDTO{Slug: "ведьмак"}, false},
+		{"object id", rulesDTO{Id: "5f8d0d55b54764421b7156c9"}, true},
+		{"short object id", rulesDTO{Id: "5f8d0d55b547"}, false},
+		{"object id with non hex", rulesDTO{Id: "5f8d0d55b54764421b7156zz"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := binding.Validator.ValidateStruct(&tt.dto)
+			if valid := err == nil; valid != tt.valid {
+				t.Errorf("valid = %v, want %v. error: %v", valid, tt.valid, err)
+			}
+		})
+	}
+}
+
+type translationDTO struct {
+	Locale string `json:"locale" binding:"required,len=2"`
+}
+
+type bodyDTO struct {
+	Title        string           `json:"title" binding:"required,min=2"`
+	Platform     string           `json:"platform" binding:"omitempty,oneof=pc ps5"`
+	Genre        string           `json:"-" form:"genre" binding:"required"`
+	Rating       int              `json:"rating" binding:"gte=0,lte=10"`
+	Translations []translationDTO `json:"translations" binding:"dive"`
+}
+
+func TestBody(t *testing.T) {
+	if err := Register(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want []apperror.FieldError
+	}{
+		{
+			name: "required and min",
+			body: `{"title": "a", "rating": 11}`,
+			want: []apperror.FieldError{
+				{Field: "title", Rule: "min", Param: "2", Message: "must be at least 2"},
+				{Field: "genre", Rule: "required", Message: "is required"},
+				{Field: "rating", Rule: "lte", Param: "10", Message: "must be at most 10"},
+			},
+		},
+		{
+			name: "nested fields",
+			body: `{"title": "Doom", "platform": "xbox", "translations": [{"locale": "ru"}, {"locale": "rus"}]}`,
+			want: []apperror.FieldError{
+				{Field: "platform", Rule: "oneof", Param: "pc ps5", Message: "must be one of: pc, ps5"},
+				{Field: "genre", Rule: "required", Message: "is required"},
+				{Field: "translations[1].locale", Rule: "len", Param: "2", Message: "must have the length of 2"},
+			},
+		},
+		{
+			name: "wrong type",
+			body: `{"title": 5}`,
+			want: []apperror.FieldError{{Field: "title", Rule: "type", Param: "string", Message: "must be of type string"}},
+		},
+		{
+			name: "malformed json",
+			body: `{"title": `,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dto bodyDTO
+			err := json.Unmarshal([]byte(tt.body), &dto)
+			if err == nil {
+				err = binding.Validator.ValidateStruct(&dto)
+			}
+			if err == nil {
+				t.Fatal("expected error")
+			}
+
+			err = Body(err)
+			if !errors.Is(err, errcode.ErrInvalidRequestBody) {
+				t.Fatalf("error = %v, want %v", err, errcode.ErrInvalidRequestBody)
+			}
+			e, _ := apperror.As(err)
+			if !reflect.DeepEqual(e.Fields, tt.want) {
+				t.Errorf("fields = %+v, want %+v", e.Fields, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	if err := Register(); err != nil {
+		t.Fatal(err)
+	}
+
+	err := Query(binding.Validator.ValidateStruct(&rulesDTO{Role: "owner"}))
+	if !errors.Is(err, errcode.ErrInvalidQueryParams) {
+		t.Fatalf("error = %v, want %v", err, errcode.ErrInvalidQueryParams)
+	}
+	e, _ := apperror.As(err)
+	want := []apperror.FieldError{{Field: "role", Rule: RuleRole, Message: "must be admin or user"}}
+	if !reflect.DeepEqual(e.Fields, want) {
+		t.Errorf("fields = %+v, want %+v", e.Fields, want)
+	}
+}
+
+func TestObjectId(t *testing.T) {
+	if err := ObjectId("id", "5f8d0d55b54764421b7156c9"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := ObjectId("id", "witcher")
+	if !errors.Is(err, errcode.ErrInvalidPathParams) {
+		t.Fatalf("error = %v, want %v", err, errcode.ErrInvalidPathParams)
+	}
+	e, _ := apperror.As(err)
+	want := []apperror.FieldError{{Field: "id", Rule: RuleObjectId, Message: "must be a valid id"}}
+	if !reflect.DeepEqual(e.Fields, want) {
+		t.Errorf("fields = %+v, want %+v", e.Fields, want)
+	}
+}
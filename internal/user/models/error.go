@@ -1,8 +1,11 @@
 package models
 
-import "errors"
+import "github.com/Alexander272/games-library/pkg/apperror"
 
 var (
-	ErrUserNotFound = errors.New("user doesn't exists")
-	ErrUserExists   = errors.New("user with the same email already exists")
+	ErrUserNotFound = apperror.NotFound("USER_NOT_FOUND", "user doesn't exists")
+	ErrUserExists   = apperror.Conflict("USER_EXISTS", "user with the same email already exists")
+
+	ErrInvalidCredentials = apperror.Unauthorized("INVALID_CREDENTIALS", "invalid credentials")
+	ErrInvalidSession     = apperror.Unauthorized("INVALID_SESSION", "session is expired or doesn't exists")
 )
@@ -1,5 +1,10 @@
 package models
 
+const (
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
 type Token struct {
 	AccessToken string `json:"accessToken"`
 }
@@ -29,8 +34,8 @@ func NewUser(dto CreateUserDTO) User {
 type CreateUserDTO struct {
 	Name     string `json:"name" binding:"required,min=3,max=128"`
 	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required,min=8,max=64"`
-	Role     string `json:"role" binding:"required"`
+	Password string `json:"password" binding:"required,min=8,max=64,password"`
+	Role     string `json:"role" binding:"required,role"`
 }
 
 func UpdateUser(dto UpdateUserDTO) User {
@@ -45,9 +50,9 @@ func UpdateUser(dto UpdateUserDTO) User {
 
 type UpdateUserDTO struct {
 	Id          string `json:"id"`
-	Name        string `json:"name"`
-	Email       string `json:"email"`
+	Name        string `json:"name" binding:"omitempty,min=3,max=128"`
+	Email       string `json:"email" binding:"omitempty,email"`
 	OldPassword string `json:"oldPassword"`
-	NewPassword string `json:"newPassword"`
-	Role        string `json:"role"`
+	NewPassword string `json:"newPassword" binding:"omitempty,min=8,max=64,password"`
+	Role        string `json:"role" binding:"omitempty,role"`
 }
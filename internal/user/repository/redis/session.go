@@ -3,10 +3,11 @@ package redis
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/Alexander272/games-library/internal/user/models"
 	"github.com/go-redis/redis/v8"
 )
 
@@ -31,27 +32,30 @@ func (d SessionData) MarshalBinary() ([]byte, error) {
 	return json.Marshal(d)
 }
 
+func (d *SessionData) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, d)
+}
+
 func (r *SessionRepo) Create(ctx context.Context, token string, data SessionData) error {
 	res := r.db.Set(ctx, token, data, data.Exp)
 	if res.Err() != nil {
 		return fmt.Errorf("failed to execute query. error: %w", res.Err())
 	}
-	logger.Debug(res.Result())
-
 	return nil
 }
 
 func (r *SessionRepo) GetDel(ctx context.Context, key string) (data SessionData, err error) {
 	cmd := r.db.GetDel(ctx, key)
 	if cmd.Err() != nil {
+		if errors.Is(cmd.Err(), redis.Nil) {
+			return data, models.ErrInvalidSession
+		}
 		return data, fmt.Errorf("failed to execute query. error: %w", cmd.Err())
 	}
 
 	if err := cmd.Scan(&data); err != nil {
-		// todo дописать ошибку
-		return data, fmt.Errorf("failed to ... . error: %w", err)
+		return data, fmt.Errorf("failed to decode session. error: %w", err)
 	}
-	logger.Info(data)
 	return data, nil
 }
 
@@ -60,6 +64,5 @@ func (r *SessionRepo) Delete(ctx context.Context, key string) error {
 	if res.Err() != nil {
 		return fmt.Errorf("failed to execute query. error: %w", res.Err())
 	}
-	logger.Debug(res)
 	return nil
 }
@@ -1 +1,50 @@
 package redis
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+const refreshToken = "f3a9c1d27b5e4a8c9d0e1f2a3b4c5d6e"
+
+// TestSessionLogs goes through the create, refresh and sign out of the session with the application logger
+// at the trace level and checks that the refresh token, which is the key of the session, isn't logged.
+func TestSessionLogs(t *testing.T) {
+	var buf bytes.Buffer
+	logger.Init(&buf)
+
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	defer client.Close()
+	repo := NewSessionRepo(client)
+	ctx := context.Background()
+
+	want := SessionData{UserId: "1", Email: "user@example.com", Role: "user", Exp: time.Hour}
+	if err := repo.Create(ctx, refreshToken, want); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	got, err := repo.GetDel(ctx, refreshToken)
+	if err != nil {
+		t.Fatalf("GetDel() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("GetDel() = %+v, want %+v", got, want)
+	}
+	if err := repo.Create(ctx, refreshToken, want); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repo.Delete(ctx, refreshToken); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if strings.Contains(buf.String(), refreshToken) {
+		t.Errorf("refresh token found in output:\n%s", buf.String())
+	}
+}
@@ -41,15 +41,17 @@ func NewAuthService(repo repository.IUser, ses repository.ISession, tokenManager
 func (s *AuthService) SignIn(ctx context.Context, dto models.SignInUserDTO, ua, ip string) (token models.Token, cookie http.Cookie, err error) {
 	user, err := s.repo.GetByEmail(ctx, dto.Email)
 	if err != nil {
-		logger.Errorf("failed to find user by email. errors: %s", err.Error())
+		// unknown email is reported as wrong credentials, so the registered emails can't be guessed
 		if errors.Is(err, models.ErrUserNotFound) {
-			return token, cookie, err
+			signIns.Inc(resultFailure)
+			return token, cookie, models.ErrInvalidCredentials
 		}
-		return token, cookie, fmt.Errorf("invalid credentials")
+		return token, cookie, fmt.Errorf("failed to find user by email. error: %w", err)
 	}
 
 	if ok := s.hasher.CheckPasswordHash(dto.Password, user.Password); !ok {
-		return token, cookie, fmt.Errorf("invalid credentials")
+		signIns.Inc(resultFailure)
+		return token, cookie, models.ErrInvalidCredentials
 	}
 
 	accessToken, err := s.tokenManager.NewJWT(user.Id, user.Email, user.Role, s.accessTokenTTL)
@@ -87,6 +89,7 @@ func (s *AuthService) SignIn(ctx context.Context, dto models.SignInUserDTO, ua,
 		HttpOnly: true,
 	}
 
+	signIns.Inc(resultSuccess)
 	return models.Token{AccessToken: accessToken}, cookie, nil
 }
 
@@ -111,18 +114,20 @@ func (s *AuthService) SignOut(ctx context.Context, token string) (cookie http.Co
 func (s *AuthService) Refresh(ctx context.Context, refToken, ua, ip string) (token models.Token, cookie http.Cookie, err error) {
 	data, err := s.session.GetDel(ctx, refToken)
 	if err != nil {
-		logger.Errorf("failed to getdel session. error: %s", err.Error())
-		return token, cookie, fmt.Errorf("failed to get session")
+		if errors.Is(err, models.ErrInvalidSession) {
+			refreshes.Inc(resultInvalidSession)
+			return token, cookie, err
+		}
+		return token, cookie, fmt.Errorf("failed to get session. error: %w", err)
 	}
 
 	if ua != data.Ua || ip != data.Ip {
-		if ua != data.Ua {
-			logger.Errorf("ua do not match. ua from redis %s, ua from request %s", data.Ua, ua)
-		}
-		if ip != data.Ip {
-			logger.Errorf("ip do not match. ip from redis %s, ip from request %s", data.Ip, ip)
-		}
-		return token, cookie, fmt.Errorf("invalid credentials")
+		logger.FromContext(ctx).Error("session doesn't match the request", map[string]interface{}{
+			"ua_changed": ua != data.Ua,
+			"ip_changed": ip != data.Ip,
+		})
+		refreshes.Inc(resultMismatch)
+		return token, cookie, models.ErrInvalidCredentials
 	}
 
 	accessToken, err := s.tokenManager.NewJWT(data.UserId, data.Email, data.Role, s.accessTokenTTL)
@@ -161,6 +166,7 @@ func (s *AuthService) Refresh(ctx context.Context, refToken, ua, ip string) (tok
 	}
 	token = models.Token{AccessToken: accessToken}
 
+	refreshes.Inc(resultSuccess)
 	return token, cookie, nil
 }
 
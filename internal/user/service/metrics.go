@@ -0,0 +1,17 @@
+package service
+
+import "github.com/Alexander272/games-library/pkg/metrics"
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+	// resultInvalidSession is the refresh token that is unknown, expired or already used.
+	// The token is removed on refresh, so the reuse of a stolen token is counted here
+	resultInvalidSession = "invalid_session"
+	resultMismatch       = "mismatch"
+)
+
+var (
+	signIns   = metrics.NewCounterVec("auth_sign_in_total", "Number of the sign-in attempts by the result.", "result")
+	refreshes = metrics.NewCounterVec("auth_refresh_total", "Number of the session refreshes by the result.", "result")
+)
@@ -21,4 +21,5 @@ type IAuth interface {
 	SignIn(ctx context.Context, dto models.SignInUserDTO, ua, ip string) (models.Token, http.Cookie, error)
 	SignOut(ctx context.Context, token string) (http.Cookie, error)
 	Refresh(ctx context.Context, refToken, ua, ip string) (models.Token, http.Cookie, error)
+	TokenParse(token string) (userId string, role string, err error)
 }
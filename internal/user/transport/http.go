@@ -1,23 +1,27 @@
 package transport
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/Alexander272/games-library/internal/service"
+	"github.com/Alexander272/games-library/internal/transport/errcode"
+	"github.com/Alexander272/games-library/internal/transport/middleware"
+	"github.com/Alexander272/games-library/internal/transport/validation"
 	"github.com/Alexander272/games-library/internal/user/models"
 	userService "github.com/Alexander272/games-library/internal/user/service"
 	"github.com/gin-gonic/gin"
 )
 
 type Handler struct {
-	services *service.Services
+	services   *service.Services
+	middleware *middleware.Middleware
 }
 
-func NewHandler(services *service.Services) *Handler {
+func NewHandler(services *service.Services, middleware *middleware.Middleware) *Handler {
 	return &Handler{
-		services: services,
+		services:   services,
+		middleware: middleware,
 	}
 }
 
@@ -30,7 +34,7 @@ func (h *Handler) Init(api *gin.RouterGroup) {
 	}
 
 	// todo нужно добавить middleware
-	users := api.Group("/users")
+	users := api.Group("/users", h.middleware.ObjectIds("id"))
 	{
 		users.GET("/", h.getAll)
 		users.POST("/", h.create)
@@ -48,14 +52,14 @@ func (h *Handler) Init(api *gin.RouterGroup) {
 // @Produce json
 // @Param signIn body models.SignInUserDTO true "credentials"
 // @Success 200 {object} dataResponse{data=models.Token}
-// @Failure 400,404 {object} response
+// @Failure 400,401 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /auth/sign-in [post]
 func (h *Handler) signIn(c *gin.Context) {
 	var dto models.SignInUserDTO
-	if err := c.BindJSON(&dto); err != nil {
-		newResponse(c, http.StatusBadRequest, "invalid credentials")
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
 		return
 	}
 
@@ -63,11 +67,7 @@ func (h *Handler) signIn(c *gin.Context) {
 	ip := c.ClientIP()
 	token, cookie, err := h.services.Auth.SignIn(c, dto, ua, ip)
 	if err != nil {
-		if errors.Is(err, fmt.Errorf("invalid credentials")) || errors.Is(err, models.ErrUserNotFound) {
-			newResponse(c, http.StatusBadRequest, err.Error())
-			return
-		}
-		newResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -82,20 +82,20 @@ func (h *Handler) signIn(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} response
-// @Failure 400,404 {object} response
+// @Failure 400,401 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /auth/sign-out [post]
 func (h *Handler) signOut(c *gin.Context) {
 	token, err := c.Cookie(userService.CookieName)
 	if err != nil {
-		newResponse(c, http.StatusBadRequest, "cookie not found")
+		c.Error(errcode.ErrCookieNotFound)
 		return
 	}
 
 	cookie, err := h.services.Auth.SignOut(c, token)
 	if err != nil {
-		newResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -110,7 +110,7 @@ func (h *Handler) signOut(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} dataResponse{data=models.Token}
-// @Failure 400,403,404 {object} response
+// @Failure 400,401 {object} response
 // @Failure 500 {object} response
 // @Failure default {object} response
 // @Router /auth/refresh [post]
@@ -120,13 +120,13 @@ func (h *Handler) refresh(c *gin.Context) {
 
 	token, err := c.Cookie(userService.CookieName)
 	if err != nil {
-		newResponse(c, http.StatusBadRequest, "cookie not found")
+		c.Error(errcode.ErrCookieNotFound)
 		return
 	}
 
 	netToken, cookie, err := h.services.Auth.Refresh(c, token, ua, ip)
 	if err != nil {
-		newResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -149,14 +149,15 @@ func (h *Handler) refresh(c *gin.Context) {
 // @Router /users [post]
 func (h *Handler) create(c *gin.Context) {
 	var dto models.CreateUserDTO
-	if err := c.BindJSON(&dto); err != nil {
-		newResponse(c, http.StatusBadRequest, "invalid request body")
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
 		return
 	}
 
 	id, err := h.services.User.Create(c, dto)
 	if err != nil {
-		newResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
+		return
 	}
 
 	c.Header("Location", fmt.Sprintf("/api/users/%s", id))
@@ -178,11 +179,7 @@ func (h *Handler) create(c *gin.Context) {
 func (h *Handler) getAll(c *gin.Context) {
 	users, err := h.services.User.GetAll(c)
 	if err != nil {
-		if errors.Is(err, models.ErrUserNotFound) {
-			newResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		newResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, dataResponse{Data: users})
@@ -202,18 +199,9 @@ func (h *Handler) getAll(c *gin.Context) {
 // @Failure default {object} response
 // @Router /users/{id} [get]
 func (h *Handler) getById(c *gin.Context) {
-	if c.Param("id") == "" {
-		newResponse(c, http.StatusBadRequest, "empty id param")
-		return
-	}
-
 	user, err := h.services.User.GetById(c, c.Param("id"))
 	if err != nil {
-		if errors.Is(err, models.ErrUserNotFound) {
-			newResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		newResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -235,25 +223,16 @@ func (h *Handler) getById(c *gin.Context) {
 // @Failure default {object} response
 // @Router /users/{id} [put]
 func (h *Handler) update(c *gin.Context) {
-	if c.Param("id") == "" {
-		newResponse(c, http.StatusBadRequest, "empty id param")
-		return
-	}
-
 	var dto models.UpdateUserDTO
-	if err := c.BindJSON(&dto); err != nil {
-		newResponse(c, http.StatusBadRequest, "invalid request body")
+	if err := c.ShouldBindJSON(&dto); err != nil {
+		c.Error(validation.Body(err))
 		return
 	}
 	dto.Id = c.Param("id")
 
 	err := h.services.User.Update(c, dto)
 	if err != nil {
-		if errors.Is(err, models.ErrUserNotFound) {
-			newResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		newResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -274,18 +253,9 @@ func (h *Handler) update(c *gin.Context) {
 // @Failure default {object} response
 // @Router /users/{id} [delete]
 func (h *Handler) remove(c *gin.Context) {
-	if c.Param("id") == "" {
-		newResponse(c, http.StatusBadRequest, "empty id param")
-		return
-	}
-
 	err := h.services.User.Remove(c, c.Param("id"))
 	if err != nil {
-		if errors.Is(err, models.ErrUserNotFound) {
-			newResponse(c, http.StatusNotFound, err.Error())
-			return
-		}
-		newResponse(c, http.StatusInternalServerError, err.Error())
+		c.Error(err)
 		return
 	}
 
@@ -1,8 +1,8 @@
 package transport
 
 import (
-	"github.com/Alexander272/games-library/pkg/logger"
-	"github.com/gin-gonic/gin"
+	"github.com/Alexander272/games-library/internal/transport/errcode"
+	"github.com/Alexander272/games-library/pkg/apperror"
 )
 
 type dataResponse struct {
@@ -15,10 +15,7 @@ type idResponse struct {
 }
 
 type response struct {
-	Message string `json:"message"`
-}
-
-func newResponse(c *gin.Context, statusCode int, message string) {
-	logger.Error(message)
-	c.AbortWithStatusJSON(statusCode, response{message})
+	Code    errcode.Code          `json:"code,omitempty"`
+	Message string                `json:"message"`
+	Fields  []apperror.FieldError `json:"fields,omitempty"`
 }
@@ -0,0 +1,152 @@
+// Package clientip finds the address of the client behind the trusted proxies.
+package clientip
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/Alexander272/games-library/pkg/apperror"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	HeaderForwarded     = "Forwarded"
+	HeaderXForwardedFor = "X-Forwarded-For"
+	HeaderXRealIP       = "X-Real-IP"
+)
+
+// ErrInvalidChain is returned when the header of the trusted proxy has an invalid address
+// before any address that isn't trusted, so the client can't be found.
+var ErrInvalidChain = apperror.Validation("INVALID_FORWARDING_CHAIN", "invalid forwarding chain")
+
+// Resolver trusts the header of the proxies only when the request comes from a trusted network,
+// so the clients can't set their address by sending the header themselves.
+type Resolver struct {
+	header  string
+	trusted []*net.IPNet
+}
+
+// New creates the resolver reading the header set by the proxy, e.g. X-Forwarded-For for nginx, and
+// trusting the networks, e.g. "10.0.0.0/8" or "127.0.0.1". Only this header is read, the others may be
+// sent by the client and passed through by the proxy unchanged. Without the networks the address
+// of the connection is always used.
+func New(header string, trustedProxies []string) (*Resolver, error) {
+	r := &Resolver{header: http.CanonicalHeaderKey(header)}
+	if r.header == "" {
+		r.header = HeaderXForwardedFor
+	}
+	if r.header != HeaderForwarded && r.header != HeaderXForwardedFor && r.header != http.CanonicalHeaderKey(HeaderXRealIP) {
+		return nil, fmt.Errorf("unsupported client ip header %q", header)
+	}
+
+	for _, p := range trustedProxies {
+		if !strings.Contains(p, "/") {
+			if ip := net.ParseIP(p); ip != nil && ip.To4() != nil {
+				p += "/32"
+			} else {
+				p += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q. error: %w", p, err)
+		}
+		r.trusted = append(r.trusted, network)
+	}
+	return r, nil
+}
+
+// Resolve returns the ip of the client. The chain of the proxies is walked from the nearest one,
+// the first address that isn't trusted is the client. ErrInvalidChain is returned when an invalid
+// address comes before it.
+func (r *Resolver) Resolve(req *http.Request) (string, error) {
+	remote := host(req.RemoteAddr)
+	ip := net.ParseIP(remote)
+	if ip == nil || !r.isTrusted(ip) {
+		return remote, nil
+	}
+
+	chain := parse(r.header, req.Header)
+	client := ip
+	for i := len(chain) - 1; i >= 0; i-- {
+		next := net.ParseIP(chain[i])
+		if next == nil {
+			return "", ErrInvalidChain
+		}
+		client = next
+		if !r.isTrusted(next) {
+			break
+		}
+	}
+	return client.String(), nil
+}
+
+func (r *Resolver) isTrusted(ip net.IP) bool {
+	for _, n := range r.trusted {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parse returns the addresses of the header from the client to the nearest proxy.
+func parse(name string, h http.Header) []string {
+	values := h.Values(name)
+	if len(values) == 0 {
+		return nil
+	}
+
+	var chain []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			part = strings.TrimSpace(part)
+			if name == HeaderForwarded {
+				part = forwardedFor(part)
+			}
+			chain = append(chain, host(part))
+		}
+	}
+	return chain
+}
+
+// forwardedFor returns the for parameter of the Forwarded element, e.g. `for="[2001:db8::17]:4711";proto=https`.
+func forwardedFor(element string) string {
+	for _, pair := range strings.Split(element, ";") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) == 2 && strings.EqualFold(kv[0], "for") {
+			return strings.Trim(kv[1], `"`)
+		}
+	}
+	return ""
+}
+
+// host strips the port and the brackets of ipv6 addresses.
+func host(addr string) string {
+	addr = strings.TrimSpace(addr)
+	if h, _, err := net.SplitHostPort(addr); err == nil {
+		return h
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+}
+
+// Handler is the middleware replacing the address of the connection with the address of the client,
+// so c.ClientIP(), the logs and the limiter see the same ip. It must go before the middleware reading the ip
+// during the request, e.g. the limiter, and the router must not trust the headers itself (ForwardedByClientIP is false).
+// The requests with an invalid chain are rejected with ErrInvalidChain, the response is sent
+// by the error middleware of the router.
+func (r *Resolver) Handler(c *gin.Context) {
+	ip, err := r.Resolve(c.Request)
+	if err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		return
+	}
+	if _, port, err := net.SplitHostPort(c.Request.RemoteAddr); err == nil {
+		c.Request.RemoteAddr = net.JoinHostPort(ip, port)
+	} else {
+		c.Request.RemoteAddr = ip
+	}
+}
@@ -0,0 +1,92 @@
+package clientip
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		remote  string
+		headers map[string]string
+		want    string
+		wantErr error
+	}{
+		{"untrusted remote ignores headers", HeaderXForwardedFor, "203.0.113.1:1234", map[string]string{HeaderXForwardedFor: "1.1.1.1"}, "203.0.113.1", nil},
+		{"trusted remote without headers", HeaderXForwardedFor, "10.0.0.1:1234", nil, "10.0.0.1", nil},
+		{"x-forwarded-for", HeaderXForwardedFor, "10.0.0.1:1234", map[string]string{HeaderXForwardedFor: "1.1.1.1, 203.0.113.7, 10.0.0.2"}, "203.0.113.7", nil},
+		{"all hops trusted", HeaderXForwardedFor, "10.0.0.1:1234", map[string]string{HeaderXForwardedFor: "10.0.0.3, 10.0.0.2"}, "10.0.0.3", nil},
+		{"invalid hop before the client", HeaderXForwardedFor, "10.0.0.1:1234", map[string]string{HeaderXForwardedFor: "1.1.1.1, garbage, 10.0.0.2"}, "", ErrInvalidChain},
+		{"invalid hop after the client", HeaderXForwardedFor, "10.0.0.1:1234", map[string]string{HeaderXForwardedFor: "garbage, 203.0.113.7, 10.0.0.2"}, "203.0.113.7", nil},
+		{"x-real-ip", HeaderXRealIP, "[::1]:1234", map[string]string{HeaderXRealIP: "203.0.113.7"}, "203.0.113.7", nil},
+		{"forwarded", HeaderForwarded, "10.0.0.1:1234", map[string]string{HeaderForwarded: `for=1.1.1.1, for="[2001:db8::17]:4711";proto=https`}, "2001:db8::17", nil},
+		// nginx appends to X-Forwarded-For and passes the Forwarded header of the client unchanged
+		{"spoofed forwarded is ignored", HeaderXForwardedFor, "10.0.0.1:1234", map[string]string{HeaderForwarded: "for=6.6.6.6", HeaderXForwardedFor: "203.0.113.7"}, "203.0.113.7", nil},
+		{"spoofed x-real-ip is ignored", HeaderXForwardedFor, "10.0.0.1:1234", map[string]string{HeaderXRealIP: "6.6.6.6", HeaderXForwardedFor: "203.0.113.7"}, "203.0.113.7", nil},
+		{"spoofed start of the chain is ignored", HeaderXForwardedFor, "10.0.0.1:1234", map[string]string{HeaderXForwardedFor: "6.6.6.6, 203.0.113.7"}, "203.0.113.7", nil},
+		{"other header without the configured one", HeaderXForwardedFor, "10.0.0.1:1234", map[string]string{HeaderForwarded: "for=6.6.6.6"}, "10.0.0.1", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(tt.header, []string{"10.0.0.0/8", "::1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := &http.Request{RemoteAddr: tt.remote, Header: http.Header{}}
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			got, err := r.Resolve(req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Resolve() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	if _, err := New(HeaderXForwardedFor, []string{"10.0.0.0/33"}); err == nil {
+		t.Error("expected error for invalid network")
+	}
+	if _, err := New("X-Client-IP", nil); err == nil {
+		t.Error("expected error for unsupported header")
+	}
+}
+
+func TestHandlerInvalidChain(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r, err := New(HeaderXForwardedFor, []string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var errs []*gin.Error
+	called := false
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	}, r.Handler)
+	router.GET("/", func(c *gin.Context) { called = true })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set(HeaderXForwardedFor, "1.1.1.1, garbage, 10.0.0.2")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if called {
+		t.Error("the handler is called after an invalid chain")
+	}
+	if len(errs) != 1 || !errors.Is(errs[0].Err, ErrInvalidChain) {
+		t.Errorf("errors = %v, want %v", errs, ErrInvalidChain)
+	}
+}
@@ -11,8 +11,9 @@ import (
 
 const timeout = 10 * time.Second
 
-func NewClient(uri, username, password string) (*mongo.Client, error) {
-	opts := options.Client().ApplyURI(uri)
+// NewClient connects to mongo. The options are applied after the uri, e.g. the monitors of the commands and the pool.
+func NewClient(uri, username, password string, clientOpts ...*options.ClientOptions) (*mongo.Client, error) {
+	opts := options.MergeClientOptions(append([]*options.ClientOptions{options.Client().ApplyURI(uri)}, clientOpts...)...)
 	if username != "" && password != "" {
 		opts.SetAuth(options.Credential{
 			Username: username,
@@ -0,0 +1,141 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Alexander272/games-library/pkg/apperror"
+)
+
+const (
+	CSV   = "csv"
+	JSONL = "jsonl"
+)
+
+// flushStep is the number of csv rows after which the buffered data is sent to the client.
+const flushStep = 100
+
+var ErrUnknownFormat = apperror.Validation("UNKNOWN_EXPORT_FORMAT", "unknown export format")
+
+// Record is a single exported row. Csv files use Values,
+// json lines files contain the record marshaled as is.
+type Record interface {
+	Values() []string
+}
+
+type Writer interface {
+	Write(r Record) error
+	Flush() error
+}
+
+// NewWriter creates the writer of the format, the header is the first row of the csv file.
+// It's written even when there are no records.
+func NewWriter(format string, w io.Writer, header []string) (Writer, error) {
+	switch format {
+	case CSV:
+		return &csvWriter{w: csv.NewWriter(w), header: header}, nil
+	case JSONL:
+		return &jsonlWriter{enc: json.NewEncoder(w)}, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func ContentType(format string) string {
+	switch format {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case JSONL:
+		return "application/x-ndjson; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+type csvWriter struct {
+	w             *csv.Writer
+	header        []string
+	headerWritten bool
+	rows          int
+}
+
+func (w *csvWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+	return w.w.Write(w.header)
+}
+
+func (w *csvWriter) Write(r Record) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	if err := w.w.Write(r.Values()); err != nil {
+		return err
+	}
+
+	w.rows++
+	if w.rows%flushStep == 0 {
+		return w.Flush()
+	}
+	return nil
+}
+
+func (w *csvWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func (w *jsonlWriter) Write(r Record) error {
+	return w.enc.Encode(r)
+}
+
+func (w *jsonlWriter) Flush() error {
+	return nil
+}
+
+// Response is the exported file sent to the client. The status and the headers of the file are sent
+// with the first written bytes, so the errors before them (e.g. of the query) can still be returned
+// as the usual error response.
+type Response struct {
+	w        http.ResponseWriter
+	format   string
+	filename string
+	started  bool
+}
+
+func NewResponse(w http.ResponseWriter, format, filename string) *Response {
+	return &Response{w: w, format: format, filename: filename}
+}
+
+func (r *Response) Write(p []byte) (int, error) {
+	r.Start()
+	return r.w.Write(p)
+}
+
+// Start sends the status and the headers if they aren't sent yet, e.g. for the empty file.
+func (r *Response) Start() {
+	if r.started {
+		return
+	}
+	r.started = true
+	r.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, r.filename))
+	r.w.Header().Set("Content-Type", ContentType(r.format))
+	r.w.WriteHeader(http.StatusOK)
+}
+
+// Started reports whether the file is partially sent, then the errors can't be returned to the client.
+func (r *Response) Started() bool {
+	return r.started
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type record struct {
+	Title string `json:"title"`
+	Year  string `json:"year"`
+}
+
+func (r record) Values() []string {
+	return []string{r.Title, r.Year}
+}
+
+var header = []string{"title", "year"}
+
+func TestWriter(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		records []Record
+		want    string
+	}{
+		{"csv", CSV, []Record{record{"Hades", "2020"}, record{"Celeste, Farewell", "2019"}}, "title,year\nHades,2020\n\"Celeste, Farewell\",2019\n"},
+		{"empty csv has the header", CSV, nil, "title,year\n"},
+		{"jsonl", JSONL, []Record{record{"Hades", "2020"}}, "{\"title\":\"Hades\",\"year\":\"2020\"}\n"},
+		{"empty jsonl", JSONL, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewWriter(tt.format, &buf, header)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, r := range tt.records {
+				if err := w.Write(r); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	if _, err := NewWriter("xml", &bytes.Buffer{}, header); err != ErrUnknownFormat {
+		t.Errorf("NewWriter() error = %v, want ErrUnknownFormat", err)
+	}
+}
+
+func TestResponse(t *testing.T) {
+	rec := httptest.NewRecorder()
+	res := NewResponse(rec, CSV, "games.csv")
+
+	// nothing is sent before the first write, so the error can still be returned
+	if res.Started() || rec.Header().Get("Content-Disposition") != "" {
+		t.Fatal("headers are sent before the first write")
+	}
+
+	w, err := NewWriter(CSV, res, header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !res.Started() || rec.Code != http.StatusOK {
+		t.Fatalf("response isn't started, status %d", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Disposition"); got != `attachment; filename="games.csv"` {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+	if got := rec.Header().Get("Content-Type"); got != ContentType(CSV) {
+		t.Errorf("Content-Type = %q", got)
+	}
+	if rec.Body.String() != "title,year\n" {
+		t.Errorf("body = %q, want the header", rec.Body.String())
+	}
+}
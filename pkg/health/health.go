@@ -0,0 +1,117 @@
+// Package health reports the liveness and the readiness of the application for the probes.
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	StatusOk   = "ok"
+	StatusFail = "fail"
+
+	defaultTimeout = 2 * time.Second
+)
+
+// CheckFunc checks one dependency, e.g. pings the database.
+type CheckFunc func(ctx context.Context) error
+
+type Check struct {
+	Name  string
+	Check CheckFunc
+}
+
+type Component struct {
+	Status  string `json:"status"`
+	Latency string `json:"latency,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status     string               `json:"status"`
+	Components map[string]Component `json:"components,omitempty"`
+}
+
+type Health struct {
+	checks       []Check
+	timeout      time.Duration
+	shuttingDown int32
+}
+
+// New creates the health with the checks of the dependencies, every check is limited by the timeout.
+func New(timeout time.Duration, checks ...Check) *Health {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Health{checks: checks, timeout: timeout}
+}
+
+// Shutdown makes the readiness fail, so the load balancer stops sending the requests before the server stops.
+func (h *Health) Shutdown() {
+	atomic.StoreInt32(&h.shuttingDown, 1)
+}
+
+// Liveness reports that the process is running, it doesn't check the dependencies,
+// so their failures don't restart the application.
+func (h *Health) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, Report{Status: StatusOk})
+}
+
+// Readiness checks the dependencies in parallel and fails if any of them fails or the application is shutting down.
+func (h *Health) Readiness(c *gin.Context) {
+	report := h.Check(c.Request.Context())
+
+	status := http.StatusOK
+	if report.Status != StatusOk {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
+
+// Check runs the checks. The errors are logged, the report has only their presence,
+// so the details of the infrastructure aren't public.
+func (h *Health) Check(ctx context.Context) Report {
+	report := Report{Status: StatusOk, Components: make(map[string]Component, len(h.checks))}
+	if atomic.LoadInt32(&h.shuttingDown) == 1 {
+		report.Status = StatusFail
+		report.Components["app"] = Component{Status: StatusFail, Error: "shutting down"}
+		return report
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range h.checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, h.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check.Check(ctx)
+			component := Component{Status: StatusOk, Latency: time.Since(start).String()}
+			if err != nil {
+				logger.Errorf("health check %s failed. error: %s", check.Name, err.Error())
+				component.Status = StatusFail
+				component.Error = "unavailable"
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Components[check.Name] = component
+			if err != nil {
+				report.Status = StatusFail
+			}
+		}(check)
+	}
+	wg.Wait()
+
+	return report
+}
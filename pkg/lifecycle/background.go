@@ -0,0 +1,52 @@
+package lifecycle
+
+import (
+	"context"
+	"sync"
+)
+
+// Background runs the goroutines outliving the requests that started them, e.g. the import jobs.
+// Their context is cancelled on stop and the stop waits for them.
+type Background struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewBackground() *Background {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Background{ctx: ctx, cancel: cancel}
+}
+
+func (b *Background) Go(fn func(ctx context.Context)) {
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		fn(b.ctx)
+	}()
+}
+
+// Hook returns the component stopping the goroutines.
+func (b *Background) Hook() Hook {
+	return Hook{
+		Name: "background jobs",
+		Stop: b.stop,
+	}
+}
+
+func (b *Background) stop(ctx context.Context) error {
+	b.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
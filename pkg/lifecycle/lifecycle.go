@@ -0,0 +1,146 @@
+// Package lifecycle starts the components of the application and stops them in reverse order on shutdown.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Alexander272/games-library/pkg/logger"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// Hook is the component of the application. All functions are optional.
+type Hook struct {
+	Name string
+	// Start is called in the order of registration, it must not block
+	Start func(ctx context.Context) error
+	// Run is the long-running part of the component, e.g. the server or the worker. It's started
+	// in a goroutine after Start, its context is cancelled on stop. An error stops the application
+	Run func(ctx context.Context) error
+	// Stop is called in the reverse order of registration, Run is waited for after it
+	Stop func(ctx context.Context) error
+}
+
+type Manager struct {
+	hooks   []Hook
+	timeout time.Duration
+}
+
+// New creates the manager, the timeout limits the stop of all components.
+func New(timeout time.Duration) *Manager {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &Manager{timeout: timeout}
+}
+
+func (m *Manager) Append(hooks ...Hook) {
+	m.hooks = append(m.hooks, hooks...)
+}
+
+type running struct {
+	hook   Hook
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Run starts the components and blocks until the context is cancelled (e.g. by a signal)
+// or a component fails. Then the started components are stopped. The error reports the failure
+// and the components that failed to stop in time.
+func (m *Manager) Run(ctx context.Context) error {
+	failed := make(chan error, len(m.hooks))
+	started := make([]*running, 0, len(m.hooks))
+
+	var cause error
+	for _, h := range m.hooks {
+		if h.Start != nil {
+			if err := h.Start(ctx); err != nil {
+				cause = fmt.Errorf("failed to start %s. error: %w", h.Name, err)
+				break
+			}
+		}
+
+		r := &running{hook: h, cancel: func() {}, done: make(chan struct{})}
+		if h.Run != nil {
+			var runCtx context.Context
+			runCtx, r.cancel = context.WithCancel(context.Background())
+			go func(h Hook) {
+				defer close(r.done)
+				if err := h.Run(runCtx); err != nil {
+					failed <- fmt.Errorf("%s failed. error: %w", h.Name, err)
+				}
+			}(h)
+		} else {
+			close(r.done)
+		}
+		started = append(started, r)
+		logger.Debugf("%s started", h.Name)
+	}
+
+	if cause == nil {
+		select {
+		case <-ctx.Done():
+		case cause = <-failed:
+		}
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	var errs []string
+	if cause != nil {
+		errs = append(errs, cause.Error())
+	}
+	for i := len(started) - 1; i >= 0; i-- {
+		if err := started[i].stop(stopCtx); err != nil {
+			errs = append(errs, err.Error())
+		}
+		logger.Debugf("%s stopped", started[i].hook.Name)
+	}
+	// the failures of the components while they were stopping
+	for len(failed) > 0 {
+		errs = append(errs, (<-failed).Error())
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+func (r *running) stop(ctx context.Context) error {
+	var err error
+	if r.hook.Stop != nil {
+		if stopErr := r.hook.Stop(ctx); stopErr != nil {
+			err = fmt.Errorf("failed to stop %s. error: %w", r.hook.Name, stopErr)
+		}
+	}
+
+	r.cancel()
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		return fmt.Errorf("%s didn't stop in time", r.hook.Name)
+	}
+	return err
+}
+
+// Sleep waits for the duration or until the context is done.
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,170 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunStopsInReverseOrder(t *testing.T) {
+	var mu sync.Mutex
+	var stopped []string
+	hook := func(name string) Hook {
+		return Hook{
+			Name: name,
+			Run: func(ctx context.Context) error {
+				<-ctx.Done()
+				return nil
+			},
+			Stop: func(context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				stopped = append(stopped, name)
+				return nil
+			},
+		}
+	}
+
+	m := New(time.Second)
+	m.Append(hook("db"), hook("worker"), hook("server"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	if err := m.Run(ctx); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if want := []string{"server", "worker", "db"}; !reflect.DeepEqual(stopped, want) {
+		t.Errorf("stopped = %v, want %v", stopped, want)
+	}
+}
+
+func TestRunStopsOnFailure(t *testing.T) {
+	var stopped bool
+	m := New(time.Second)
+	m.Append(
+		Hook{Name: "db", Stop: func(context.Context) error { stopped = true; return nil }},
+		Hook{Name: "server", Run: func(context.Context) error { return errors.New("address in use") }},
+	)
+
+	err := m.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "server failed") {
+		t.Fatalf("Run() error = %v, want the failure of the server", err)
+	}
+	if !stopped {
+		t.Error("db isn't stopped")
+	}
+}
+
+func TestRunReportsStopTimeout(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	m := New(50 * time.Millisecond)
+	m.Append(Hook{Name: "stuck", Run: func(context.Context) error {
+		<-block
+		return nil
+	}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := m.Run(ctx)
+	if err == nil || !strings.Contains(err.Error(), "stuck didn't stop in time") {
+		t.Fatalf("Run() error = %v, want the timeout", err)
+	}
+}
+
+// TestCleanExit runs the server and a background job like the application does and checks
+// that SIGTERM stops everything without errors and leaked goroutines.
+func TestCleanExit(t *testing.T) {
+	// the watcher of the signals lives as long as the process, it's started before counting
+	signal.Stop(signalWarmUp())
+	baseline := runtime.NumGoroutine()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})}
+
+	background := NewBackground()
+	jobStopped := make(chan struct{})
+	background.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		close(jobStopped)
+	})
+
+	m := New(time.Second)
+	m.Append(background.Hook(), Hook{
+		Name: "http server",
+		Run: func(context.Context) error {
+			if err := srv.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		Stop: srv.Shutdown,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+
+	res := make(chan error, 1)
+	go func() { res <- m.Run(ctx) }()
+
+	resp, err := http.Get("http://" + listener.Addr().String())
+	if err != nil {
+		t.Fatalf("server isn't running. error: %v", err)
+	}
+	resp.Body.Close()
+	http.DefaultClient.CloseIdleConnections()
+
+	process, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := process.Signal(syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-res:
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("application didn't stop")
+	}
+
+	select {
+	case <-jobStopped:
+	default:
+		t.Error("background job isn't stopped")
+	}
+
+	stop()
+	// the goroutines may need a moment to exit after they are released
+	deadline := time.Now().Add(5 * time.Second)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > baseline {
+		t.Errorf("goroutines = %d, want at most %d", n, baseline)
+	}
+}
+
+func signalWarmUp() chan os.Signal {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGUSR1)
+	return c
+}
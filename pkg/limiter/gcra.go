@@ -0,0 +1,30 @@
+package limiter
+
+import "time"
+
+// gcra decides whether the request is allowed by the generic cell rate algorithm. The state of the key
+// is the theoretical arrival time (tat) of the next request: every request moves it by the interval
+// of the rate, and the request is allowed while the tat is less than burst intervals ahead of now.
+// It returns the new tat, which is the same as the old one for denied requests.
+func gcra(now, tat time.Time, rate Rate) (time.Time, Result) {
+	interval := rate.interval()
+	tolerance := interval * time.Duration(rate.burst())
+
+	if tat.Before(now) {
+		tat = now
+	}
+	newTat := tat.Add(interval)
+	diff := now.Sub(newTat.Add(-tolerance))
+
+	res := Result{Limit: rate.burst()}
+	if diff < 0 {
+		res.RetryAfter = -diff
+		res.ResetAfter = tat.Sub(now)
+		return tat, res
+	}
+
+	res.Allowed = true
+	res.Remaining = int(diff / interval)
+	res.ResetAfter = newTat.Sub(now)
+	return newTat, res
+}
@@ -1,95 +1,88 @@
 package limiter
 
 import (
-	"net"
-	"net/http"
-	"sync"
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/sirupsen/logrus"
-	"golang.org/x/time/rate"
+	"github.com/go-redis/redis/v8"
 )
 
-type visitor struct {
-	limiter  *rate.Limiter
-	lastSeen time.Time
-}
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
 
-type rateLimiter struct {
-	sync.RWMutex
+// Rate allows Count requests per Period with bursts of up to Burst requests.
+type Rate struct {
+	Count  int
+	Period time.Duration
+	Burst  int
+}
 
-	visitors map[string]*visitor
-	limit    rate.Limit
-	burst    int
-	ttl      time.Duration
+// IsZero reports whether the rate is unset, such rate doesn't limit the requests.
+func (r Rate) IsZero() bool {
+	return r.Count <= 0 || r.Period <= 0
 }
 
-// newRateLimiter creates an instance of the rateLimite
-func newRateLimiter(rps, burst int, ttl time.Duration) *rateLimiter {
-	return &rateLimiter{
-		visitors: make(map[string]*visitor),
-		limit:    rate.Limit(rps),
-		burst:    burst,
-		ttl:      ttl,
+// interval is the time in which one request is restored.
+func (r Rate) interval() time.Duration {
+	if r.IsZero() {
+		return time.Nanosecond
+	}
+	if i := r.Period / time.Duration(r.Count); i > 0 {
+		return i
 	}
+	return time.Nanosecond
 }
 
-// getVisitor returns limiter for the specific visitor by its IP,
-// looking up within the visitors map.
-func (l *rateLimiter) getVisitor(ip string) *rate.Limiter {
-	l.RLock()
-	v, exists := l.visitors[ip]
-	l.RUnlock()
-
-	if !exists {
-		limiter := rate.NewLimiter(l.limit, l.burst)
-		l.Lock()
-		l.visitors[ip] = &visitor{limiter, time.Now()}
-		l.Unlock()
-
-		return limiter
+func (r Rate) burst() int {
+	if r.Burst < 1 {
+		return 1
 	}
+	return r.Burst
+}
 
-	v.lastSeen = time.Now()
-	return v.limiter
+// Result is the decision of the limiter for one request.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is the time after which the denied request will be allowed
+	RetryAfter time.Duration
+	// ResetAfter is the time after which the limit is fully restored
+	ResetAfter time.Duration
 }
 
-// cleanupVisitors removes old entries from the visitors map.
-func (l *rateLimiter) cleanupVisitors() {
-	for {
-		time.Sleep(time.Minute)
+// Backend stores the state of the limits. The memory backend limits the requests to one instance,
+// the redis backend shares the limits between all instances of the application.
+type Backend interface {
+	Allow(ctx context.Context, key string, rate Rate) (Result, error)
+}
 
-		l.Lock()
-		for ip, v := range l.visitors {
-			if time.Since(v.lastSeen) > l.ttl {
-				delete(l.visitors, ip)
-			}
+// NewBackend creates the backend by its name. The memory backend removes the idle keys every ttl.
+func NewBackend(name string, client *redis.Client, ttl time.Duration) (Backend, error) {
+	switch name {
+	case "", BackendMemory:
+		return NewMemoryBackend(ttl), nil
+	case BackendRedis:
+		if client == nil {
+			return nil, fmt.Errorf("redis client is required for the %s limiter backend", name)
 		}
-		l.Unlock()
+		return NewRedisBackend(client), nil
+	default:
+		return nil, fmt.Errorf("unknown limiter backend %q", name)
 	}
 }
 
-// Limit creates a new rate limiter middleware handler.
-func Limit(rps, burst int, ttl time.Duration) gin.HandlerFunc {
-	l := newRateLimiter(rps, burst, ttl)
-
-	// run a background worker to clean up old entries
-	go l.cleanupVisitors()
-
-	return func(c *gin.Context) {
-		ip, _, err := net.SplitHostPort(c.Request.RemoteAddr)
-		if err != nil {
-			logrus.Errorf("failed to run limiter middleware. error: %w", err)
-			c.AbortWithStatus(http.StatusInternalServerError)
-			return
-		}
-
-		if !l.getVisitor(ip).Allow() {
-			c.AbortWithStatus(http.StatusTooManyRequests)
-			return
-		}
-
-		c.Next()
+// Limit creates the rate limiter middleware with one policy for all routes.
+// The requests are limited by the client ip.
+func Limit(backend Backend, rate Rate) gin.HandlerFunc {
+	l, err := New(backend, []Policy{{Name: DefaultPolicy, Anonymous: rate}}, nil, nil)
+	if err != nil {
+		// the default policy always exists
+		panic(err)
 	}
+	return l.Handler
 }
@@ -0,0 +1,57 @@
+package limiter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Limit(NewMemoryBackend(time.Minute), Rate{Count: 1, Period: time.Hour, Burst: 10}))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	codes := make(chan int, 40)
+	var wg sync.WaitGroup
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			// two clients with their own limits
+			req.RemoteAddr = []string{"10.0.0.1:1234", "10.0.0.2:1234"}[i%2]
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			codes <- w.Code
+		}(i)
+	}
+	wg.Wait()
+	close(codes)
+
+	count := map[int]int{}
+	for code := range codes {
+		count[code]++
+	}
+	if count[http.StatusOK] != 20 || count[http.StatusTooManyRequests] != 20 {
+		t.Errorf("got %v, want 20 allowed and 20 limited requests", count)
+	}
+}
+
+func TestLimitZeroRate(t *testing.T) {
+	router := gin.New()
+	router.Use(Limit(NewMemoryBackend(time.Minute), Rate{}))
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for i := 0; i < 5; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: status %d, want 200", i+1, w.Code)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultTTL = 10 * time.Minute
+
+// MemoryBackend keeps the limits in the memory of the process.
+type MemoryBackend struct {
+	mu          sync.Mutex
+	tats        map[string]time.Time
+	ttl         time.Duration
+	lastCleanup time.Time
+
+	now func() time.Time
+}
+
+// NewMemoryBackend creates the backend that removes the idle keys every ttl.
+func NewMemoryBackend(ttl time.Duration) *MemoryBackend {
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+	return &MemoryBackend{
+		tats: make(map[string]time.Time),
+		ttl:  ttl,
+		now:  time.Now,
+	}
+}
+
+func (b *MemoryBackend) Allow(ctx context.Context, key string, rate Rate) (Result, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.cleanup(now)
+
+	tat, res := gcra(now, b.tats[key], rate)
+	b.tats[key] = tat
+	return res, nil
+}
+
+// cleanup removes the keys whose limits are fully restored, they are the same as the missing ones.
+// It must be called with the lock held.
+func (b *MemoryBackend) cleanup(now time.Time) {
+	if b.lastCleanup.IsZero() {
+		b.lastCleanup = now
+	}
+	if now.Sub(b.lastCleanup) < b.ttl {
+		return
+	}
+	for key, tat := range b.tats {
+		if !tat.After(now) {
+			delete(b.tats, key)
+		}
+	}
+	b.lastCleanup = now
+}
@@ -0,0 +1,100 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestBackend(now *time.Time) *MemoryBackend {
+	b := NewMemoryBackend(time.Minute)
+	b.now = func() time.Time { return *now }
+	return b
+}
+
+func TestMemoryBackendBurst(t *testing.T) {
+	now := time.Date(2021, time.December, 1, 12, 0, 0, 0, time.UTC)
+	b := newTestBackend(&now)
+	rate := Rate{Count: 1, Period: time.Second, Burst: 3}
+
+	for i := 0; i < 3; i++ {
+		res, err := b.Allow(context.Background(), "ip", rate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Allowed {
+			t.Fatalf("request %d is denied", i+1)
+		}
+		if res.Remaining != 2-i {
+			t.Errorf("request %d: remaining = %d, want %d", i+1, res.Remaining, 2-i)
+		}
+	}
+
+	res, _ := b.Allow(context.Background(), "ip", rate)
+	if res.Allowed {
+		t.Fatal("request over the burst is allowed")
+	}
+	if res.RetryAfter != time.Second {
+		t.Errorf("retry after = %s, want 1s", res.RetryAfter)
+	}
+	if res.ResetAfter != 3*time.Second {
+		t.Errorf("reset after = %s, want 3s", res.ResetAfter)
+	}
+
+	// other keys have their own limits
+	if res, _ := b.Allow(context.Background(), "other", rate); !res.Allowed {
+		t.Error("request of the other key is denied")
+	}
+
+	now = now.Add(time.Second)
+	if res, _ := b.Allow(context.Background(), "ip", rate); !res.Allowed {
+		t.Error("request is denied after the interval")
+	}
+}
+
+func TestMemoryBackendCleanup(t *testing.T) {
+	now := time.Date(2021, time.December, 1, 12, 0, 0, 0, time.UTC)
+	b := newTestBackend(&now)
+	rate := Rate{Count: 10, Period: time.Second, Burst: 5}
+
+	b.Allow(context.Background(), "idle", rate)
+	now = now.Add(2 * time.Minute)
+	b.Allow(context.Background(), "active", rate)
+
+	if _, ok := b.tats["idle"]; ok {
+		t.Error("idle key isn't removed")
+	}
+	if _, ok := b.tats["active"]; !ok {
+		t.Error("active key is removed")
+	}
+}
+
+func TestMemoryBackendConcurrent(t *testing.T) {
+	now := time.Date(2021, time.December, 1, 12, 0, 0, 0, time.UTC)
+	b := newTestBackend(&now)
+	rate := Rate{Count: 1, Period: time.Hour, Burst: 50}
+
+	var allowed int64
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := b.Allow(context.Background(), "ip", rate)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if res.Allowed {
+				atomic.AddInt64(&allowed, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 50 {
+		t.Errorf("allowed %d requests, want 50", allowed)
+	}
+}
@@ -0,0 +1,168 @@
+package limiter
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Alexander272/games-library/pkg/apperror"
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/Alexander272/games-library/pkg/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultPolicy is used for the routes without a policy.
+const DefaultPolicy = "default"
+
+// The prefixes of the identities returned by IdentityFunc.
+const (
+	IdentityUser   = "user:"
+	IdentityAPIKey = "apikey:"
+)
+
+var ErrLimited = apperror.New(apperror.KindTooManyRequests, "TOO_MANY_REQUESTS", "too many requests")
+
+var rejected = metrics.NewCounterVec("limiter_rejected_requests_total", "Number of the requests rejected by the limiter.", "policy")
+
+// Policy is the named limit of the requests. Anonymous requests are limited by the client ip,
+// the requests with an identity (the user id or the api key) are limited by the identity.
+type Policy struct {
+	Name      string
+	Anonymous Rate
+	// Authenticated is the rate of the requests with an identity, the anonymous rate is used if it's empty
+	Authenticated Rate
+	// APIKey is the rate of the requests with an api key, the authenticated rate is used if it's empty
+	APIKey Rate
+}
+
+// Route binds the routes to the policy. The path is the pattern of the gin route, e.g. "/api/games/:id",
+// the path ending with "*" matches all routes with the prefix. Empty method matches every method.
+type Route struct {
+	Method string
+	Path   string
+	Policy string
+}
+
+func (r Route) match(method, path string) bool {
+	if r.Method != "" && !strings.EqualFold(r.Method, method) {
+		return false
+	}
+	if prefix := strings.TrimSuffix(r.Path, "*"); prefix != r.Path {
+		return strings.HasPrefix(path, prefix)
+	}
+	return r.Path == path
+}
+
+// IdentityFunc returns the identity of the request, "user:<id>" or "apikey:<name>",
+// or an empty string for anonymous requests.
+type IdentityFunc func(c *gin.Context) string
+
+// Limiter limits the requests by the policies of the routes.
+type Limiter struct {
+	backend  Backend
+	policies map[string]Policy
+	routes   []Route
+	identify IdentityFunc
+}
+
+// New creates the limiter. The routes are matched in order, the routes without a match use the default policy.
+// Without the default policy such routes aren't limited.
+func New(backend Backend, policies []Policy, routes []Route, identify IdentityFunc) (*Limiter, error) {
+	l := &Limiter{
+		backend:  backend,
+		policies: make(map[string]Policy, len(policies)),
+		routes:   routes,
+		identify: identify,
+	}
+	for _, p := range policies {
+		l.policies[p.Name] = p
+	}
+	for _, r := range routes {
+		if _, ok := l.policies[r.Policy]; !ok {
+			return nil, fmt.Errorf("unknown policy %q of route %s %s", r.Policy, r.Method, r.Path)
+		}
+	}
+	return l, nil
+}
+
+// Handler is the middleware. It must be used after the routing, so the pattern of the route is known.
+// Limited requests are aborted with ErrLimited. When the backend fails, the request is let through,
+// so the api stays available without redis.
+func (l *Limiter) Handler(c *gin.Context) {
+	policy, ok := l.policy(c.Request.Method, c.FullPath())
+	if !ok {
+		return
+	}
+
+	key, rate, err := l.key(c, policy)
+	if err != nil {
+		logger.FromContext(c).Error("failed to run limiter middleware", map[string]interface{}{"error": err.Error()})
+		return
+	}
+	if rate.IsZero() {
+		return
+	}
+
+	res, err := l.backend.Allow(c, policy.Name+":"+key, rate)
+	if err != nil {
+		logger.FromContext(c).Error("failed to check rate limit", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	setHeaders(c.Writer.Header(), res)
+	if !res.Allowed {
+		rejected.Inc(policy.Name)
+		_ = c.Error(ErrLimited)
+		c.AbortWithStatus(http.StatusTooManyRequests)
+	}
+}
+
+func (l *Limiter) policy(method, path string) (Policy, bool) {
+	for _, r := range l.routes {
+		if r.match(method, path) {
+			return l.policies[r.Policy], true
+		}
+	}
+	p, ok := l.policies[DefaultPolicy]
+	return p, ok
+}
+
+func (l *Limiter) key(c *gin.Context, policy Policy) (string, Rate, error) {
+	if l.identify != nil {
+		if id := l.identify(c); id != "" {
+			return id, policy.rate(id), nil
+		}
+	}
+
+	// the address is resolved by the client ip middleware, gin doesn't trust the forwarding headers itself
+	return "ip:" + c.ClientIP(), policy.Anonymous, nil
+}
+
+// rate returns the rate of the identity falling back to the less specific rates.
+func (p Policy) rate(id string) Rate {
+	if strings.HasPrefix(id, IdentityAPIKey) && !p.APIKey.IsZero() {
+		return p.APIKey
+	}
+	if !p.Authenticated.IsZero() {
+		return p.Authenticated
+	}
+	return p.Anonymous
+}
+
+// setHeaders sets the RateLimit headers of the IETF draft and Retry-After for limited requests.
+func setHeaders(h http.Header, res Result) {
+	h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(seconds(res.ResetAfter)))
+	if !res.Allowed {
+		h.Set("Retry-After", strconv.Itoa(seconds(res.RetryAfter)))
+	}
+}
+
+// seconds rounds the duration up, so the client doesn't retry too early.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,159 @@
+package limiter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newTestRouter(t *testing.T) *gin.Engine {
+	policies := []Policy{
+		{Name: DefaultPolicy, Anonymous: Rate{Count: 1, Period: time.Hour, Burst: 3}},
+		{
+			Name:          "auth",
+			Anonymous:     Rate{Count: 1, Period: time.Minute, Burst: 1},
+			Authenticated: Rate{Count: 1, Period: time.Minute, Burst: 2},
+			APIKey:        Rate{Count: 1, Period: time.Minute, Burst: 4},
+		},
+	}
+	routes := []Route{
+		{Method: http.MethodPost, Path: "/api/auth/*", Policy: "auth"},
+	}
+	identify := func(c *gin.Context) string {
+		if key := c.GetHeader("X-API-Key"); key != "" {
+			return IdentityAPIKey + key
+		}
+		if token := c.GetHeader("Authorization"); token != "" {
+			return IdentityUser + token
+		}
+		return ""
+	}
+
+	l, err := New(NewMemoryBackend(time.Minute), policies, routes, identify)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := gin.New()
+	api := router.Group("/api", l.Handler)
+	api.GET("/games/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	api.POST("/auth/sign-in", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func serve(router *gin.Engine, method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPolicies(t *testing.T) {
+	router := newTestRouter(t)
+
+	// the route policy is separate from the default one
+	if w := serve(router, http.MethodPost, "/api/auth/sign-in", ""); w.Code != http.StatusOK {
+		t.Fatalf("first sign in: status %d", w.Code)
+	}
+	w := serve(router, http.MethodPost, "/api/auth/sign-in", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("second sign in: status %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("Retry-After = %q, want 60", got)
+	}
+
+	// the route with the default policy
+	for i := 0; i < 3; i++ {
+		w := serve(router, http.MethodGet, "/api/games/1", "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("game request %d: status %d", i+1, w.Code)
+		}
+		if got, want := w.Header().Get("RateLimit-Remaining"), []string{"2", "1", "0"}[i]; got != want {
+			t.Errorf("game request %d: RateLimit-Remaining = %q, want %q", i+1, got, want)
+		}
+	}
+	if w := serve(router, http.MethodGet, "/api/games/1", ""); w.Code != http.StatusTooManyRequests {
+		t.Errorf("game request over the limit: status %d, want 429", w.Code)
+	}
+
+	// users from the same ip have their own limits with the authenticated rate
+	for i := 0; i < 2; i++ {
+		w := serve(router, http.MethodPost, "/api/auth/sign-in", "token")
+		if w.Code != http.StatusOK {
+			t.Fatalf("user request %d: status %d", i+1, w.Code)
+		}
+		if got := w.Header().Get("RateLimit-Limit"); got != "2" {
+			t.Errorf("user request %d: RateLimit-Limit = %q, want 2", i+1, got)
+		}
+	}
+}
+
+func TestAPIKeyPolicy(t *testing.T) {
+	router := newTestRouter(t)
+
+	serveKey := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/sign-in", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set("X-API-Key", key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// the api key has its own rate and its own limit
+	for i := 0; i < 4; i++ {
+		w := serveKey("partner")
+		if w.Code != http.StatusOK {
+			t.Fatalf("api key request %d: status %d", i+1, w.Code)
+		}
+		if got := w.Header().Get("RateLimit-Limit"); got != "4" {
+			t.Errorf("api key request %d: RateLimit-Limit = %q, want 4", i+1, got)
+		}
+	}
+	if w := serveKey("partner"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("api key request over the limit: status %d, want 429", w.Code)
+	}
+	if w := serveKey("mobile"); w.Code != http.StatusOK {
+		t.Errorf("other api key: status %d, want 200", w.Code)
+	}
+}
+
+func TestPolicyRate(t *testing.T) {
+	anonymous := Rate{Count: 1, Period: time.Second}
+	authenticated := Rate{Count: 2, Period: time.Second}
+	apiKey := Rate{Count: 3, Period: time.Second}
+
+	tests := []struct {
+		name   string
+		policy Policy
+		id     string
+		want   Rate
+	}{
+		{"api key", Policy{Anonymous: anonymous, Authenticated: authenticated, APIKey: apiKey}, IdentityAPIKey + "partner", apiKey},
+		{"api key without its rate", Policy{Anonymous: anonymous, Authenticated: authenticated}, IdentityAPIKey + "partner", authenticated},
+		{"api key with the anonymous rate only", Policy{Anonymous: anonymous}, IdentityAPIKey + "partner", anonymous},
+		{"user", Policy{Anonymous: anonymous, Authenticated: authenticated, APIKey: apiKey}, IdentityUser + "1", authenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.rate(tt.id); got != tt.want {
+				t.Errorf("rate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnknownPolicy(t *testing.T) {
+	_, err := New(NewMemoryBackend(time.Minute), nil, []Route{{Path: "/api/*", Policy: "missing"}}, nil)
+	if err == nil {
+		t.Error("route with unknown policy is accepted")
+	}
+}
@@ -0,0 +1,69 @@
+package limiter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const keyPrefix = "limiter:"
+
+// gcraScript is the same algorithm as gcra, run atomically in redis. The time of redis is used,
+// so the instances of the application don't depend on their clocks. Times are in microseconds.
+var gcraScript = redis.NewScript(`
+redis.replicate_commands()
+
+local interval = tonumber(ARGV[1])
+local tolerance = interval * tonumber(ARGV[2])
+
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+local tat = tonumber(redis.call("GET", KEYS[1]))
+if not tat or tat < now then
+	tat = now
+end
+
+local new_tat = tat + interval
+local diff = now - (new_tat - tolerance)
+if diff < 0 then
+	return {0, 0, -diff, tat - now}
+end
+
+redis.call("SET", KEYS[1], new_tat, "PX", math.ceil((new_tat - now) / 1000))
+return {1, math.floor(diff / interval), 0, new_tat - now}
+`)
+
+// RedisBackend keeps the limits in redis, so they are shared by all instances and survive restarts.
+type RedisBackend struct {
+	client *redis.Client
+}
+
+func NewRedisBackend(client *redis.Client) *RedisBackend {
+	return &RedisBackend{client: client}
+}
+
+func (b *RedisBackend) Allow(ctx context.Context, key string, rate Rate) (Result, error) {
+	interval := rate.interval().Microseconds()
+	if interval < 1 {
+		interval = 1
+	}
+	args := []interface{}{interval, rate.burst()}
+	values, err := gcraScript.Run(ctx, b.client, []string{keyPrefix + key}, args...).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to execute script. error: %w", err)
+	}
+	if len(values) != 4 {
+		return Result{}, fmt.Errorf("unexpected result of script %v", values)
+	}
+
+	return Result{
+		Allowed:    values[0] == 1,
+		Limit:      rate.burst(),
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Microsecond,
+		ResetAfter: time.Duration(values[3]) * time.Microsecond,
+	}, nil
+}
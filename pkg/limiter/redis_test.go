@@ -0,0 +1,58 @@
+package limiter
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// The test needs a running redis, e.g. LIMITER_TEST_REDIS=localhost:6379 go test -race ./pkg/limiter
+func newTestRedis(t *testing.T) *redis.Client {
+	addr := os.Getenv("LIMITER_TEST_REDIS")
+	if addr == "" {
+		t.Skip("LIMITER_TEST_REDIS isn't set")
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		t.Fatalf("failed to connect to redis. error: %s", err.Error())
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestRedisBackendShared(t *testing.T) {
+	client := newTestRedis(t)
+	key := "test:" + time.Now().Format(time.RFC3339Nano)
+	rate := Rate{Count: 1, Period: time.Hour, Burst: 20}
+
+	// two backends are two instances of the application sharing the limit
+	backends := []Backend{NewRedisBackend(client), NewRedisBackend(client)}
+
+	var allowed int64
+	var wg sync.WaitGroup
+	for i := 0; i < 60; i++ {
+		wg.Add(1)
+		go func(b Backend) {
+			defer wg.Done()
+			res, err := b.Allow(context.Background(), key, rate)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if res.Allowed {
+				atomic.AddInt64(&allowed, 1)
+			}
+		}(backends[i%2])
+	}
+	wg.Wait()
+
+	if allowed != 20 {
+		t.Errorf("allowed %d requests, want 20", allowed)
+	}
+	client.Del(context.Background(), keyPrefix+key)
+}
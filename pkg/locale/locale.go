@@ -0,0 +1,83 @@
+package locale
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	En = "en"
+	Ru = "ru"
+
+	// Default is used when the client accepts none of the supported locales
+	// and is the last locale of every fallback chain.
+	Default = En
+)
+
+// Supported are the locales of the localised content.
+var Supported = []string{En, Ru}
+
+// IsSupported reports whether the locale is one of the supported locales.
+func IsSupported(locale string) bool {
+	for _, l := range Supported {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate selects the locale for the request. The explicit lang parameter wins, then the locales
+// of the Accept-Language header are tried in the order of their quality, e.g. "ru-RU,ru;q=0.9,en;q=0.8".
+func Negotiate(lang, acceptLanguage string) string {
+	if l := base(lang); IsSupported(l) {
+		return l
+	}
+
+	type accepted struct {
+		locale  string
+		quality float64
+	}
+	var locales []accepted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		quality := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if strings.HasPrefix(f, "q=") {
+				if q, err := strconv.ParseFloat(strings.TrimPrefix(f, "q="), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality > 0 {
+			locales = append(locales, accepted{locale: base(fields[0]), quality: quality})
+		}
+	}
+	sort.SliceStable(locales, func(i, j int) bool { return locales[i].quality > locales[j].quality })
+
+	for _, l := range locales {
+		if IsSupported(l.locale) {
+			return l.locale
+		}
+	}
+	return Default
+}
+
+// Chain is the order in which localised values are looked up: the locale itself, then the default one.
+func Chain(locale string) []string {
+	if locale == "" || locale == Default {
+		return []string{Default}
+	}
+	return []string{locale, Default}
+}
+
+// base returns the language of the tag, e.g. "ru" for "ru-RU".
+func base(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
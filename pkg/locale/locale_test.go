@@ -0,0 +1,47 @@
+package locale
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   []string
+	}{
+		{"", []string{En}},
+		{En, []string{En}},
+		{Ru, []string{Ru, En}},
+	}
+	for _, tt := range tests {
+		if got := Chain(tt.locale); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Chain(%q) = %v, want %v", tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name           string
+		lang           string
+		acceptLanguage string
+		want           string
+	}{
+		{"lang parameter", "ru", "en", Ru},
+		{"lang with region", "RU_ru", "", Ru},
+		{"unsupported lang", "de", "ru", Ru},
+		{"header by quality", "", "en;q=0.5, ru-RU;q=0.9", Ru},
+		{"same quality keeps order", "", "en, ru", En},
+		{"zero quality", "", "ru;q=0, de", Default},
+		{"nothing supported", "", "de-DE,fr;q=0.8", Default},
+		{"empty", "", "", Default},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Negotiate(tt.lang, tt.acceptLanguage); got != tt.want {
+				t.Errorf("Negotiate(%q, %q) = %q, want %q", tt.lang, tt.acceptLanguage, got, tt.want)
+			}
+		})
+	}
+}
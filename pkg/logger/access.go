@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessLog is the middleware replacing gin.Logger. It adds the route to the logger of the request
+// and writes one message per request when the request is completed.
+func AccessLog(c *gin.Context) {
+	start := time.Now()
+
+	With(c, map[string]interface{}{FieldRoute: c.FullPath()})
+
+	c.Next()
+
+	status, size := c.Writer.Status(), c.Writer.Size()
+	if size < 0 {
+		size = 0
+	}
+	fields := map[string]interface{}{
+		"method":  c.Request.Method,
+		"path":    RequestPath(c),
+		"status":  status,
+		"latency": time.Since(start).String(),
+		"ip":      c.ClientIP(),
+		"size":    size,
+	}
+	if len(c.Errors) > 0 {
+		fields["errors"] = c.Errors.String()
+	}
+
+	l := FromContext(c)
+	if status >= http.StatusInternalServerError {
+		l.Error("request completed", fields)
+		return
+	}
+	l.Info("request completed", fields)
+}
+
+// SecretParams are the path params masked in the logged path, e.g. the token of the shared list
+// which gives access to the list.
+var SecretParams = []string{"token"}
+
+// RequestPath returns the path of the request with the values of SecretParams masked.
+// The path is built from the route, the unmatched requests have no params and are returned as is.
+func RequestPath(c *gin.Context) string {
+	route := c.FullPath()
+	if route == "" {
+		return c.Request.URL.Path
+	}
+
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if len(segment) < 2 || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+		name := segment[1:]
+		if isSecretParam(name) {
+			segments[i] = redacted
+			continue
+		}
+		// the catch-all param starts with the slash
+		segments[i] = strings.TrimPrefix(c.Param(name), "/")
+	}
+	return strings.Join(segments, "/")
+}
+
+func isSecretParam(name string) bool {
+	for _, p := range SecretParams {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
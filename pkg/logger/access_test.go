@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAccessLogPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		route    string
+		target   string
+		wantPath string
+	}{
+		{"params", "/games/:id/releases/:releaseId", "/games/doom/releases/1", "/games/doom/releases/1"},
+		{"secret param", "/lists/shared/:token", "/lists/shared/" + refreshToken, "/lists/shared/" + redacted},
+		{"catch-all param", "/files/*path", "/files/covers/doom.png", "/files/covers/doom.png"},
+		{"unmatched", "/games", "/unknown/path", "/unknown/path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			Init(&buf)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(AccessLog)
+			router.GET(tt.route, func(c *gin.Context) { c.Status(http.StatusOK) })
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			out := buf.String()
+			if strings.Contains(out, refreshToken) {
+				t.Errorf("access log contains the token: %s", out)
+			}
+			// the text formatter quotes the values with brackets
+			if !strings.Contains(out, "path="+tt.wantPath+" ") && !strings.Contains(out, `path="`+tt.wantPath+`"`) {
+				t.Errorf("access log = %s, want path=%s", out, tt.wantPath)
+			}
+		})
+	}
+}
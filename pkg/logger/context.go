@@ -0,0 +1,34 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// contextKey is a plain string, so gin.Context finds the logger in its keys too
+// and the handlers can pass c to the services as the context.
+const contextKey = "logger"
+
+// NewContext returns the context carrying the logger.
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, contextKey, l)
+}
+
+// FromContext returns the logger of the request or the logger without fields.
+func FromContext(ctx context.Context) *Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(contextKey).(*Logger); ok {
+			return l
+		}
+	}
+	return New()
+}
+
+// With adds the params to the logger of the request, they are written with every message
+// of the request including the access log.
+func With(c *gin.Context, params map[string]interface{}) {
+	l := FromContext(c).With(params)
+	c.Set(contextKey, l)
+	c.Request = c.Request.WithContext(NewContext(c.Request.Context(), l))
+}
@@ -0,0 +1,40 @@
+package logger
+
+import "github.com/sirupsen/logrus"
+
+var _ ILogger = (*Logger)(nil)
+
+// Logger is the logger with the fields added to every message, e.g. the id of the request.
+// It writes to the global logrus instance, so it uses the level and the format set by Init and Configure.
+type Logger struct {
+	entry *logrus.Entry
+}
+
+func New() *Logger {
+	return &Logger{entry: logrus.NewEntry(logrus.StandardLogger())}
+}
+
+// With returns the logger with the params added to the fields of l.
+func (l *Logger) With(params map[string]interface{}) *Logger {
+	return &Logger{entry: l.entry.WithFields(params)}
+}
+
+func (l *Logger) Trace(msg string, params map[string]interface{}) {
+	l.entry.WithFields(params).Trace(msg)
+}
+
+func (l *Logger) Debug(msg string, params map[string]interface{}) {
+	l.entry.WithFields(params).Debug(msg)
+}
+
+func (l *Logger) Info(msg string, params map[string]interface{}) {
+	l.entry.WithFields(params).Info(msg)
+}
+
+func (l *Logger) Error(msg string, params map[string]interface{}) {
+	l.entry.WithFields(params).Error(msg)
+}
+
+func (l *Logger) Fatal(msg string, params map[string]interface{}) {
+	l.entry.WithFields(params).Fatal(msg)
+}
@@ -1,5 +1,18 @@
 package logger
 
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Names of the fields of the request-scoped loggers.
+const (
+	FieldRequestId = "request_id"
+	FieldUserId    = "user_id"
+	FieldRoute     = "route"
+	FieldTraceId   = "trace_id"
+)
+
 type ILogger interface {
 	Trace(msg string, params map[string]interface{})
 	Debug(msg string, params map[string]interface{})
@@ -7,3 +20,11 @@ type ILogger interface {
 	Error(msg string, params map[string]interface{})
 	Fatal(msg string, params map[string]interface{})
 }
+
+// Config sets the level (trace, debug, info, warn, error) and the format (text, json) of the output.
+// The values of the Redact fields are masked, DefaultRedactFields are used if it's empty.
+type Config struct {
+	Level  string
+	Format string
+	Redact []string
+}
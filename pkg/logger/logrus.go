@@ -12,15 +12,44 @@ import (
 func Init(out io.Writer) {
 	logrus.SetLevel(logrus.TraceLevel)
 	logrus.SetFormatter(&logrus.TextFormatter{
-		CallerPrettyfier: func(f *runtime.Frame) (string, string) {
-			filename := path.Base(f.File)
-			return fmt.Sprintf("%s:%d", filename, f.Line), fmt.Sprintf("%s()", f.Function)
-		},
-		DisableColors: false,
-		FullTimestamp: true,
+		CallerPrettyfier: callerPrettyfier,
+		DisableColors:    false,
+		FullTimestamp:    true,
 	})
 
 	logrus.SetOutput(out)
+	logrus.StandardLogger().ReplaceHooks(logrus.LevelHooks{})
+	logrus.AddHook(NewRedactor(nil))
+}
+
+// Configure sets the level, the format and the redacted fields from the config, Init must be called before.
+func Configure(conf Config) error {
+	if conf.Level != "" {
+		level, err := logrus.ParseLevel(conf.Level)
+		if err != nil {
+			return err
+		}
+		logrus.SetLevel(level)
+	}
+
+	logrus.StandardLogger().ReplaceHooks(logrus.LevelHooks{})
+	logrus.AddHook(NewRedactor(conf.Redact))
+
+	switch conf.Format {
+	case "", FormatText:
+	case FormatJSON:
+		logrus.SetFormatter(&logrus.JSONFormatter{
+			CallerPrettyfier: callerPrettyfier,
+		})
+	default:
+		return fmt.Errorf("unknown log format %q", conf.Format)
+	}
+	return nil
+}
+
+func callerPrettyfier(f *runtime.Frame) (string, string) {
+	filename := path.Base(f.File)
+	return fmt.Sprintf("%s:%d", filename, f.Line), fmt.Sprintf("%s()", f.Function)
 }
 
 func Trace(msg ...interface{}) {
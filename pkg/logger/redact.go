@@ -0,0 +1,156 @@
+package logger
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const redacted = "[REDACTED]"
+
+// DefaultRedactFields are masked when the config doesn't set the fields. The names match
+// the keys containing them, e.g. "token" matches "refresh_token" and "RefreshToken".
+var DefaultRedactFields = []string{"password", "token", "authorization", "cookie", "secret", "email"}
+
+var (
+	bearerPattern = regexp.MustCompile(`(?i)\b(bearer)\s+[A-Za-z0-9\-._~+/]+=*`)
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// keyValuePattern finds `key=value`, `key: value` and `"key":"value"` pairs in the messages
+	keyValuePattern = regexp.MustCompile(`([A-Za-z0-9_\-]+)("?\s*[:=]\s*)("[^"]*"|[^"\s,;&}]+)`)
+)
+
+// Redactor is the logrus hook masking the values of the sensitive fields and the pairs with their names
+// in the messages. Emails are masked partially, so the logs still show whose request it is.
+type Redactor struct {
+	fields []string
+}
+
+// NewRedactor creates the hook masking the fields, DefaultRedactFields are used without the fields.
+func NewRedactor(fields []string) *Redactor {
+	if len(fields) == 0 {
+		fields = DefaultRedactFields
+	}
+	r := &Redactor{}
+	for _, f := range fields {
+		r.fields = append(r.fields, normalize(f))
+	}
+	return r
+}
+
+func (r *Redactor) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (r *Redactor) Fire(entry *logrus.Entry) error {
+	entry.Message = r.Redact(entry.Message)
+	for key, value := range entry.Data {
+		entry.Data[key] = r.field(key, value)
+	}
+	return nil
+}
+
+// Redact masks the sensitive values in the text.
+func (r *Redactor) Redact(text string) string {
+	text = bearerPattern.ReplaceAllString(text, "$1 "+redacted)
+	text = keyValuePattern.ReplaceAllStringFunc(text, func(pair string) string {
+		m := keyValuePattern.FindStringSubmatch(pair)
+		key, sep, value := m[1], m[2], m[3]
+		if !r.isSensitive(key) {
+			// the value may be a pair itself, e.g. "error: token=..."
+			return key + sep + r.Redact(value)
+		}
+		if unquoted := strings.Trim(value, `"`); unquoted != value {
+			return key + sep + `"` + r.mask(key, unquoted) + `"`
+		}
+		return key + sep + r.mask(key, value)
+	})
+	return emailPattern.ReplaceAllStringFunc(text, maskEmail)
+}
+
+func (r *Redactor) field(key string, value interface{}) interface{} {
+	if r.isSensitive(key) {
+		return r.mask(key, fmt.Sprint(value))
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return r.Redact(v)
+	case error:
+		return r.Redact(v.Error())
+	case fmt.Stringer:
+		return r.Redact(v.String())
+	}
+	return r.value(reflect.ValueOf(value))
+}
+
+// value masks the sensitive fields of the structs and the maps, other values are kept as they are.
+func (r *Redactor) value(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		res := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			res[f.Name] = r.field(f.Name, v.Field(i).Interface())
+		}
+		return res
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return v.Interface()
+		}
+		res := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			res[key] = r.field(key, iter.Value().Interface())
+		}
+		return res
+	case reflect.String:
+		return r.Redact(v.String())
+	}
+	return v.Interface()
+}
+
+func (r *Redactor) isSensitive(key string) bool {
+	key = normalize(key)
+	for _, f := range r.fields {
+		if strings.Contains(key, f) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redactor) mask(key, value string) string {
+	if strings.Contains(normalize(key), "email") && emailPattern.MatchString(value) {
+		return emailPattern.ReplaceAllStringFunc(value, maskEmail)
+	}
+	return redacted
+}
+
+// maskEmail keeps the first letter of the name and the domain, e.g. "j***@example.com".
+func maskEmail(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 1 {
+		return redacted
+	}
+	return email[:1] + "***" + email[at:]
+}
+
+func normalize(name string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+}
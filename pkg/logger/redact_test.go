@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+const refreshToken = "f3a9c1d27b5e4a8c9d0e1f2a3b4c5d6e"
+
+type session struct {
+	UserId       string
+	Email        string
+	RefreshToken string
+}
+
+func newTestLogger(formatter logrus.Formatter) (*Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	l := logrus.New()
+	l.SetOutput(&buf)
+	l.SetFormatter(formatter)
+	l.SetLevel(logrus.TraceLevel)
+	l.AddHook(NewRedactor(nil))
+	return &Logger{entry: logrus.NewEntry(l)}, &buf
+}
+
+func TestRedactorRefreshToken(t *testing.T) {
+	formatters := map[string]logrus.Formatter{
+		"text": &logrus.TextFormatter{DisableColors: true},
+		"json": &logrus.JSONFormatter{},
+	}
+
+	for name, f := range formatters {
+		t.Run(name, func(t *testing.T) {
+			l, buf := newTestLogger(f)
+
+			l.Info("refresh", map[string]interface{}{"refresh_token": refreshToken})
+			l.Info("refresh", map[string]interface{}{"RefreshToken": refreshToken})
+			l.Info("refresh", map[string]interface{}{"session": session{UserId: "1", RefreshToken: refreshToken}})
+			l.Info("refresh", map[string]interface{}{"session": &session{UserId: "1", RefreshToken: refreshToken}})
+			l.Info("refresh", map[string]interface{}{"data": map[string]string{"token": refreshToken}})
+			l.Info("refresh", map[string]interface{}{"cookie": "refresh_token=" + refreshToken})
+			l.Info("refresh", map[string]interface{}{"errors": errors.New("invalid token=" + refreshToken)})
+			l.Info("refresh token="+refreshToken, nil)
+			l.Info("refresh token: "+refreshToken, nil)
+			l.Info(`body {"refreshToken":"`+refreshToken+`"}`, nil)
+			l.Info("header Cookie: refresh_token="+refreshToken+"; lang=ru", nil)
+			l.Info("header Authorization: Bearer "+refreshToken, nil)
+			l.Info("failed: token="+refreshToken, nil)
+			l.entry.Infof("session %v", fmt.Sprintf("refresh_token=%s", refreshToken))
+
+			if strings.Contains(buf.String(), refreshToken) {
+				t.Fatalf("refresh token found in output:\n%s", buf.String())
+			}
+			if c := strings.Count(buf.String(), redacted); c < 14 {
+				t.Errorf("expected every line to be redacted, got %d redactions:\n%s", c, buf.String())
+			}
+		})
+	}
+}
+
+func TestRedactorEmail(t *testing.T) {
+	l, buf := newTestLogger(&logrus.JSONFormatter{})
+
+	l.Info("user john.doe@example.com signed in", map[string]interface{}{"email": "john.doe@example.com"})
+
+	out := buf.String()
+	if strings.Contains(out, "john.doe") {
+		t.Fatalf("email found in output: %s", out)
+	}
+	if c := strings.Count(out, "j***@example.com"); c != 2 {
+		t.Errorf("expected partially masked email twice, got %d: %s", c, out)
+	}
+}
+
+func TestRedactorKeepsOtherFields(t *testing.T) {
+	l, buf := newTestLogger(&logrus.JSONFormatter{})
+
+	l.Info("request completed", map[string]interface{}{"route": "/api/games/:id", "status": 200, "error": "user: not found"})
+
+	out := buf.String()
+	for _, s := range []string{`"route":"/api/games/:id"`, `"status":200`, `"error":"user: not found"`} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected %s in output: %s", s, out)
+		}
+	}
+	if strings.Contains(out, redacted) {
+		t.Errorf("unexpected redaction: %s", out)
+	}
+}
+
+func TestRedactorFields(t *testing.T) {
+	r := NewRedactor([]string{"ua"})
+
+	if got := r.Redact("ua=Mozilla token=abc"); got != "ua="+redacted+" token=abc" {
+		t.Errorf("Redact() = %q", got)
+	}
+}
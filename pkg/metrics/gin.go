@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	httpRequests = NewCounterVec("http_requests_total",
+		"Number of the http requests by the route and the status.", "method", "route", "status")
+	httpDuration = NewHistogramVec("http_request_duration_seconds",
+		"Duration of the http requests by the route.", DefBuckets, "method", "route")
+)
+
+// Middleware counts the requests and observes their durations. The requests without a route
+// are counted together, so unknown paths don't create new series.
+func Middleware(c *gin.Context) {
+	start := time.Now()
+
+	c.Next()
+
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	httpRequests.Inc(c.Request.Method, route, strconv.Itoa(c.Writer.Status()))
+	httpDuration.ObserveDuration(start, c.Request.Method, route)
+}
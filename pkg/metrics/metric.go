@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefBuckets are the buckets of the durations in seconds.
+var DefBuckets = prometheus.DefBuckets
+
+// CounterVec is the counter partitioned by the labels.
+type CounterVec struct {
+	vec *prometheus.CounterVec
+}
+
+// NewCounterVec creates the counter and registers it in the default registry.
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	vec := prometheus.NewCounterVec(prometheus.CounterOpts{Name: name, Help: help}, labels)
+	Default.MustRegister(vec)
+	return &CounterVec{vec: vec}
+}
+
+func (c *CounterVec) Inc(values ...string) {
+	c.vec.WithLabelValues(values...).Inc()
+}
+
+// Add increases the counter, negative deltas are ignored.
+func (c *CounterVec) Add(delta float64, values ...string) {
+	if delta < 0 {
+		return
+	}
+	c.vec.WithLabelValues(values...).Add(delta)
+}
+
+// GaugeVec is the gauge partitioned by the labels.
+type GaugeVec struct {
+	vec *prometheus.GaugeVec
+}
+
+// NewGaugeVec creates the gauge and registers it in the default registry.
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	vec := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, labels)
+	Default.MustRegister(vec)
+	return &GaugeVec{vec: vec}
+}
+
+func (g *GaugeVec) Set(value float64, values ...string) {
+	g.vec.WithLabelValues(values...).Set(value)
+}
+
+func (g *GaugeVec) Add(delta float64, values ...string) {
+	g.vec.WithLabelValues(values...).Add(delta)
+}
+
+// HistogramVec counts the observations in the buckets, partitioned by the labels.
+type HistogramVec struct {
+	vec *prometheus.HistogramVec
+}
+
+// NewHistogramVec creates the histogram with the upper bounds of the buckets in increasing order
+// and registers it in the default registry. DefBuckets are used without the buckets.
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefBuckets
+	}
+	vec := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: name, Help: help, Buckets: buckets}, labels)
+	Default.MustRegister(vec)
+	return &HistogramVec{vec: vec}
+}
+
+func (h *HistogramVec) Observe(value float64, values ...string) {
+	h.vec.WithLabelValues(values...).Observe(value)
+}
+
+// ObserveDuration observes the time since start in seconds.
+func (h *HistogramVec) ObserveDuration(start time.Time, values ...string) {
+	h.Observe(time.Since(start).Seconds(), values...)
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCounterConcurrent(t *testing.T) {
+	c := NewCounterVec("test_concurrent_total", "Counter updated concurrently.", "route")
+	g := NewGaugeVec("test_concurrent_gauge", "Gauge updated concurrently.", "route")
+
+	const workers, updates = 16, 1000
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < updates; j++ {
+				c.Inc("/games")
+				c.Add(-1, "/games")
+				g.Add(1, "/games")
+				g.Add(-1, "/games")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := testutil.ToFloat64(c.vec.WithLabelValues("/games")); got != workers*updates {
+		t.Errorf("counter = %v, want %d", got, workers*updates)
+	}
+	if got := testutil.ToFloat64(g.vec.WithLabelValues("/games")); got != 0 {
+		t.Errorf("gauge = %v, want 0", got)
+	}
+}
+
+func TestHistogramBucketEdges(t *testing.T) {
+	h := NewHistogramVec("test_edges_seconds", "Histogram with the observations on the edges.", []float64{0.1, 1}, "route")
+	for _, v := range []float64{0, 0.1, 0.10000001, 1, 1.5} {
+		h.Observe(v, "/games")
+	}
+
+	families, err := Default.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range families {
+		if f.GetName() != "test_edges_seconds" {
+			continue
+		}
+		hist := f.GetMetric()[0].GetHistogram()
+		// the buckets are cumulative and their upper bounds are inclusive
+		want := map[float64]uint64{0.1: 2, 1: 4}
+		for _, b := range hist.GetBucket() {
+			if b.GetCumulativeCount() != want[b.GetUpperBound()] {
+				t.Errorf("bucket le=%v = %d, want %d", b.GetUpperBound(), b.GetCumulativeCount(), want[b.GetUpperBound()])
+			}
+		}
+		if hist.GetSampleCount() != 5 {
+			t.Errorf("count = %d, want 5", hist.GetSampleCount())
+		}
+		return
+	}
+	t.Fatal("histogram isn't registered")
+}
+
+func TestHandler(t *testing.T) {
+	c := NewCounterVec("test_handler_total", "Counter\nexposed by the handler.", "route")
+	c.Inc(`/a"b`)
+
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := w.Body.String()
+	for _, want := range []string{
+		`# HELP test_handler_total Counter\nexposed by the handler.`,
+		"# TYPE test_handler_total counter",
+		`test_handler_total{route="/a\"b"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("response doesn't contain %q:\n%s", want, body)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+var (
+	mongoConnections = NewGaugeVec("mongo_pool_connections",
+		"Number of the connections of the mongo pool by the state (open, in_use).", "address", "state")
+	mongoCheckoutFailures = NewCounterVec("mongo_pool_checkout_failures_total",
+		"Number of the failed checkouts of the mongo connections.", "address")
+)
+
+// NewMongoPoolMonitor creates the pool monitor of the mongo driver counting the connections.
+func NewMongoPoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(e *event.PoolEvent) {
+			switch e.Type {
+			case event.ConnectionCreated:
+				mongoConnections.Add(1, e.Address, "open")
+			case event.ConnectionClosed:
+				mongoConnections.Add(-1, e.Address, "open")
+			case event.GetSucceeded:
+				mongoConnections.Add(1, e.Address, "in_use")
+			case event.ConnectionReturned:
+				mongoConnections.Add(-1, e.Address, "in_use")
+			case event.GetFailed:
+				mongoCheckoutFailures.Inc(e.Address)
+			}
+		},
+	}
+}
+
+// RegisterRedisPool registers the stats of the pool of the client in the default registry.
+// The stats are read on every scrape.
+func RegisterRedisPool(client *redis.Client) {
+	counter := func(name, help string, value func(s *redis.PoolStats) uint32) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{Name: name, Help: help}, func() float64 {
+			return float64(value(client.PoolStats()))
+		})
+	}
+	gauge := func(name, help string, value func(s *redis.PoolStats) uint32) prometheus.Collector {
+		return prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: name, Help: help}, func() float64 {
+			return float64(value(client.PoolStats()))
+		})
+	}
+
+	Default.MustRegister(
+		counter("redis_pool_hits_total", "Number of times a free connection was found in the pool.",
+			func(s *redis.PoolStats) uint32 { return s.Hits }),
+		counter("redis_pool_misses_total", "Number of times a free connection was not found in the pool.",
+			func(s *redis.PoolStats) uint32 { return s.Misses }),
+		counter("redis_pool_timeouts_total", "Number of times a wait timeout occurred.",
+			func(s *redis.PoolStats) uint32 { return s.Timeouts }),
+		gauge("redis_pool_connections", "Number of connections in the pool.",
+			func(s *redis.PoolStats) uint32 { return s.TotalConns }),
+		gauge("redis_pool_idle_connections", "Number of idle connections in the pool.",
+			func(s *redis.PoolStats) uint32 { return s.IdleConns }),
+		counter("redis_pool_stale_connections_total", "Number of stale connections removed from the pool.",
+			func(s *redis.PoolStats) uint32 { return s.StaleConns }),
+	)
+}
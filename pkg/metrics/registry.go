@@ -0,0 +1,18 @@
+// Package metrics collects the metrics of the application and exposes them in the Prometheus text format.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Default is the registry of the metrics created by the constructors of the package. It isn't the global
+// registry of client_golang, so only the metrics registered here are exposed.
+var Default = prometheus.NewRegistry()
+
+// Handler exposes the metrics of the default registry.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Default, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,11 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus/collectors"
+
+// RegisterRuntime registers the metrics of the go runtime and the process in the default registry.
+func RegisterRuntime() {
+	Default.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
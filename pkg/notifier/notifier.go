@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/Alexander272/games-library/pkg/logger"
+)
+
+type Message struct {
+	UserId  string
+	Email   string
+	Subject string
+	Text    string
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// LogNotifier writes messages to the application log. It is used until a real delivery channel is configured.
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(ctx context.Context, msg Message) error {
+	logger.Infof("notification to user %s <%s>: %s. %s", msg.UserId, msg.Email, msg.Subject, msg.Text)
+	return nil
+}
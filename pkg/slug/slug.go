@@ -0,0 +1,114 @@
+package slug
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxSuffix limits the search of a free slug, e.g. "the-witcher-3-99".
+const maxSuffix = 100
+
+var ErrNoFreeSlug = errors.New("failed to find a free slug")
+
+var slugRe = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// cyrillic is the transliteration of the russian alphabet, so "Ведьмак 3" becomes "vedmak-3".
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+	// ukrainian and belarusian letters
+	'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u",
+}
+
+// latin are the letters that have no decomposition to ascii letters with diacritics.
+var latin = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'ł': "l", 'đ': "d", 'ð': "d", 'þ': "th", 'ı': "i",
+}
+
+// Make converts the title to the url friendly form, e.g. "The Witcher 3: Wild Hunt"
+// becomes "the-witcher-3-wild-hunt". Diacritics are removed, so "Pokémon" becomes "pokemon",
+// and cyrillic letters are transliterated.
+func Make(title string) string {
+	var b strings.Builder
+	dash := false
+	write := func(s string) {
+		if dash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteString(s)
+		dash = false
+	}
+
+	for _, r := range strings.ToLower(title) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			write(string(r))
+			continue
+		}
+		if t, ok := cyrillic[r]; ok {
+			// soft and hard signs are dropped without splitting the word
+			if t != "" {
+				write(t)
+			}
+			continue
+		}
+		if t := fold(r); t != "" {
+			write(t)
+			continue
+		}
+		dash = true
+	}
+	return b.String()
+}
+
+// fold returns the ascii letters of the latin letter with diacritics, e.g. "e" for "é".
+// It returns the empty string for other characters.
+func fold(r rune) string {
+	if t, ok := latin[r]; ok {
+		return t
+	}
+
+	var b strings.Builder
+	for _, d := range norm.NFD.String(string(r)) {
+		if d < unicode.MaxASCII && (unicode.IsLetter(d) || unicode.IsDigit(d)) {
+			b.WriteRune(d)
+			continue
+		}
+		if !unicode.Is(unicode.Mn, d) {
+			return ""
+		}
+	}
+	return b.String()
+}
+
+// Unique returns the base slug or the base with the first free numeric suffix,
+// e.g. "doom", "doom-2", "doom-3". The taken func reports whether the slug is in use.
+func Unique(base string, taken func(slug string) (bool, error)) (string, error) {
+	for i := 1; i <= maxSuffix; i++ {
+		candidate := base
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, i)
+		}
+
+		used, err := taken(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !used {
+			return candidate, nil
+		}
+	}
+	return "", ErrNoFreeSlug
+}
+
+// IsValid reports whether the string is a well-formed slug.
+func IsValid(s string) bool {
+	return slugRe.MatchString(s)
+}
@@ -0,0 +1,82 @@
+package slug
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"The Witcher 3: Wild Hunt", "the-witcher-3-wild-hunt"},
+		{"  DOOM  ", "doom"},
+		{"Pokémon Sword", "pokemon-sword"},
+		{"Ōkami HD", "okami-hd"},
+		{"Straße", "strasse"},
+		{"Ведьмак 3: Дикая Охота", "vedmak-3-dikaya-okhota"},
+		{"Сталкер: Тень Чернобыля", "stalker-ten-chernobylya"},
+		{"Йога", "yoga"},
+		{"S.T.A.L.K.E.R.", "s-t-a-l-k-e-r"},
+		{"Half-Life 2 — Episode One", "half-life-2-episode-one"},
+		{"!!!", ""},
+		{"東方", ""},
+	}
+	for _, tt := range tests {
+		if got := Make(tt.title); got != tt.want {
+			t.Errorf("Make(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestUnique(t *testing.T) {
+	errDb := errors.New("connection refused")
+
+	tests := []struct {
+		name    string
+		taken   map[string]bool
+		err     error
+		want    string
+		wantErr error
+	}{
+		{"free", map[string]bool{}, nil, "doom", nil},
+		{"first suffix", map[string]bool{"doom": true}, nil, "doom-2", nil},
+		{"next suffix", map[string]bool{"doom": true, "doom-2": true}, nil, "doom-3", nil},
+		{"error", map[string]bool{}, errDb, "", errDb},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Unique("doom", func(slug string) (bool, error) {
+				return tt.taken[slug], tt.err
+			})
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Errorf("Unique() = %q, %v, want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUniqueNoFreeSlug(t *testing.T) {
+	calls := 0
+	_, err := Unique("doom", func(slug string) (bool, error) {
+		calls++
+		return true, nil
+	})
+	if !errors.Is(err, ErrNoFreeSlug) || calls != maxSuffix {
+		t.Errorf("Unique() error = %v after %d calls, want %v after %d", err, calls, ErrNoFreeSlug, maxSuffix)
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	for _, s := range []string{"doom", "the-witcher-3", "a1-b2"} {
+		if !IsValid(s) {
+			t.Errorf("IsValid(%q) = false", s)
+		}
+	}
+	for _, s := range []string{"", "Doom", "doom-", "-doom", "doom--2", "pokémon", "doom 2"} {
+		if IsValid(s) {
+			t.Errorf("IsValid(%q) = true", s)
+		}
+	}
+}
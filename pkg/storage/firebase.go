@@ -51,7 +51,24 @@ func NewFileStorage(bucketName, pathToCredentials string) (*FileStore, error) {
 	}, nil
 }
 
-func (fs *FileStore) Upload(ctx context.Context, file multipart.File, header *multipart.FileHeader, path, name string) (File, error) {
+// Ping checks that the bucket is reachable.
+func (fs *FileStore) Ping(ctx context.Context) error {
+	if _, err := fs.storage.Attrs(ctx); err != nil {
+		return fmt.Errorf("failed to get bucket attrs. error: %w", err)
+	}
+	return nil
+}
+
+func (fs *FileStore) Upload(ctx context.Context, file multipart.File, header *multipart.FileHeader, path, name string) (res File, err error) {
+	start := time.Now()
+	defer func() {
+		result := "success"
+		if err != nil {
+			result = "failure"
+		}
+		uploadDuration.ObserveDuration(start, result)
+	}()
+
 	fileBytes, err := io.ReadAll(file)
 	if err != nil {
 		return File{}, fmt.Errorf("failed to read file. error: %w", err)
@@ -81,6 +98,8 @@ func (fs *FileStore) Upload(ctx context.Context, file multipart.File, header *mu
 		}
 	}
 
+	uploadSize.Observe(float64(len(newFile)))
+
 	uuid := uuid.New()
 	wc := fs.storage.Object(filepath.Join(path, filename)).NewWriter(ctx)
 	wc.ObjectAttrs.Metadata = map[string]string{"firebaseStorageDownloadTokens": uuid.String()}
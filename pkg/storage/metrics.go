@@ -0,0 +1,10 @@
+package storage
+
+import "github.com/Alexander272/games-library/pkg/metrics"
+
+var (
+	uploadSize = metrics.NewHistogramVec("storage_upload_size_bytes", "Size of the uploaded files after the compression.",
+		[]float64{16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20, 16 << 20})
+	uploadDuration = metrics.NewHistogramVec("storage_upload_duration_seconds", "Duration of the uploads by the result.",
+		metrics.DefBuckets, "result")
+)
@@ -0,0 +1,272 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// OTLPExporter sends the spans to the collector with OTLP/HTTP in the JSON encoding.
+type OTLPExporter struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func NewOTLPExporter(endpoint string, headers map[string]string, timeout time.Duration) *OTLPExporter {
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	return &OTLPExporter{
+		url:     strings.TrimSuffix(endpoint, "/") + "/v1/traces",
+		headers: headers,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (e *OTLPExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	body, err := json.Marshal(newTraceRequest(spans))
+	if err != nil {
+		return fmt.Errorf("failed to marshal spans. error: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request. error: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send spans. error: %w", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("failed to send spans. status: %s", res.Status)
+	}
+	return nil
+}
+
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// StdoutExporter writes the spans as json lines, it's for local runs.
+type StdoutExporter struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func NewStdoutExporter(out io.Writer) *StdoutExporter {
+	return &StdoutExporter{enc: json.NewEncoder(out)}
+}
+
+func (e *StdoutExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, s := range spans {
+		if err := e.enc.Encode(newSpan(s)); err != nil {
+			return fmt.Errorf("failed to write span. error: %w", err)
+		}
+	}
+	return nil
+}
+
+func (e *StdoutExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// The types below are the JSON mapping of the OTLP ExportTraceServiceRequest.
+// Ids are hex strings, 64-bit integers are decimal strings.
+type (
+	traceRequest struct {
+		ResourceSpans []resourceSpans `json:"resourceSpans"`
+	}
+	resourceSpans struct {
+		Resource   resourceJSON `json:"resource"`
+		ScopeSpans []scopeSpans `json:"scopeSpans"`
+	}
+	resourceJSON struct {
+		Attributes []keyValue `json:"attributes,omitempty"`
+	}
+	scopeSpans struct {
+		Scope scope      `json:"scope"`
+		Spans []spanJSON `json:"spans"`
+	}
+	scope struct {
+		Name    string `json:"name"`
+		Version string `json:"version,omitempty"`
+	}
+	spanJSON struct {
+		TraceId           string      `json:"traceId"`
+		SpanId            string      `json:"spanId"`
+		ParentSpanId      string      `json:"parentSpanId,omitempty"`
+		Name              string      `json:"name"`
+		Kind              int         `json:"kind"`
+		StartTimeUnixNano string      `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string      `json:"endTimeUnixNano"`
+		Attributes        []keyValue  `json:"attributes,omitempty"`
+		Events            []eventJSON `json:"events,omitempty"`
+		Status            statusJSON  `json:"status"`
+	}
+	eventJSON struct {
+		TimeUnixNano string     `json:"timeUnixNano"`
+		Name         string     `json:"name"`
+		Attributes   []keyValue `json:"attributes,omitempty"`
+	}
+	statusJSON struct {
+		Code    int    `json:"code,omitempty"`
+		Message string `json:"message,omitempty"`
+	}
+	keyValue struct {
+		Key   string   `json:"key"`
+		Value anyValue `json:"value"`
+	}
+	anyValue struct {
+		StringValue *string     `json:"stringValue,omitempty"`
+		BoolValue   *bool       `json:"boolValue,omitempty"`
+		IntValue    *string     `json:"intValue,omitempty"`
+		DoubleValue *float64    `json:"doubleValue,omitempty"`
+		ArrayValue  *arrayValue `json:"arrayValue,omitempty"`
+	}
+	arrayValue struct {
+		Values []anyValue `json:"values"`
+	}
+)
+
+func newTraceRequest(spans []sdktrace.ReadOnlySpan) traceRequest {
+	var req traceRequest
+	resources := make(map[string]int)
+	scopes := make(map[string]int)
+
+	for _, s := range spans {
+		resKey := s.Resource().Encoded(attribute.DefaultEncoder())
+		ri, ok := resources[resKey]
+		if !ok {
+			ri = len(req.ResourceSpans)
+			resources[resKey] = ri
+			req.ResourceSpans = append(req.ResourceSpans, resourceSpans{
+				Resource: resourceJSON{Attributes: newKeyValues(s.Resource().Attributes())},
+			})
+		}
+
+		rs := &req.ResourceSpans[ri]
+		sc := s.InstrumentationScope()
+		scopeKey := resKey + "|" + sc.Name + "|" + sc.Version
+		si, ok := scopes[scopeKey]
+		if !ok {
+			si = len(rs.ScopeSpans)
+			scopes[scopeKey] = si
+			rs.ScopeSpans = append(rs.ScopeSpans, scopeSpans{Scope: scope{Name: sc.Name, Version: sc.Version}})
+		}
+		rs.ScopeSpans[si].Spans = append(rs.ScopeSpans[si].Spans, newSpan(s))
+	}
+	return req
+}
+
+func newSpan(s sdktrace.ReadOnlySpan) spanJSON {
+	span := spanJSON{
+		TraceId:           s.SpanContext().TraceID().String(),
+		SpanId:            s.SpanContext().SpanID().String(),
+		Name:              s.Name(),
+		Kind:              int(s.SpanKind()),
+		StartTimeUnixNano: unixNano(s.StartTime()),
+		EndTimeUnixNano:   unixNano(s.EndTime()),
+		Attributes:        newKeyValues(s.Attributes()),
+		Status:            newStatus(s.Status()),
+	}
+	if s.Parent().HasSpanID() {
+		span.ParentSpanId = s.Parent().SpanID().String()
+	}
+	for _, e := range s.Events() {
+		span.Events = append(span.Events, eventJSON{
+			TimeUnixNano: unixNano(e.Time),
+			Name:         e.Name,
+			Attributes:   newKeyValues(e.Attributes),
+		})
+	}
+	return span
+}
+
+// newStatus maps the codes of otel to OTLP, they differ: OTLP has Unset 0, Ok 1, Error 2.
+func newStatus(s sdktrace.Status) statusJSON {
+	switch s.Code {
+	case codes.Ok:
+		return statusJSON{Code: 1}
+	case codes.Error:
+		return statusJSON{Code: 2, Message: s.Description}
+	}
+	return statusJSON{}
+}
+
+func newKeyValues(attrs []attribute.KeyValue) []keyValue {
+	if len(attrs) == 0 {
+		return nil
+	}
+	res := make([]keyValue, 0, len(attrs))
+	for _, a := range attrs {
+		res = append(res, keyValue{Key: string(a.Key), Value: newValue(a.Value)})
+	}
+	return res
+}
+
+func newValue(v attribute.Value) anyValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		b := v.AsBool()
+		return anyValue{BoolValue: &b}
+	case attribute.INT64:
+		i := strconv.FormatInt(v.AsInt64(), 10)
+		return anyValue{IntValue: &i}
+	case attribute.FLOAT64:
+		f := v.AsFloat64()
+		return anyValue{DoubleValue: &f}
+	case attribute.BOOLSLICE, attribute.INT64SLICE, attribute.FLOAT64SLICE, attribute.STRINGSLICE:
+		var values []anyValue
+		switch v.Type() {
+		case attribute.BOOLSLICE:
+			for _, b := range v.AsBoolSlice() {
+				values = append(values, newValue(attribute.BoolValue(b)))
+			}
+		case attribute.INT64SLICE:
+			for _, i := range v.AsInt64Slice() {
+				values = append(values, newValue(attribute.Int64Value(i)))
+			}
+		case attribute.FLOAT64SLICE:
+			for _, f := range v.AsFloat64Slice() {
+				values = append(values, newValue(attribute.Float64Value(f)))
+			}
+		default:
+			for _, s := range v.AsStringSlice() {
+				values = append(values, newValue(attribute.StringValue(s)))
+			}
+		}
+		return anyValue{ArrayValue: &arrayValue{Values: values}}
+	}
+	s := v.Emit()
+	return anyValue{StringValue: &s}
+}
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
@@ -0,0 +1,182 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// golden compares the value marshalled to json with the file in testdata.
+func golden(t *testing.T, name string, v interface{}) {
+	t.Helper()
+	got, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s differs:\n%s\nwant:\n%s", name, got, want)
+	}
+}
+
+func testSpans() []sdktrace.ReadOnlySpan {
+	traceId, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	start := time.Unix(1700000000, 0).UTC()
+	res := resource.NewSchemaless(attribute.String("service.name", "games-library"))
+	scope := instrumentation.Library{Name: instrumentationName, Version: "1.0.0"}
+	spanContext := func(id byte) trace.SpanContext {
+		return trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceId,
+			SpanID:     trace.SpanID{0, 0, 0, 0, 0, 0, 0, id},
+			TraceFlags: trace.FlagsSampled,
+		})
+	}
+
+	stubs := tracetest.SpanStubs{
+		{
+			Name:        "GET /api/games/:id",
+			SpanContext: spanContext(1),
+			SpanKind:    trace.SpanKindServer,
+			StartTime:   start,
+			EndTime:     start.Add(25 * time.Millisecond),
+			Attributes:  []attribute.KeyValue{attribute.Int("http.status_code", 500)},
+			Events: []sdktrace.Event{{
+				Name:       "exception",
+				Time:       start.Add(20 * time.Millisecond),
+				Attributes: []attribute.KeyValue{attribute.String("exception.message", "connection refused")},
+			}},
+			Status:                 sdktrace.Status{Code: codes.Error, Description: "Internal Server Error"},
+			Resource:               res,
+			InstrumentationLibrary: scope,
+		},
+		{
+			Name:                   "mongo.find",
+			SpanContext:            spanContext(2),
+			Parent:                 spanContext(1),
+			SpanKind:               trace.SpanKindClient,
+			StartTime:              start.Add(time.Millisecond),
+			EndTime:                start.Add(20 * time.Millisecond),
+			Attributes:             []attribute.KeyValue{attribute.String("db.system", "mongodb")},
+			Status:                 sdktrace.Status{Code: codes.Ok},
+			Resource:               res,
+			InstrumentationLibrary: scope,
+		},
+		{
+			Name:                   "redis.get",
+			SpanContext:            spanContext(3),
+			Parent:                 spanContext(1),
+			SpanKind:               trace.SpanKindClient,
+			StartTime:              start.Add(21 * time.Millisecond),
+			EndTime:                start.Add(22 * time.Millisecond),
+			Resource:               res,
+			InstrumentationLibrary: instrumentation.Library{Name: "github.com/go-redis/redis"},
+		},
+	}
+	return stubs.Snapshots()
+}
+
+func TestNewTraceRequest(t *testing.T) {
+	golden(t, "trace_request.json", newTraceRequest(testSpans()))
+}
+
+func TestNewValue(t *testing.T) {
+	attrs := []attribute.KeyValue{
+		attribute.String("string", "games"),
+		attribute.Bool("bool", true),
+		attribute.Int64("int", 9007199254740993),
+		attribute.Float64("float", 0.25),
+		attribute.BoolSlice("bools", []bool{true, false}),
+		attribute.Int64Slice("ints", []int64{1, -2}),
+		attribute.Float64Slice("floats", []float64{1.5}),
+		attribute.StringSlice("strings", []string{"a", "b"}),
+	}
+	golden(t, "values.json", newKeyValues(attrs))
+}
+
+func TestNewStatus(t *testing.T) {
+	tests := []struct {
+		status sdktrace.Status
+		want   string
+	}{
+		{sdktrace.Status{Code: codes.Unset}, `{}`},
+		{sdktrace.Status{Code: codes.Ok}, `{"code":1}`},
+		// the description is sent only with the error
+		{sdktrace.Status{Code: codes.Ok, Description: "fine"}, `{"code":1}`},
+		{sdktrace.Status{Code: codes.Error, Description: "timeout"}, `{"code":2,"message":"timeout"}`},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(newStatus(tt.status))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("newStatus(%v) = %s, want %s", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestExportSpans(t *testing.T) {
+	var body []byte
+	var header http.Header
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/traces" {
+			t.Errorf("request = %s %s, want POST /v1/traces", r.Method, r.URL.Path)
+		}
+		header = r.Header
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	e := NewOTLPExporter(srv.URL+"/", map[string]string{"Api-Key": "collector-key"}, time.Second)
+	spans := testSpans()
+	if err := e.ExportSpans(context.Background(), spans); err != nil {
+		t.Fatalf("ExportSpans() error = %v", err)
+	}
+
+	if header.Get("Content-Type") != "application/json" || header.Get("Api-Key") != "collector-key" {
+		t.Errorf("headers = %v", header)
+	}
+	want, err := json.Marshal(newTraceRequest(spans))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(body, want) {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+
+	status = http.StatusServiceUnavailable
+	if err := e.ExportSpans(context.Background(), spans); err == nil {
+		t.Error("ExportSpans() with the failed collector returns no error")
+	}
+}
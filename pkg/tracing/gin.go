@@ -0,0 +1,67 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Alexander272/games-library/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+const errorsKey = attribute.Key("http.errors")
+
+// Middleware continues the trace of the traceparent header or starts a new one
+// and wraps the request in a server span named by the route.
+func Middleware(c *gin.Context) {
+	ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+	route := c.FullPath()
+	name := c.Request.Method + " " + route
+	if route == "" {
+		name = c.Request.Method
+	}
+
+	ctx, span := tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.NetAttributesFromHTTPRequest("tcp", c.Request)...),
+		trace.WithAttributes(serverAttributes(c, route)...),
+	)
+	defer span.End()
+
+	c.Request = c.Request.WithContext(ctx)
+	c.Set(spanKey, span)
+	if sc := span.SpanContext(); sc.IsValid() {
+		logger.With(c, map[string]interface{}{logger.FieldTraceId: sc.TraceID().String()})
+	}
+	// the response carries the trace, so the client can report it
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(c.Writer.Header()))
+
+	c.Next()
+
+	status := c.Writer.Status()
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+	if len(c.Errors) > 0 {
+		span.SetAttributes(errorsKey.String(c.Errors.String()))
+	}
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+}
+
+// serverAttributes are the http attributes of the request. The target is the path with the secret params
+// masked like in the access log, the raw uri would export the token of the shared list and the query.
+func serverAttributes(c *gin.Context, route string) []attribute.KeyValue {
+	attrs := semconv.HTTPServerAttributesFromHTTPRequest("", route, c.Request)
+	for i, attr := range attrs {
+		if attr.Key == semconv.HTTPTargetKey {
+			attrs[i] = semconv.HTTPTargetKey.String(logger.RequestPath(c))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,79 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMiddlewareParent(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware)
+	router.GET("/games/:id", func(c *gin.Context) {
+		// the services get gin.Context and the contexts derived from it
+		ctx, cancel := context.WithCancel(c)
+		defer cancel()
+		_, span := tracer().Start(parent(ctx), "mongo.find")
+		span.End()
+	})
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/games/1", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("ended %d spans, want 2", len(spans))
+	}
+	child, server := spans[0], spans[1]
+	if server.Name() != "GET /games/:id" || child.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Errorf("span %q has the parent %s, want the child of %q %s",
+			child.Name(), child.Parent().SpanID(), server.Name(), server.SpanContext().SpanID())
+	}
+}
+
+func TestParentWithoutRequest(t *testing.T) {
+	ctx := context.Background()
+	if got := parent(ctx); got != ctx {
+		t.Error("parent() changed the context without a span")
+	}
+}
+
+func TestMiddlewareTarget(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	const token = "f3a9c1d27b5e4a8c9d0e1f2a3b4c5d6e"
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware)
+	router.GET("/lists/shared/:token", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/lists/shared/"+token+"?token="+token, nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("ended %d spans, want 1", len(spans))
+	}
+	for _, attr := range spans[0].Attributes() {
+		if strings.Contains(attr.Value.Emit(), token) {
+			t.Errorf("attribute %s exports the token: %s", attr.Key, attr.Value.Emit())
+		}
+		if attr.Key == semconv.HTTPTargetKey && attr.Value.AsString() != "/lists/shared/[REDACTED]" {
+			t.Errorf("http.target = %s, want the masked path", attr.Value.AsString())
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type mongoMonitor struct {
+	mu    sync.Mutex
+	spans map[int64]trace.Span
+}
+
+// NewMongoMonitor creates the command monitor of the mongo driver wrapping every command in a client span.
+// The contents of the commands aren't recorded, they may contain personal data.
+func NewMongoMonitor() *event.CommandMonitor {
+	m := &mongoMonitor{spans: make(map[int64]trace.Span)}
+	return &event.CommandMonitor{
+		Started:   m.started,
+		Succeeded: m.succeeded,
+		Failed:    m.failed,
+	}
+}
+
+func (m *mongoMonitor) started(ctx context.Context, e *event.CommandStartedEvent) {
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemMongoDB,
+		semconv.DBNameKey.String(e.DatabaseName),
+		semconv.DBOperationKey.String(e.CommandName),
+	}
+	if collection, ok := e.Command.Lookup(e.CommandName).StringValueOK(); ok {
+		attrs = append(attrs, semconv.DBMongoDBCollectionKey.String(collection))
+	}
+
+	_, span := tracer().Start(parent(ctx), "mongo."+e.CommandName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+
+	m.mu.Lock()
+	m.spans[e.RequestID] = span
+	m.mu.Unlock()
+}
+
+func (m *mongoMonitor) succeeded(ctx context.Context, e *event.CommandSucceededEvent) {
+	if span, ok := m.pop(e.RequestID); ok {
+		span.End()
+	}
+}
+
+func (m *mongoMonitor) failed(ctx context.Context, e *event.CommandFailedEvent) {
+	if span, ok := m.pop(e.RequestID); ok {
+		span.SetStatus(codes.Error, e.Failure)
+		span.End()
+	}
+}
+
+func (m *mongoMonitor) pop(id int64) (trace.Span, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	span, ok := m.spans[id]
+	delete(m.spans, id)
+	return span, ok
+}
@@ -0,0 +1,98 @@
+// Package tracing sets up OpenTelemetry and traces the http requests, the mongo commands and the redis commands.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+
+	instrumentationName = "github.com/Alexander272/games-library/pkg/tracing"
+)
+
+type Config struct {
+	ServiceName string
+	// Exporter is none, stdout or otlp. With none the trace context is still propagated, but the spans aren't recorded
+	Exporter string
+	// Endpoint is the url of the OTLP/HTTP collector, e.g. http://localhost:4318
+	Endpoint string
+	Headers  map[string]string
+	Timeout  time.Duration
+	// SampleRatio is the part of the traces started by the service that are recorded
+	SampleRatio float64
+}
+
+// Init sets the global tracer provider and the W3C trace context propagator.
+// The returned function flushes the spans and must be called on shutdown.
+func Init(conf Config) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	switch conf.Exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter = NewStdoutExporter(os.Stdout)
+	case ExporterOTLP:
+		if conf.Endpoint == "" {
+			return nil, fmt.Errorf("endpoint is required for the %s exporter", conf.Exporter)
+		}
+		exporter = NewOTLPExporter(conf.Endpoint, conf.Headers, conf.Timeout)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", conf.Exporter)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceNameKey.String(conf.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource. error: %w", err)
+	}
+
+	ratio := conf.SampleRatio
+	if ratio <= 0 {
+		ratio = 1
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// spanKey is a plain string, so gin.Context passed to the services as the context finds
+// the span of the request in its keys, the same way as the logger of the request.
+const spanKey = "span"
+
+// parent returns the context with the span of the request set by Middleware.
+func parent(ctx context.Context) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	if span, ok := ctx.Value(spanKey).(trace.Span); ok {
+		return trace.ContextWithSpan(ctx, span)
+	}
+	return ctx
+}